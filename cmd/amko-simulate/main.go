@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/simulate"
+)
+
+func main() {
+	var opts simulate.Options
+	var output, logLevel string
+	flag.StringVar(&opts.Dir, "dir", "", "directory with the GSLBConfig, GDP, GSLBHostRule and member cluster manifests")
+	flag.StringVar(&opts.LeaderCluster, "leader-cluster", "",
+		"member cluster with the GDP and GSLBHostRule objects, defaults to the first member cluster in the GSLBConfig")
+	flag.StringVar(&opts.PKIProfile, "pki-profile", "System-Default-PKI-Profile",
+		"PKI profile for the GslbServices with site persistence but without a PKI profile")
+	flag.StringVar(&output, "o", "", "file to write the GslbService and HealthMonitor JSON to, defaults to stdout")
+	flag.StringVar(&logLevel, "log-level", "ERROR", "AMKO log level, the logs are written to stdout")
+	flag.Parse()
+
+	if opts.Dir == "" {
+		fmt.Fprintln(os.Stderr, "-dir is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if !gslbutils.IsLogLevelValid(logLevel) {
+		fmt.Fprintf(os.Stderr, "invalid log level %s\n", logLevel)
+		os.Exit(2)
+	}
+	utils.AviLog.SetLevel(logLevel)
+
+	result, err := simulate.Run(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error in simulating %s: %v\n", opts.Dir, err)
+		os.Exit(1)
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error in marshalling the result: %v\n", err)
+		os.Exit(1)
+	}
+	data = append(data, '\n')
+	if output == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error in writing %s: %v\n", output, err)
+		os.Exit(1)
+	}
+}
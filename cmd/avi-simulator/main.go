@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// avi-simulator serves a stateful, in-memory Avi controller API, which AMKO can be pointed to for
+// end to end tests without a controller.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/test/mockaviserver"
+)
+
+// seedFlags are the objType=file pairs of the -seed flag.
+type seedFlags []string
+
+func (s *seedFlags) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *seedFlags) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("seed %s isn't of the form objType=file", value)
+	}
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	var seeds seedFlags
+	addr := flag.String("addr", ":8443", "address to serve the Avi API on")
+	plainHTTP := flag.Bool("http", false, "serve HTTP instead of HTTPS")
+	certFile := flag.String("tls-cert", "", "TLS certificate file, a self signed certificate is used if not set")
+	keyFile := flag.String("tls-key", "", "TLS key file")
+	logLevel := flag.String("log-level", "INFO", "log level")
+	flag.Var(&seeds, "seed", "objType=file with the objects to load on start up, e.g. healthmonitor=hm_mock.json, can be repeated")
+	flag.Parse()
+
+	if !gslbutils.IsLogLevelValid(*logLevel) {
+		fmt.Fprintf(os.Stderr, "invalid log level %s\n", *logLevel)
+		os.Exit(2)
+	}
+	utils.AviLog.SetLevel(*logLevel)
+
+	sim := mockaviserver.NewAviSimulator()
+	for _, seed := range seeds {
+		objType, file, _ := strings.Cut(seed, "=")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error in reading %s: %v\n", file, err)
+			os.Exit(1)
+		}
+		if err := sim.Load(objType, data); err != nil {
+			fmt.Fprintf(os.Stderr, "error in loading %s: %v\n", file, err)
+			os.Exit(1)
+		}
+	}
+
+	var err error
+	switch {
+	case *plainHTTP:
+		gslbutils.Logf("serving the Avi API over HTTP on %s", *addr)
+		err = http.ListenAndServe(*addr, sim)
+	case *certFile != "":
+		gslbutils.Logf("serving the Avi API over HTTPS on %s", *addr)
+		err = http.ListenAndServeTLS(*addr, *certFile, *keyFile, sim)
+	default:
+		err = serveSelfSigned(*addr, sim)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error in serving the Avi API: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// serveSelfSigned serves the simulator over HTTPS with the self signed certificate of httptest,
+// AMKO doesn't verify the controller's certificate unless a CA is configured.
+func serveSelfSigned(addr string, sim *mockaviserver.AviSimulator) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := httptest.NewUnstartedServer(sim)
+	server.Listener.Close()
+	server.Listener = listener
+	server.StartTLS()
+	gslbutils.Logf("serving the Avi API over HTTPS on %s", server.URL)
+	select {}
+}
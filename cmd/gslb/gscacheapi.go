@@ -24,6 +24,7 @@ import (
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/apiserver"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
 )
 
 type GSCacheAPI struct{}
@@ -100,12 +101,43 @@ func HmCacheHandler(w http.ResponseWriter, r *http.Request) {
 	apiserver.WriteToResponse(w, objs)
 }
 
+type PlanAPI struct{}
+
+func (p PlanAPI) InitModel() {}
+
+func (p PlanAPI) ApiOperationMap(prometheusEnabled bool, reg *prometheus.Registry) []models.OperationMap {
+	get := models.OperationMap{
+		Route:   "/api/plan",
+		Method:  "GET",
+		Handler: PlanHandler,
+	}
+	return []models.OperationMap{get}
+}
+
+// PlanHandler returns the operations planned on the Avi controller in the dry run mode.
+func PlanHandler(w http.ResponseWriter, r *http.Request) {
+	plan := rest.SharedOperationPlan()
+
+	names, ok := r.URL.Query()["name"]
+	if ok {
+		tenant := gslbutils.GetTenant()
+		if tenants, exists := r.URL.Query()["tenant"]; exists {
+			tenant = tenants[0]
+		}
+		apiserver.WriteToResponse(w, plan.Get(tenant+"/"+names[0]))
+		return
+	}
+	apiserver.WriteToResponse(w, plan.GetAll())
+}
+
 func InitAmkoAPIServer() {
 	modelList := []models.ApiModel{
 		apiserver.AcceptedIngressAPI{},
 		apiserver.RejectedIngressAPI{},
 		apiserver.AcceptedLBSvcAPI{},
 		apiserver.RejectedLBSvcAPI{},
+		apiserver.AcceptedHTTPRouteAPI{},
+		apiserver.RejectedHTTPRouteAPI{},
 		apiserver.AcceptedRouteAPI{},
 		apiserver.RejectedRouteAPI{},
 		apiserver.FilterAPI{},
@@ -113,8 +145,10 @@ func InitAmkoAPIServer() {
 		apiserver.GSGraphAPI{},
 		GSCacheAPI{},
 		HmCacheAPI{},
+		PlanAPI{},
 	}
-	amkoAPIServer := api.NewServer("8080", modelList, false, nil)
+	cache.RegisterCacheMetrics()
+	amkoAPIServer := api.NewServer("8080", modelList, gslbutils.IsPrometheusEnabled(), gslbutils.GetMetricsRegistry())
 	amkoAPIServer.InitApi()
 
 	apiserver.SetAmkoAPIServer(amkoAPIServer)
@@ -38,6 +38,20 @@ type AMKOClusterSpec struct {
 // AMKOClusterStatus defines the observed state of AMKOCluster
 type AMKOClusterStatus struct {
 	Conditions []AMKOClusterCondition `json:"conditions,omitempty"`
+
+	// MemberClusters contain the connectivity state of each member cluster, as seen by AMKO
+	MemberClusters []MemberClusterStatus `json:"memberClusters,omitempty"`
+}
+
+// MemberClusterStatus defines the connectivity state of a member cluster
+type MemberClusterStatus struct {
+	Cluster string `json:"cluster,omitempty"`
+	// State is one of Healthy, Unhealthy or Recovering
+	State  string `json:"state,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// StaleObjects is the number of objects whose deletion is yet to be confirmed by the cluster
+	StaleObjects       int         `json:"staleObjects,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
 type AMKOClusterCondition struct {
@@ -127,6 +127,13 @@ func (in *AMKOClusterStatus) DeepCopyInto(out *AMKOClusterStatus) {
 		*out = make([]AMKOClusterCondition, len(*in))
 		copy(*out, *in)
 	}
+	if in.MemberClusters != nil {
+		in, out := &in.MemberClusters, &out.MemberClusters
+		*out = make([]MemberClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AMKOClusterStatus.
@@ -138,3 +145,19 @@ func (in *AMKOClusterStatus) DeepCopy() *AMKOClusterStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberClusterStatus) DeepCopyInto(out *MemberClusterStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberClusterStatus.
+func (in *MemberClusterStatus) DeepCopy() *MemberClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
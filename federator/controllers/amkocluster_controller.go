@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -47,6 +48,7 @@ type AMKOClusterReconciler struct {
 //+kubebuilder:rbac:groups=amko.vmware.com,resources=amkoclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=amko.vmware.com,resources=gslbconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=amko.vmware.com,resources=globaldeploymentpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=amko.vmware.com,resources=gslbhostrules,verbs=get;list;watch;create;update;patch;delete
 
 func (r *AMKOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = log.FromContext(ctx)
@@ -80,7 +82,7 @@ func (r *AMKOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	defer r.UpdateStatus(updatedAMKOCluster)
 
-	// the Reconcile function can be called for 3 objects: AMKOCluster, GC and GDP objects
+	// the Reconcile function can be called for 4 objects: AMKOCluster, GC, GDP and GSLBHostRule objects
 	// we have to determine what kind of an object this function is getting called for.
 	if IsObjAMKOClusterType(ctx, req.Name) {
 		if err != nil && k8serrors.IsNotFound(err) {
@@ -131,6 +133,11 @@ func (r *AMKOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrlResultRequeue, err
 	}
 
+	// Federate the GSLBHostRule objects on all member clusters
+	if err := r.FederateGSLBHostRulesAndUpdateStatus(ctx, validClusters, updatedAMKOCluster); err != nil {
+		return ctrlResultRequeue, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -233,12 +240,39 @@ func (r *AMKOClusterReconciler) FederateGDPAndUpdateStatus(ctx context.Context,
 	if err != nil {
 		// errors on which the execution will stop here and will be retried:
 		// - CRD for GDP is absent in the current cluster
-		// - more than one GDPs in the current cluster
 		return fmt.Errorf("error in federating GDP object: %v", err)
 	}
 	return nil
 }
 
+func (r *AMKOClusterReconciler) FederateGSLBHostRulesAndUpdateStatus(ctx context.Context, validClusters []KubeContextDetails,
+	amkoCluster *amkov1alpha1.AMKOCluster) error {
+	objErrClusters, errClusters, err := r.FederateGSLBHostRules(ctx, validClusters)
+	if statusErr := r.UpdateAMKOClusterStatus(ctx, GSLBHostRuleFederationStatusType, "",
+		getErrorMsg(err), errClusters, amkoCluster); statusErr != nil {
+		return statusErr
+	}
+	if err != nil {
+		// errors on which the execution will stop here and will be retried:
+		// - CRD for GSLBHostRule is absent in the current cluster
+		return fmt.Errorf("error in federating GSLBHostRule objects: %v", err)
+	}
+
+	// report the federation status of each GSLBHostRule
+	keys := make([]string, 0, len(objErrClusters))
+	for key := range objErrClusters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if statusErr := r.UpdateAMKOClusterObjStatus(ctx, GSLBHostRuleFederationStatusType, key, "",
+			"", objErrClusters[key], amkoCluster); statusErr != nil {
+			return statusErr
+		}
+	}
+	return nil
+}
+
 func (r *AMKOClusterReconciler) FederateGSLBConfig(ctx context.Context, memberClusters []KubeContextDetails) ([]ClusterErrorMsg, error) {
 	// Determine the state that we need to federate across all member clusters
 	var currGCList gslbalphav1.GSLBConfigList
@@ -269,32 +303,36 @@ func (r *AMKOClusterReconciler) FederateGSLBConfig(ctx context.Context, memberCl
 
 func (r *AMKOClusterReconciler) FederateGDP(ctx context.Context, memberClusters []KubeContextDetails) ([]ClusterErrorMsg, error) {
 	// Determine the state that we need to federate across all member clusters
+	// GDP objects can be present in avi-system as well as in the application namespaces
 	var currGDPList gdpalphav2.GlobalDeploymentPolicyList
-	err := r.List(ctx, &currGDPList, &client.ListOptions{
-		Namespace: AviSystemNS,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("cannot list GlobalDeploymentPolicy list on current cluster in %s namespace: %v",
-			AviSystemNS, err)
+	if err := r.List(ctx, &currGDPList); err != nil {
+		return nil, fmt.Errorf("cannot list GlobalDeploymentPolicy list on current cluster: %v", err)
 	}
 
-	if len(currGDPList.Items) > 1 {
-		return nil, fmt.Errorf("more than one GlobalDeploymentPolicies are present in the current cluster")
-	}
+	// make sure that all the member clusters have only these GDP objects, if no GDP objects exist,
+	// the GDPs on all member clusters (if any) will be deleted
+	return FederateGDPObjectsOnMemberClusters(ctx, memberClusters, currGDPList.Items), nil
+}
 
-	// if no GDP objects exist, we need to sync all member clusters to the same state
-	// which would mean, we need to delete GDPs on all member clusters (if any)
-	if len(currGDPList.Items) == 0 {
-		return DeleteObjsOnAllMemberClusters(ctx, memberClusters, AviSystemNS, &gdpalphav2.GlobalDeploymentPolicy{}), nil
+func (r *AMKOClusterReconciler) FederateGSLBHostRules(ctx context.Context,
+	memberClusters []KubeContextDetails) (map[string][]ClusterErrorMsg, []ClusterErrorMsg, error) {
+	// GSLBHostRules can be present in any namespace, determine the state that we need to
+	// federate across all member clusters
+	var currGSLBHRList gslbalphav1.GSLBHostRuleList
+	if err := r.List(ctx, &currGSLBHRList); err != nil {
+		return nil, nil, fmt.Errorf("cannot list GSLBHostRule list on current cluster: %v", err)
 	}
-	// if a GDP object exists in the current cluster, we need to make sure that all the member
-	// clusters have only this GDP object in the avi-system namespace.
-	return FederateGDPObjectOnMemberClusters(ctx, memberClusters, currGDPList.Items[0].DeepCopy()), nil
+
+	// if no GSLBHostRules exist, the GSLBHostRules on all member clusters (if any) will be deleted
+	objErrClusters, errClusters := FederateGSLBHostRuleObjectsOnMemberClusters(ctx, memberClusters,
+		currGSLBHRList.Items)
+	return objErrClusters, errClusters, nil
 }
 
 func (r *AMKOClusterReconciler) GetObjectsToBeFederated(ctx context.Context) ([]client.Object, error) {
 	// - List all gslb config objects (has to be only 1)
-	// - List all GDP objects (has to be only 1)
+	// - List all GDP objects across all namespaces
+	// - List all GSLBHostRule objects across all namespaces
 	// - append them to a client.Object list
 	// - return this list
 
@@ -315,18 +353,23 @@ func (r *AMKOClusterReconciler) GetObjectsToBeFederated(ctx context.Context) ([]
 	objList = append(objList, gcObj)
 
 	var gdpList gdpalphav2.GlobalDeploymentPolicyList
-	err = r.List(ctx, &gdpList, &client.ListOptions{
-		Namespace: AviSystemNS,
-	})
+	err = r.List(ctx, &gdpList)
 	if err != nil {
-		return nil, fmt.Errorf("cannot list GDP list on current cluster in avi-system namespace: %v", err)
+		return nil, fmt.Errorf("cannot list GDP list on current cluster: %v", err)
+	}
+
+	for idx := range gdpList.Items {
+		objList = append(objList, gdpList.Items[idx].DeepCopy())
 	}
 
-	if len(gdpList.Items) > 1 {
-		return nil, fmt.Errorf("more than one GDP objects are present in current cluster")
+	var gslbhrList gslbalphav1.GSLBHostRuleList
+	err = r.List(ctx, &gslbhrList)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list GSLBHostRule list on current cluster: %v", err)
+	}
+	for idx := range gslbhrList.Items {
+		objList = append(objList, gslbhrList.Items[idx].DeepCopy())
 	}
-	gdpObj := gdpList.Items[0].DeepCopy()
-	objList = append(objList, gdpObj)
 
 	return objList, nil
 }
@@ -358,6 +401,8 @@ func (r *AMKOClusterReconciler) UpdateStatus(updatedAMKOCluster *amkov1alpha1.AM
 		return
 	}
 
+	// the member cluster states are owned by AMKO, retain the latest ones
+	updatedAMKOCluster.Status.MemberClusters = currAMKOClusterList.Items[0].Status.MemberClusters
 	// currAMKOClusterList.Items[0].Status.Conditions = []amkov1alpha1.AMKOClusterCondition{}
 	log.Log.Info("updated AMKO Cluster status", "status", updatedAMKOCluster.Status.Conditions)
 	if err := r.PatchAMKOClusterStatus(context.TODO(), &currAMKOClusterList.Items[0],
@@ -376,7 +421,29 @@ func (r *AMKOClusterReconciler) UpdateAMKOClusterStatus(ctx context.Context, sta
 		return err
 	}
 	log.Log.Info("status condition", "condition", condition)
+	setAMKOClusterCondition(updatedAMKOCluster, condition)
+	return nil
+}
+
+// UpdateAMKOClusterObjStatus updates the status condition of statusType for an individual object
+// identified by objKey.
+func (r *AMKOClusterReconciler) UpdateAMKOClusterObjStatus(ctx context.Context, statusType int,
+	objKey, statusMsg, reason string, errClusters []ClusterErrorMsg,
+	updatedAMKOCluster *amkov1alpha1.AMKOCluster) error {
+
+	condition, err := getStatusCondition(statusType, statusMsg, reason, errClusters)
+	if err != nil {
+		log.Log.Error(err, "error while generating status condition")
+		return err
+	}
+	condition.Type = GetObjStatusType(condition.Type, objKey)
+	log.Log.Info("status condition", "condition", condition)
+	setAMKOClusterCondition(updatedAMKOCluster, condition)
+	return nil
+}
 
+func setAMKOClusterCondition(updatedAMKOCluster *amkov1alpha1.AMKOCluster,
+	condition amkov1alpha1.AMKOClusterCondition) {
 	// get the previous status
 	conditions := updatedAMKOCluster.Status.Conditions
 	if len(conditions) == 0 {
@@ -384,7 +451,7 @@ func (r *AMKOClusterReconciler) UpdateAMKOClusterStatus(ctx context.Context, sta
 		updatedAMKOCluster.Status.Conditions = []amkov1alpha1.AMKOClusterCondition{
 			condition,
 		}
-		return nil
+		return
 	}
 
 	// conditions already present, update the one that we need for statusType
@@ -393,13 +460,12 @@ func (r *AMKOClusterReconciler) UpdateAMKOClusterStatus(ctx context.Context, sta
 			updatedAMKOCluster.Status.Conditions[idx].Type = condition.Type
 			updatedAMKOCluster.Status.Conditions[idx].Status = condition.Status
 			updatedAMKOCluster.Status.Conditions[idx].Reason = condition.Reason
-			return nil
+			return
 		}
 	}
 
 	// no such condition with status type, add a new one
 	updatedAMKOCluster.Status.Conditions = append(updatedAMKOCluster.Status.Conditions, condition)
-	return nil
 }
 
 func (r *AMKOClusterReconciler) PatchAMKOClusterStatus(ctx context.Context, amkoCluster, updatedAMKOCluster *amkov1alpha1.AMKOCluster) error {
@@ -427,6 +493,18 @@ func (r *AMKOClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				}
 			}),
 		).
+		Watches(&gslbalphav1.GSLBHostRule{},
+			handler.EnqueueRequestsFromMapFunc(func(c context.Context, o client.Object) []reconcile.Request {
+				return []reconcile.Request{
+					{
+						NamespacedName: types.NamespacedName{
+							Name:      o.GetName() + GSLBHRSuffix,
+							Namespace: o.GetNamespace(),
+						},
+					},
+				}
+			}),
+		).
 		Watches(&gdpalphav2.GlobalDeploymentPolicy{},
 			handler.EnqueueRequestsFromMapFunc(func(c context.Context, o client.Object) []reconcile.Request {
 				return []reconcile.Request{
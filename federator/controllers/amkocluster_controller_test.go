@@ -181,6 +181,58 @@ var _ = Describe("AMKOClusterReconciler Unit Tests", func() {
 		})
 	})
 
+	Context("UpdateAMKOClusterObjStatus", func() {
+		It("should add a status condition for each object", func() {
+			amkoCluster := createUnitTestAMKOCluster("test-amko-cluster", AviSystemNS, "1.0.0", "cluster1", true)
+			updatedCluster := amkoCluster.DeepCopy()
+			errClusters := []ClusterErrorMsg{
+				{
+					cname: "cluster2",
+					err:   errors.New("namespace not found"),
+				},
+			}
+
+			err := (&AMKOClusterReconciler{}).UpdateAMKOClusterObjStatus(ctx, GSLBHostRuleFederationStatusType,
+				"default/hr1", "", "", nil, updatedCluster)
+			Expect(err).ToNot(HaveOccurred())
+			err = (&AMKOClusterReconciler{}).UpdateAMKOClusterObjStatus(ctx, GSLBHostRuleFederationStatusType,
+				"default/hr2", "", "", errClusters, updatedCluster)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(updatedCluster.Status.Conditions).To(HaveLen(2))
+			Expect(updatedCluster.Status.Conditions[0].Type).To(Equal(GSLBHostRuleFederationStatusField + " default/hr1"))
+			Expect(updatedCluster.Status.Conditions[0].Status).To(Equal(StatusGSLBHostRuleFederationSuccess))
+			Expect(updatedCluster.Status.Conditions[1].Type).To(Equal(GSLBHostRuleFederationStatusField + " default/hr2"))
+			Expect(updatedCluster.Status.Conditions[1].Status).To(Equal(StatusSomeGSLBHostRuleFederationFailure))
+			Expect(updatedCluster.Status.Conditions[1].Reason).To(ContainSubstring("namespace not found"))
+		})
+
+		It("should update the status condition of an object", func() {
+			amkoCluster := createUnitTestAMKOCluster("test-amko-cluster", AviSystemNS, "1.0.0", "cluster1", true)
+			amkoCluster.Status.Conditions = []amkov1alpha1.AMKOClusterCondition{
+				{
+					Type:   GSLBHostRuleFederationStatusField,
+					Status: StatusGSLBHostRuleFederationSuccess,
+				},
+				{
+					Type:   GSLBHostRuleFederationStatusField + " default/hr1",
+					Status: StatusSomeGSLBHostRuleFederationFailure,
+					Reason: "test error",
+				},
+			}
+			updatedCluster := amkoCluster.DeepCopy()
+
+			err := (&AMKOClusterReconciler{}).UpdateAMKOClusterObjStatus(ctx, GSLBHostRuleFederationStatusType,
+				"default/hr1", "", "", nil, updatedCluster)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updatedCluster.Status.Conditions).To(HaveLen(2))
+			Expect(updatedCluster.Status.Conditions[0].Status).To(Equal(StatusGSLBHostRuleFederationSuccess))
+			Expect(updatedCluster.Status.Conditions[1].Status).To(Equal(StatusGSLBHostRuleFederationSuccess))
+			Expect(updatedCluster.Status.Conditions[1].Reason).To(BeEmpty())
+		})
+	})
+
 	Context("IsObjAMKOClusterType", func() {
 		It("should return true for AMKOCluster type", func() {
 			result := IsObjAMKOClusterType(ctx, "test-amko-cluster")
@@ -197,6 +249,11 @@ var _ = Describe("AMKOClusterReconciler Unit Tests", func() {
 			Expect(result).To(BeFalse())
 		})
 
+		It("should return false for GSLBHostRule suffix", func() {
+			result := IsObjAMKOClusterType(ctx, "test-gslbhr"+GSLBHRSuffix)
+			Expect(result).To(BeFalse())
+		})
+
 		It("should return true for name without special suffix", func() {
 			result := IsObjAMKOClusterType(ctx, "my-amko-cluster-name")
 			Expect(result).To(BeTrue())
@@ -335,6 +392,7 @@ var _ = Describe("AMKOClusterReconciler Unit Tests", func() {
 				MemberValidationStatusType,
 				GSLBConfigFederationStatusType,
 				GDPFederationStatusType,
+				GSLBHostRuleFederationStatusType,
 			}
 
 			for _, statusType := range statusTypes {
@@ -30,6 +30,7 @@ const (
 	MemberValidationStatusType             = 2
 	GSLBConfigFederationStatusType         = 3
 	GDPFederationStatusType                = 4
+	GSLBHostRuleFederationStatusType       = 5
 
 	// Status field type values
 	CurrentAMKOClusterValidationStatusField = "current AMKOCluster Validation"
@@ -37,6 +38,7 @@ const (
 	MemberValidationStatusField             = "member cluster validation"
 	GSLBConfigFederationStatusField         = "GSLBConfig Federation"
 	GDPFederationStatusField                = "GDP Federation"
+	GSLBHostRuleFederationStatusField       = "GSLBHostRule Federation"
 
 	StatusMsgInvalidAMKOCluster = "invalid AMKOCluster object"
 	StatusMsgValidAMKOCluster   = "valid AMKOCluster object"
@@ -57,6 +59,10 @@ const (
 	StatusSomeGDPFederationFailure = "error in federating to some clusters"
 	StatusGDPFederationSuccess     = "federated to all valid clusters successfully"
 
+	StatusGSLBHostRuleFederationFailure     = "failure in federation"
+	StatusSomeGSLBHostRuleFederationFailure = "error in federating to some clusters"
+	StatusGSLBHostRuleFederationSuccess     = "federated to all valid clusters successfully"
+
 	StatusMsgFederationFailure = "failure in federating objects"
 	StatusMsgFederationSuccess = "federation successful"
 	StatusMsgNotALeader        = "won't federate objects"
@@ -109,6 +115,19 @@ var statusMsgTypes map[int]*StatusMsgRecord = map[int]*StatusMsgRecord{
 		someFailed: StatusSomeGDPFederationFailure,
 		success:    StatusGDPFederationSuccess,
 	},
+
+	GSLBHostRuleFederationStatusType: {
+		statusType: GSLBHostRuleFederationStatusField,
+		allFailed:  StatusGSLBHostRuleFederationFailure,
+		someFailed: StatusSomeGSLBHostRuleFederationFailure,
+		success:    StatusGSLBHostRuleFederationSuccess,
+	},
+}
+
+// GetObjStatusType returns the status type for an individual object, used when the federation
+// status is reported per object.
+func GetObjStatusType(statusType, objKey string) string {
+	return statusType + " " + objKey
 }
 
 func GetClusterErrMsg(errClusters []ClusterErrorMsg) string {
@@ -595,6 +595,79 @@ var _ = Describe("Federation Consolidation Operations", func() {
 	})
 })
 
+var _ = Describe("GSLBHostRule Federation Operations", func() {
+	amkoCluster1 := getTestAMKOClusterObj(Cluster1, true)
+	amkoCluster2 := getTestAMKOClusterObj(Cluster2, false)
+	gcObj := getTestGCObj()
+	gdpObj := getTestGDPObject()
+	gslbhrObj := getTestGSLBHostRuleObj()
+	gslbhrKey := GetGSLBHostRuleKey(&gslbhrObj)
+
+	//   when a valid AMKOCluster object is present on both clusters and a GSLBHostRule is added
+	//     federator should federate the GSLBHostRule and its updates on cluster2
+	//     status should reflect the federation success for the GSLBHostRule
+	//     federator should delete the GSLBHostRule on cluster2 once it is deleted on cluster1
+	Context("when a valid AMKOCluster object is added to both clusters and a GSLBHostRule is added", func() {
+		Specify("AMKOCluster's federation status should indicate success", func() {
+			By("Creating a valid AMKOCluster object on both the clusters")
+			ctx := context.Background()
+			createTestGCAndGDPObjs(ctx, k8sClient1, &gcObj, &gdpObj)
+			Expect(k8sClient1.Create(ctx, &gslbhrObj)).Should(Succeed())
+			Expect(k8sClient1.Create(ctx, &amkoCluster1)).Should(Succeed())
+			Expect(k8sClient2.Create(ctx, &amkoCluster2)).Should(Succeed())
+			VerifySuccessForAllStatusFields(k8sClient1)
+			VerifyTestAMKOClusterStatus(k8sClient1, GetObjStatusType(GSLBHostRuleFederationStatusField, gslbhrKey),
+				StatusGSLBHostRuleFederationSuccess, "")
+		})
+
+		It("should federate the GSLBHostRule on cluster2", func() {
+			ctx := context.Background()
+			Eventually(func() string {
+				var obj gslbalphav1.GSLBHostRule
+				if err := k8sClient2.Get(ctx, types.NamespacedName{Name: gslbhrObj.Name,
+					Namespace: gslbhrObj.Namespace}, &obj); err != nil {
+					return ""
+				}
+				return obj.Spec.Fqdn
+			}, 5*time.Second, 1*time.Second).Should(Equal(TestGSLBHRFqdn))
+		})
+
+		It("should federate GSLBHostRule updates to cluster2", func() {
+			ctx := context.Background()
+			var obj gslbalphav1.GSLBHostRule
+			Expect(k8sClient1.Get(ctx, types.NamespacedName{Name: gslbhrObj.Name,
+				Namespace: gslbhrObj.Namespace}, &obj)).Should(Succeed())
+			obj.Spec.TTL = getGDPTTLPtr(60)
+			Expect(k8sClient1.Update(ctx, &obj)).Should(Succeed())
+
+			Eventually(func() int {
+				var remoteObj gslbalphav1.GSLBHostRule
+				if err := k8sClient2.Get(ctx, types.NamespacedName{Name: gslbhrObj.Name,
+					Namespace: gslbhrObj.Namespace}, &remoteObj); err != nil || remoteObj.Spec.TTL == nil {
+					return 0
+				}
+				return *remoteObj.Spec.TTL
+			}, 5*time.Second, 1*time.Second).Should(Equal(60))
+		})
+
+		It("should delete the GSLBHostRule on cluster2 when the GSLBHostRule on cluster1 is deleted", func() {
+			ctx := context.Background()
+			Expect(k8sClient1.Delete(ctx, &gslbhrObj)).Should(Succeed())
+
+			Eventually(func() int {
+				gslbhrList := gslbalphav1.GSLBHostRuleList{}
+				Expect(k8sClient2.List(ctx, &gslbhrList)).Should(Succeed())
+				return len(gslbhrList.Items)
+			}, 5*time.Second, 1*time.Second).Should(BeZero())
+		})
+
+		Specify("deletion of AMKOCluster, GC and GDP is successful", func() {
+			CleanupTestObjects(k8sClient1, k8sClient2, &amkoCluster1, &amkoCluster2,
+				&gcObj, &gdpObj)
+		})
+	})
+})
+
 var _ = AfterSuite(func() {
 	By("tearing down the test environment")
 	cancel()
@@ -52,6 +52,8 @@ const (
 	AMKOCRDs                 = "../../helm/amko/crds"
 	TestGCName               = "test-gc"
 	TestGDPName              = "test-gdp"
+	TestGSLBHRName           = "test-gslbhr"
+	TestGSLBHRFqdn           = "test.example.com"
 	TestLeaderIP             = "10.10.10.10"
 )
 
@@ -274,6 +276,19 @@ func getTestGDPObject() gdpalphav2.GlobalDeploymentPolicy {
 	}
 }
 
+func getTestGSLBHostRuleObj() gslbalphav1.GSLBHostRule {
+	return gslbalphav1.GSLBHostRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TestGSLBHRName,
+			Namespace: AviSystemNS,
+		},
+		Spec: gslbalphav1.GSLBHostRuleSpec{
+			Fqdn: TestGSLBHRFqdn,
+			TTL:  getGDPTTLPtr(30),
+		},
+	}
+}
+
 func getGDPTTLPtr(val int) *int {
 	ttl := val
 	return &ttl
@@ -358,4 +373,6 @@ func VerifySuccessForAllStatusFields(k8sClient client.Client) {
 		StatusGSLBConfigFederationSuccess, "")
 	VerifyTestAMKOClusterStatus(k8sClient, GDPFederationStatusField,
 		StatusGDPFederationSuccess, "")
+	VerifyTestAMKOClusterStatus(k8sClient, GSLBHostRuleFederationStatusField,
+		StatusGSLBHostRuleFederationSuccess, "")
 }
@@ -47,11 +47,14 @@ const (
 	MembersKubePath       = "/tmp/members-kubeconfig"
 	GCSuffix              = "--amko.gslbconfig-"
 	GDPSuffix             = "--amko.gdp-"
+	GSLBHRSuffix          = "--amko.gslbhr-"
 	AMKOGroup             = "amko.vmware.com"
 	GCKind                = "GSLBConfig"
 	GDPKind               = "GlobalDeploymentPolicy"
+	GSLBHRKind            = "GSLBHostRule"
 	GCVersion             = "v1alpha1"
 	GDPVersion            = "v1alpha2"
+	GSLBHRVersion         = "v1alpha1"
 	FederatorFieldManager = "AMKO-Federator"
 )
 
@@ -67,13 +70,20 @@ var gdpGVK schema.GroupVersionKind = schema.GroupVersionKind{
 	Version: GDPVersion,
 }
 
+var gslbhrGVK schema.GroupVersionKind = schema.GroupVersionKind{
+	Group:   AMKOGroup,
+	Kind:    GSLBHRKind,
+	Version: GSLBHRVersion,
+}
+
 type ClusterErrorMsg struct {
 	cname string
 	err   error
 }
 
 func IsObjAMKOClusterType(ctx context.Context, name string) bool {
-	if strings.HasSuffix(name, GCSuffix) || strings.HasSuffix(name, GDPSuffix) {
+	if strings.HasSuffix(name, GCSuffix) || strings.HasSuffix(name, GDPSuffix) ||
+		strings.HasSuffix(name, GSLBHRSuffix) {
 		return false
 	}
 	return true
@@ -124,6 +134,10 @@ func UpdateObjOnMemberCluster(ctx context.Context, c client.Client, source,
 		sourceGDP := source.(*gdpalphav2.GlobalDeploymentPolicy)
 		targetGDP := target.(*gdpalphav2.GlobalDeploymentPolicy)
 		sourceGDP.Spec.DeepCopyInto(&targetGDP.Spec)
+	case gslbhrGVK:
+		sourceGSLBHR := source.(*gslbalphav1.GSLBHostRule)
+		targetGSLBHR := target.(*gslbalphav1.GSLBHostRule)
+		sourceGSLBHR.Spec.DeepCopyInto(&targetGSLBHR.Spec)
 	default:
 		return fmt.Errorf("can't federate an unsupported object on cluster %s, object type: %v", cname, sourceGVK)
 	}
@@ -226,73 +240,165 @@ func FederateGCObjectOnMemberClusters(ctx context.Context, memberClusters []Kube
 	return errClusters
 }
 
-func FederateGDPObjectOnMemberClusters(ctx context.Context, memberClusters []KubeContextDetails,
-	currObj *gdpalphav2.GlobalDeploymentPolicy) []ClusterErrorMsg {
+// GetGDPKey returns the key with which a GDP object is federated, GDP objects can be present in
+// the avi-system namespace as well as in the application namespaces.
+func GetGDPKey(obj *gdpalphav2.GlobalDeploymentPolicy) string {
+	return obj.Namespace + "/" + obj.Name
+}
+
+// FederateGDPObjectsOnMemberClusters makes sure that all the member clusters have only the GDP
+// objects in currObjs, across all namespaces.
+func FederateGDPObjectsOnMemberClusters(ctx context.Context, memberClusters []KubeContextDetails,
+	currObjs []gdpalphav2.GlobalDeploymentPolicy) []ClusterErrorMsg {
 
 	errClusters := []ClusterErrorMsg{}
-	namespace := currObj.Namespace
+	currObjMap := make(map[string]*gdpalphav2.GlobalDeploymentPolicy)
+	for idx := range currObjs {
+		currObjMap[GetGDPKey(&currObjs[idx])] = &currObjs[idx]
+	}
 	for _, m := range memberClusters {
 		clusterClient := *m.client
 		objList := gdpalphav2.GlobalDeploymentPolicyList{}
-		if err := clusterClient.List(ctx, &objList, &client.ListOptions{
-			Namespace: namespace,
-		}); err != nil {
+		if err := clusterClient.List(ctx, &objList); err != nil {
 			errClusters = append(errClusters, ClusterErrorMsg{
 				cname: m.clusterName,
-				err: fmt.Errorf("can't list GlobalDeploymentPolicies in %s namespace for %s cluster: %v",
-					namespace, m.clusterName, err),
+				err:   fmt.Errorf("can't list GlobalDeploymentPolicies for %s cluster: %v", m.clusterName, err),
 			})
 			continue
 		}
 
-		// go through the list of GDP objects in the namespace, if we find the relevant GDP, just
-		// update and return
-		updated := false
+		// go through the list of GDP objects, update the GDPs present in the current cluster
+		// and delete the rest
+		existing := make(map[string]struct{})
 		for _, remoteObj := range objList.Items {
-			if remoteObj.Name == currObj.Name {
-				if err := UpdateObjOnMemberCluster(ctx, clusterClient,
-					currObj, remoteObj.DeepCopy(), m.clusterName); err != nil {
+			currObj, ok := currObjMap[GetGDPKey(&remoteObj)]
+			if !ok {
+				if err := DeleteObjInMemberCluster(ctx, clusterClient, remoteObj.DeepCopy(),
+					m.clusterName); err != nil {
 					errClusters = append(errClusters, ClusterErrorMsg{
 						cname: m.clusterName,
 						err:   err,
 					})
-					continue
 				}
-				updated = true
-			} else {
-				// delete rest of the GDPs (for which names don't match with the current cluster's GDP)
+				continue
+			}
+			existing[GetGDPKey(&remoteObj)] = struct{}{}
+			if err := UpdateObjOnMemberCluster(ctx, clusterClient,
+				currObj, remoteObj.DeepCopy(), m.clusterName); err != nil {
+				errClusters = append(errClusters, ClusterErrorMsg{
+					cname: m.clusterName,
+					err:   err,
+				})
+			}
+		}
+
+		// create the GDP objects which are missing on the member cluster
+		for idx := range currObjs {
+			if _, ok := existing[GetGDPKey(&currObjs[idx])]; ok {
+				continue
+			}
+			newObj := currObjs[idx].DeepCopy()
+			newObj.ResourceVersion = ""
+			if err := clusterClient.Create(ctx, newObj, &client.CreateOptions{
+				FieldManager: FederatorFieldManager,
+			}); err != nil {
+				errClusters = append(errClusters, ClusterErrorMsg{
+					cname: m.clusterName,
+					err: fmt.Errorf("error in creating GDP object %s/%s on cluster %s: %v",
+						newObj.Namespace, newObj.Name, m.clusterName, err),
+				})
+				continue
+			}
+		}
+	}
+
+	return errClusters
+}
+
+// GetGSLBHostRuleKey returns the key with which the federation status of a GSLBHostRule is reported.
+func GetGSLBHostRuleKey(obj *gslbalphav1.GSLBHostRule) string {
+	return obj.Namespace + "/" + obj.Name
+}
+
+// FederateGSLBHostRuleObjectsOnMemberClusters makes sure that the member clusters have the same set of
+// GSLBHostRules as the current cluster. GSLBHostRules which are absent in the current cluster are deleted
+// from the member clusters. The errors in federating a GSLBHostRule are returned per GSLBHostRule key,
+// the rest of the errors (listing and deletion) are returned separately.
+func FederateGSLBHostRuleObjectsOnMemberClusters(ctx context.Context, memberClusters []KubeContextDetails,
+	currObjs []gslbalphav1.GSLBHostRule) (map[string][]ClusterErrorMsg, []ClusterErrorMsg) {
+
+	objErrClusters := make(map[string][]ClusterErrorMsg, len(currObjs))
+	errClusters := []ClusterErrorMsg{}
+
+	currObjMap := make(map[string]*gslbalphav1.GSLBHostRule, len(currObjs))
+	for idx := range currObjs {
+		key := GetGSLBHostRuleKey(&currObjs[idx])
+		currObjMap[key] = &currObjs[idx]
+		objErrClusters[key] = []ClusterErrorMsg{}
+	}
+
+	for _, m := range memberClusters {
+		clusterClient := *m.client
+		objList := gslbalphav1.GSLBHostRuleList{}
+		if err := clusterClient.List(ctx, &objList); err != nil {
+			// can't list the GSLBHostRules on this cluster, none of them can be federated here
+			listErr := ClusterErrorMsg{
+				cname: m.clusterName,
+				err:   fmt.Errorf("can't list GSLBHostRules for %s cluster: %v", m.clusterName, err),
+			}
+			errClusters = append(errClusters, listErr)
+			for key := range objErrClusters {
+				objErrClusters[key] = append(objErrClusters[key], listErr)
+			}
+			continue
+		}
+
+		// update the GSLBHostRules which are present in both the clusters and delete the ones
+		// which are no longer present in the current cluster
+		updated := make(map[string]bool)
+		for _, remoteObj := range objList.Items {
+			key := GetGSLBHostRuleKey(&remoteObj)
+			currObj, ok := currObjMap[key]
+			if !ok {
 				if err := DeleteObjInMemberCluster(ctx, clusterClient, remoteObj.DeepCopy(),
 					m.clusterName); err != nil {
 					errClusters = append(errClusters, ClusterErrorMsg{
 						cname: m.clusterName,
 						err:   err,
 					})
-					continue
 				}
+				continue
+			}
+			updated[key] = true
+			if err := UpdateObjOnMemberCluster(ctx, clusterClient, currObj.DeepCopy(), remoteObj.DeepCopy(),
+				m.clusterName); err != nil {
+				objErrClusters[key] = append(objErrClusters[key], ClusterErrorMsg{
+					cname: m.clusterName,
+					err:   err,
+				})
 			}
 		}
 
-		if updated {
-			// update is already done, return
-			continue
-		}
-
-		// reaching here would mean, we need to create the GDP object
-		newObj := currObj.DeepCopy()
-		newObj.ResourceVersion = ""
-		if err := clusterClient.Create(ctx, newObj, &client.CreateOptions{
-			FieldManager: FederatorFieldManager,
-		}); err != nil {
-			errClusters = append(errClusters, ClusterErrorMsg{
-				cname: m.clusterName,
-				err: fmt.Errorf("error in creating GSLBConfig object %s/%s on cluster %s: %v",
-					newObj.Namespace, newObj.Name, m.clusterName, err),
-			})
-			continue
+		// create the rest of the GSLBHostRules
+		for key, currObj := range currObjMap {
+			if updated[key] {
+				continue
+			}
+			newObj := currObj.DeepCopy()
+			newObj.ResourceVersion = ""
+			if err := clusterClient.Create(ctx, newObj, &client.CreateOptions{
+				FieldManager: FederatorFieldManager,
+			}); err != nil {
+				objErrClusters[key] = append(objErrClusters[key], ClusterErrorMsg{
+					cname: m.clusterName,
+					err: fmt.Errorf("error in creating GSLBHostRule %s on cluster %s: %v",
+						key, m.clusterName, err),
+				})
+			}
 		}
 	}
 
-	return errClusters
+	return objErrClusters, errClusters
 }
 
 func InitialiseMemberClusterClient(cfg *restclient.Config) (client.Client, error) {
@@ -191,6 +191,40 @@ func RejectedLBSvcAPIHandler(w http.ResponseWriter, r *http.Request) {
 	FetchIngestionObjectsAndRespond(w, r, gdpalphav2.LBSvcObj, false)
 }
 
+type AcceptedHTTPRouteAPI struct{}
+
+func (ah AcceptedHTTPRouteAPI) InitModel() {}
+
+func (ah AcceptedHTTPRouteAPI) ApiOperationMap(prometheusEnabled bool, reg *prometheus.Registry) []models.OperationMap {
+	get := models.OperationMap{
+		Route:   "/api/accepted/httproute",
+		Method:  "GET",
+		Handler: AcceptedHTTPRouteAPIHandler,
+	}
+	return []models.OperationMap{get}
+}
+
+func AcceptedHTTPRouteAPIHandler(w http.ResponseWriter, r *http.Request) {
+	FetchIngestionObjectsAndRespond(w, r, gslbutils.HTTPRouteType, true)
+}
+
+type RejectedHTTPRouteAPI struct{}
+
+func (rh RejectedHTTPRouteAPI) InitModel() {}
+
+func (rh RejectedHTTPRouteAPI) ApiOperationMap(prometheusEnabled bool, reg *prometheus.Registry) []models.OperationMap {
+	get := models.OperationMap{
+		Route:   "/api/rejected/httproute",
+		Method:  "GET",
+		Handler: RejectedHTTPRouteAPIHandler,
+	}
+	return []models.OperationMap{get}
+}
+
+func RejectedHTTPRouteAPIHandler(w http.ResponseWriter, r *http.Request) {
+	FetchIngestionObjectsAndRespond(w, r, gslbutils.HTTPRouteType, false)
+}
+
 func FetchIngestionObjectsAndRespond(w http.ResponseWriter, r *http.Request, objType string, accepted bool) {
 	var cluster, ns, name string
 
@@ -229,6 +263,12 @@ func FetchIngestionObjectsAndRespond(w http.ResponseWriter, r *http.Request, obj
 		} else {
 			objList = store.GetRejectedIngressStore()
 		}
+	} else if objType == gslbutils.HTTPRouteType {
+		if accepted {
+			objList = store.GetAcceptedHTTPRouteStore()
+		} else {
+			objList = store.GetRejectedHTTPRouteStore()
+		}
 	} else {
 		gslbutils.Errf("Unknown Object type: %s", objType)
 		WriteErrorToResponse(w)
@@ -238,7 +278,7 @@ func FetchIngestionObjectsAndRespond(w http.ResponseWriter, r *http.Request, obj
 	objects := objList.GetAllClusterNSObjects()
 	result := []interface{}{}
 	for _, o := range objects {
-		cname, namespace, sname, err := splitName(gdpalphav2.IngressObj, o)
+		cname, namespace, sname, err := splitName(objType, o)
 		if err != nil {
 			gslbutils.Logf("can't split name for object: %s", o)
 			continue
@@ -265,7 +305,7 @@ func FetchIngestionObjectsAndRespond(w http.ResponseWriter, r *http.Request, obj
 func splitName(objType, objName string) (string, string, string, error) {
 	var cname, ns, sname, hostname string
 	var err error
-	if objType == gdpalphav2.IngressObj {
+	if objType == gdpalphav2.IngressObj || objType == gslbutils.HTTPRouteType {
 		cname, ns, sname, hostname, err = gslbutils.SplitMultiClusterIngHostName(objName)
 		sname += "/" + hostname
 	} else {
@@ -32,8 +32,21 @@ import (
 
 var aviClientInstanceMap sync.Map
 
+// offlineAviClients is set when running without a controller, SharedAviClients then returns an
+// empty pool of connections.
+var offlineAviClients bool
+
+// UseOfflineAviClients makes SharedAviClients return an empty pool of connections for all the
+// tenants, used for building the Avi objects without a controller.
+func UseOfflineAviClients() {
+	offlineAviClients = true
+}
+
 // SharedAviClients initializes a pool of connections to the avi controller
 func SharedAviClients(tenant string) *utils.AviRestClientPool {
+	if offlineAviClients {
+		return &utils.AviRestClientPool{}
+	}
 	aviClientInstance, ok := aviClientInstanceMap.Load(tenant)
 	if ok {
 		return aviClientInstance.(*utils.AviRestClientPool)
@@ -217,3 +230,16 @@ func GetHMFromName(name string, gdp bool, tenant string) (*models.HealthMonitor,
 	}
 	return &hm, nil
 }
+
+// GetGSRuntime fetches the runtime of a GslbService, which has an entry for each GSLB site.
+func GetGSRuntime(uuid, tenant string) ([]models.GslbServiceRuntime, error) {
+	aviClient := SharedAviClients(tenant).AviClient[0]
+	uri := "api/gslbservice/" + uuid + "/runtime"
+
+	var gsRuntime []models.GslbServiceRuntime
+	if err := aviClient.AviSession.Get(uri, &gsRuntime); err != nil {
+		gslbutils.Errf("uri: %s, msg: error in fetching the GslbService runtime: %v", uri, err)
+		return nil, err
+	}
+	return gsRuntime, nil
+}
@@ -67,6 +67,26 @@ type AviHmObj struct {
 	CustomHmSettings *CustomHmSettings
 	Description      string
 	CreatedBy        string
+	// SettingsCksum is the checksum of the health check settings, which aren't a part of the
+	// CloudConfigCksum, but are compared to detect the drift of the health monitor
+	SettingsCksum uint32
+}
+
+// GetHmSettingsChecksum returns the checksum of the health check settings of the health monitor hm,
+// as present on the controller.
+func GetHmSettingsChecksum(hm models.HealthMonitor) uint32 {
+	settings := struct {
+		SendInterval     *int32
+		ReceiveTimeout   *int32
+		SuccessfulChecks *int32
+		FailedChecks     *int32
+		HTTPMonitor      *models.HealthMonitorHTTP
+		HTTPSMonitor     *models.HealthMonitorHTTP
+		TCPMonitor       *models.HealthMonitorTCP
+		UDPMonitor       *models.HealthMonitorUDP
+	}{hm.SendInterval, hm.ReceiveTimeout, hm.SuccessfulChecks, hm.FailedChecks, hm.HTTPMonitor, hm.HTTPSMonitor,
+		hm.TCPMonitor, hm.UDPMonitor}
+	return utils.Hash(utils.Stringify(settings))
 }
 
 type AviHmCache struct {
@@ -111,6 +131,12 @@ func (h *AviHmCache) AviHmGetAllKeys() []interface{} {
 	return hmKeys
 }
 
+func (h *AviHmCache) AviHmCacheLen() int {
+	h.cacheLock.RLock()
+	defer h.cacheLock.RUnlock()
+	return len(h.Cache)
+}
+
 func (h *AviHmCache) AviHmCacheGetHmsForGS(tenant, gsName string) []interface{} {
 	var hmObjs []interface{}
 	hmObjs = make([]interface{}, 0)
@@ -241,11 +267,13 @@ func (h *AviHmCache) AviHmObjCachePopulate(client *clients.AviClient, hmname ...
 				Name:             *hm.Name,
 				Tenant:           getTenantFromTenantRef(*hm.TenantRef),
 				UUID:             *hm.UUID,
+				Type:             *hm.Type,
 				Port:             monitorPort,
 				CloudConfigCksum: cksum,
 				Template:         nodes.GetTemplateFromHmDescription(*hm.Name, description),
 				Description:      description,
 				CreatedBy:        createdBy,
+				SettingsCksum:    GetHmSettingsChecksum(hm),
 			}
 			h.AviHmCacheAdd(k, &hmCacheObj)
 			gslbutils.Debugf("processed health monitor %s", *hm.Name)
@@ -524,6 +552,12 @@ func (c *AviCache) AviCacheGetAllKeys() []TenantName {
 	return gses
 }
 
+func (c *AviCache) AviCacheLen() int {
+	c.cacheLock.RLock()
+	defer c.cacheLock.RUnlock()
+	return len(c.Cache)
+}
+
 func (c *AviCache) AviCacheGetByUuid(uuid string) (interface{}, bool) {
 	c.cacheLock.RLock()
 	defer c.cacheLock.RUnlock()
@@ -661,6 +695,79 @@ func parseGSObject(c *AviCache, gsObj models.GslbService, gsname []string) {
 		utils.Stringify(gsCacheObj)))
 }
 
+// AviUnmanagedGS is a GslbService in the controller which isn't created by this AMKO instance.
+type AviUnmanagedGS struct {
+	Name        string
+	Tenant      string
+	Uuid        string
+	CreatedBy   string
+	DomainNames []string
+	// Members are the IP addresses of the pool members, or their FQDNs if configured
+	Members []string
+}
+
+// GetUnmanagedGSs fetches the GslbServices of all the tenants which aren't created by this AMKO instance,
+// client must be set for all the tenants.
+func GetUnmanagedGSs(client *clients.AviClient) ([]AviUnmanagedGS, error) {
+	var gsList []AviUnmanagedGS
+	aviuri := "/api/gslbservice?include_name&page_size=100"
+	for aviuri != "" {
+		result, err := gslbutils.GetUriFromAvi(aviuri, client, false)
+		if err != nil {
+			return nil, fmt.Errorf("GS get URI %s returned error: %v", aviuri, err)
+		}
+		elems := make([]json.RawMessage, result.Count)
+		if err = json.Unmarshal(result.Results, &elems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal gslb service data, err: %v", err)
+		}
+		for i := 0; i < len(elems); i++ {
+			gs := models.GslbService{}
+			if err = json.Unmarshal(elems[i], &gs); err != nil {
+				gslbutils.Warnf("failed to unmarshal gs element, err: %s", err.Error())
+				continue
+			}
+			if gs.Name == nil || gs.UUID == nil || gs.TenantRef == nil {
+				gslbutils.Warnf("incomplete gs data unmarshalled %s", utils.Stringify(gs))
+				continue
+			}
+			createdBy := ""
+			if gs.CreatedBy != nil {
+				createdBy = *gs.CreatedBy
+			}
+			if createdBy == gslbutils.AmkoUser || createdBy == gslbutils.AMKOControlConfig().CreatedByField() {
+				continue
+			}
+			unmanagedGS := AviUnmanagedGS{
+				Name:        *gs.Name,
+				Tenant:      getTenantFromTenantRef(*gs.TenantRef),
+				Uuid:        *gs.UUID,
+				CreatedBy:   createdBy,
+				DomainNames: gs.DomainNames,
+			}
+			for _, group := range gs.Groups {
+				for _, member := range group.Members {
+					if member.Fqdn != nil && *member.Fqdn != "" {
+						unmanagedGS.Members = append(unmanagedGS.Members, *member.Fqdn)
+					} else if member.IP != nil && member.IP.Addr != nil {
+						unmanagedGS.Members = append(unmanagedGS.Members, *member.IP.Addr)
+					}
+				}
+			}
+			gsList = append(gsList, unmanagedGS)
+		}
+
+		aviuri = ""
+		if result.Next != "" {
+			nextURI := strings.Split(result.Next, "/api/gslbservice")
+			if len(nextURI) <= 1 {
+				return nil, fmt.Errorf("error in getting the nextURI, next URI %s", result.Next)
+			}
+			aviuri = "/api/gslbservice" + nextURI[1]
+		}
+	}
+	return gsList, nil
+}
+
 func parseDescription(description string) ([]string, error) {
 	// description field should be like:
 	// LBSvc/cluster-x/namespace-x/svc-x,Ingress/cluster-y/namespace-y/ingress-y/hostname,...,ThirdPartySite
@@ -675,6 +782,10 @@ func parseDescription(description string) ([]string, error) {
 			if len(seg) != 5 {
 				return []string{}, errors.New("description field has malformed ingress: " + description)
 			}
+		case gslbutils.HTTPRouteType:
+			if len(seg) != 5 {
+				return []string{}, errors.New("description field has malformed httproute: " + description)
+			}
 		case gdpv1alpha2.LBSvcObj:
 			if len(seg) != 4 {
 				return []string{}, errors.New("description field has malformed LB service: " + description)
@@ -795,8 +906,30 @@ func parseDownResponseFromRaw(val interface{}) *gslbalphav1.DownResponse {
 	return downResponse
 }
 
+// parseGSSettings returns the GslbService properties which can be set via the GDP and GSLBHostRule
+// objects, along with the minimum number of health monitors up of each pool.
+func parseGSSettings(gsObj models.GslbService) gslbutils.GSSettings {
+	gsSettings := gslbutils.GSSettings{
+		HealthMonitorScope:  gsObj.HealthMonitorScope,
+		MinMembers:          gsObj.MinMembers,
+		WildcardMatch:       gsObj.WildcardMatch,
+		ResolveCname:        gsObj.ResolveCname,
+		UseEdnsClientSubnet: gsObj.UseEdnsClientSubnet,
+		IsFederated:         gsObj.IsFederated,
+	}
+	gsSettings.PoolsMinHealthMonitorsUp = []uint32{}
+	for _, group := range gsObj.Groups {
+		var minHmUp uint32
+		if group.MinHealthMonitorsUp != nil {
+			minHmUp = *group.MinHealthMonitorsUp
+		}
+		gsSettings.PoolsMinHealthMonitorsUp = append(gsSettings.PoolsMinHealthMonitorsUp, minHmUp)
+	}
+	return gsSettings.DeepCopy()
+}
+
 func GetDetailsFromAviGSLBFormatted(gsObj models.GslbService) (uint32, []GSMember, []string, []string, *gslbalphav1.DownResponse, string, error) {
-	var serverList, domainList, memberObjs []string
+	var serverList, domainList, memberObjs, memberIPs []string
 	var hms []string
 	var gsMembers []GSMember
 	var persistenceProfileRef, createdBy string
@@ -932,6 +1065,7 @@ func GetDetailsFromAviGSLBFormatted(gsObj models.GslbService) (uint32, []GSMembe
 				gsMember.PublicIP = *member.PublicIP.IP.Addr
 			}
 			serverList = append(serverList, server+"-"+strconv.Itoa(int(weight))+"-"+strconv.Itoa(int(priority)))
+			memberIPs = append(memberIPs, ipAddr)
 			gsMembers = append(gsMembers, gsMember)
 		}
 	}
@@ -944,7 +1078,9 @@ func GetDetailsFromAviGSLBFormatted(gsObj models.GslbService) (uint32, []GSMembe
 
 	// calculate the checksum
 	checksum := gslbutils.GetGSLBServiceChecksum(serverList, domainList, memberObjs, hms,
-		persistenceProfileRefPtr, ttl, poolAlgorithmSettings, gsDownResponse, pkiProfileRef, createdBy)
+		persistenceProfileRefPtr, ttl, poolAlgorithmSettings, gsDownResponse, pkiProfileRef, parseGSSettings(gsObj),
+		createdBy)
+	checksum += gslbutils.GetChecksumForIPFamily(memberIPs)
 	return checksum, gsMembers, memberObjs, hms, gsDownResponse, createdBy, nil
 }
 
@@ -1022,8 +1158,42 @@ func GetGSFromHmName(hmName, tenant string) (string, int8, error) {
 	return "", 0, fmt.Errorf("hmName: %s, hmDescription: %s, msg: hm is malformed, %v", hmName, hmDesc, err)
 }
 
+func parseGSSettingsFromRaw(gslbSvcMap map[string]interface{}, groups []interface{}) gslbutils.GSSettings {
+	var gsSettings gslbutils.GSSettings
+	if hmScope, ok := gslbSvcMap["health_monitor_scope"].(string); ok {
+		gsSettings.HealthMonitorScope = &hmScope
+	}
+	if minMembers, ok := gslbSvcMap["min_members"].(float64); ok {
+		minMembersI := uint32(minMembers)
+		gsSettings.MinMembers = &minMembersI
+	}
+	if wildcardMatch, ok := gslbSvcMap["wildcard_match"].(bool); ok {
+		gsSettings.WildcardMatch = &wildcardMatch
+	}
+	if resolveCname, ok := gslbSvcMap["resolve_cname"].(bool); ok {
+		gsSettings.ResolveCname = &resolveCname
+	}
+	if useEdnsClientSubnet, ok := gslbSvcMap["use_edns_client_subnet"].(bool); ok {
+		gsSettings.UseEdnsClientSubnet = &useEdnsClientSubnet
+	}
+	if isFederated, ok := gslbSvcMap["is_federated"].(bool); ok {
+		gsSettings.IsFederated = &isFederated
+	}
+	gsSettings.PoolsMinHealthMonitorsUp = []uint32{}
+	for _, groupIntf := range groups {
+		var minHmUpI uint32
+		if group, ok := groupIntf.(map[string]interface{}); ok {
+			if minHmUp, ok := group["min_health_monitors_up"].(float64); ok {
+				minHmUpI = uint32(minHmUp)
+			}
+		}
+		gsSettings.PoolsMinHealthMonitorsUp = append(gsSettings.PoolsMinHealthMonitorsUp, minHmUpI)
+	}
+	return gsSettings
+}
+
 func GetDetailsFromAviGSLB(gslbSvcMap map[string]interface{}) (uint32, []GSMember, []string, []string, *gslbalphav1.DownResponse, string, error) {
-	var serverList, domainList, memberObjs []string
+	var serverList, domainList, memberObjs, memberIPs []string
 	var hms []string
 	var gsMembers []GSMember
 	var ttl *uint32
@@ -1159,6 +1329,7 @@ func GetDetailsFromAviGSLB(gslbSvcMap map[string]interface{}) (uint32, []GSMembe
 				server = ipAddr
 			}
 			serverList = append(serverList, server+"-"+strconv.Itoa(int(weightI))+"-"+strconv.Itoa(int(priority)))
+			memberIPs = append(memberIPs, ipAddr)
 			gsMember := GSMember{
 				IPAddr:     ipAddr,
 				Weight:     weightI,
@@ -1180,7 +1351,9 @@ func GetDetailsFromAviGSLB(gslbSvcMap map[string]interface{}) (uint32, []GSMembe
 
 	// calculate the checksum
 	checksum := gslbutils.GetGSLBServiceChecksum(serverList, domainList, memberObjs, hms,
-		persistenceProfileRefPtr, ttl, poolAlgorithmSettings, gsDownResponse, pkiProfileRefPtr, createdBy)
+		persistenceProfileRefPtr, ttl, poolAlgorithmSettings, gsDownResponse, pkiProfileRefPtr,
+		parseGSSettingsFromRaw(gslbSvcMap, groups), createdBy)
+	checksum += gslbutils.GetChecksumForIPFamily(memberIPs)
 	return checksum, gsMembers, memberObjs, hms, gsDownResponse, createdBy, nil
 }
 
@@ -1239,6 +1412,23 @@ func PopulateHMCache(createSharedCache bool) *AviHmCache {
 	return aviHmCache
 }
 
+// FetchHMCache returns a new HM cache with the health monitors fetched from the controller, only the
+// ones named hmName if given. Unlike PopulateHMCache, an error in fetching them is returned.
+func FetchHMCache(hmName ...string) (*AviHmCache, error) {
+	aviRestClientPool := SharedAviClients("*")
+	if aviRestClientPool == nil || len(aviRestClientPool.AviClient) == 0 {
+		return nil, errors.New("no avi clients available")
+	}
+	aviHmCache := &AviHmCache{}
+	aviHmCache.Cache = make(map[interface{}]interface{})
+	aviHmCache.UUIDCache = make(map[string]interface{})
+	SetTenantAndVersion(aviRestClientPool.AviClient[0], gslbutils.GetAviConfig().Version)
+	if err := aviHmCache.AviHmObjCachePopulate(aviRestClientPool.AviClient[0], hmName...); err != nil {
+		return nil, err
+	}
+	return aviHmCache, nil
+}
+
 func PopulateSPCache() *AviSpCache {
 	aviRestClientPool := SharedAviClients("*")
 	aviSpCache := GetAviSpCache()
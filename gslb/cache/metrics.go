@@ -0,0 +1,32 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+)
+
+// RegisterCacheMetrics registers the gauges for the number of objects in the GS and HM caches.
+func RegisterCacheMetrics() {
+	help := "Number of objects in the AMKO cache of Avi objects."
+	gslbutils.RegisterGaugeFunc("cache_objects", help, prometheus.Labels{"type": "GSLBService"}, func() float64 {
+		return float64(GetAviCache().AviCacheLen())
+	})
+	gslbutils.RegisterGaugeFunc("cache_objects", help, prometheus.Labels{"type": "HealthMonitor"}, func() float64 {
+		return float64(GetAviHmCache().AviHmCacheLen())
+	})
+}
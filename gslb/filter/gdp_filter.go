@@ -40,12 +40,8 @@ func ApplyFilter(fargs FilterArgs) bool {
 		return false
 	}
 
-	// First see, if there's a namespace filter set for this object's namespace, if not, apply
-	// the global filter.
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
-
-	if gf.AppFilter == nil && gf.NSFilter == nil {
+	// the object has to be selected by at least one of the GDP filters
+	if gf.Len() == 0 {
 		return false
 	}
 	return metaobj.ApplyFilter()
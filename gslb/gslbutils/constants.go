@@ -37,10 +37,12 @@ const (
 	IngressType          = gdpalphav2.IngressObj
 	SvcType              = gdpalphav2.LBSvcObj
 	MCIType              = "MCI"
+	HTTPRouteType        = "HTTPROUTE"
 	GSFQDNType           = "GSFqdn"
 	PassthroughRoute     = "passthrough"
 	ThirdPartyMemberType = "ThirdPartyMember"
 	HostRuleType         = "HostRule"
+	NamespaceType        = "Namespace"
 
 	// Refresh cycle for AVI cache in seconds
 	DefaultRefreshInterval = 600
@@ -48,6 +50,12 @@ const (
 	// Refresh cycle for member clusters in seconds
 	DefaultClusterConnectInterval = 30
 
+	// Interval in seconds at which the connectivity to the member clusters is checked
+	DefaultClusterHealthCheckInterval = 15
+
+	// Default time in seconds between two steps of a GSLBTrafficShift
+	DefaultTrafficShiftStepInterval = 300
+
 	// Store types
 	AcceptedStore = "Accepted"
 	RejectedStore = "Rejected"
@@ -74,14 +82,30 @@ const (
 	GSLBConfigError         = "GSLBConfigError"
 	MemberClusterValidation = "MemberClusterValidation"
 	AMKOClusterReady        = "AMKOClusterReady"
+	MemberClusterHealth     = "MemberClusterHealth"
+	GSDeletionsHeld         = "GSDeletionsHeld"
+	GSAdoption              = "GSAdoption"
+	OrphanedObjects         = "OrphanedObjects"
+	HealthMonitorDrift      = "HealthMonitorDrift"
+	LeaderElection          = "LeaderElection"
+	DryRunOperationPlanned  = "DryRunOperationPlanned"
+	GSLBTrafficShift        = "GSLBTrafficShift"
 
 	// Go routines in the rest layer
 	NumRestWorkers = 8
 
+	// Validating admission webhook server
+	DefaultWebhookPort = "9443"
+	WebhookCertDir     = "/etc/amko/webhook/certs"
+
 	// Service Protocols
 	ProtocolTCP = "TCP"
 	ProtocolUDP = "UDP"
 
+	// IP address types for GS pool members
+	IPVersionV4 = "V4"
+	IPVersionV6 = "V6"
+
 	// Health monitors
 	SystemHealthMonitorTypeTCP   = "HEALTH_MONITOR_TCP"
 	SystemHealthMonitorTypeUDP   = "HEALTH_MONITOR_UDP"
@@ -113,6 +137,35 @@ const (
 	// AMKO UUID annotation
 	AmkoUuid = "amko.vmware.com/amko-uuid"
 
+	// Annotation on the GSLBConfig object to release the GslbService deletions held by the deletion
+	// protection, the value must be the ID of the held deletions
+	ReleaseHeldDeletionsAnnotation = "amko.vmware.com/release-held-deletions"
+
+	// Default time window in seconds for the GslbService deletion protection
+	DefaultDeletionProtectionWindow = 60
+
+	// Default time in seconds for which a GslbService or health monitor must be orphaned before it is deleted
+	DefaultOrphanGracePeriod = 1800
+
+	// Default GslbService properties, used if not set via the GDP or GSLBHostRule objects
+	DefaultGSHealthMonitorScope  = "GSLB_SERVICE_HEALTH_MONITOR_ALL_MEMBERS"
+	DefaultGSMinMembers          = 0
+	DefaultGSWildcardMatch       = false
+	DefaultGSResolveCname        = false
+	DefaultGSUseEdnsClientSubnet = true
+	DefaultGSIsFederated         = true
+
+	// Lease objects in the AMKO namespace used to elect the active AMKO replica, and for the AMKOCluster
+	// controller
+	LeaderElectionLeaseName     = "amko-leader"
+	FederatorLeaderElectionName = "amko-federator-leader"
+
+	// Leader election timings, a standby replica takes over within the lease duration once the elected
+	// replica stops renewing the lease
+	LeaderElectionLeaseDuration = 15 * time.Second
+	LeaderElectionRenewDeadline = 10 * time.Second
+	LeaderElectionRetryPeriod   = 2 * time.Second
+
 	// AMKO Created by label key for HM labels
 	CreatedByLabelKey = "created-by"
 
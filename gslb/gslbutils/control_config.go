@@ -25,8 +25,8 @@ import (
 
 type amkoControlConfig struct {
 	clientset     *kubernetes.Clientset
-	gslbClientset *gslbcs.Clientset
-	gdpClientset  *gdpcs.Clientset
+	gslbClientset gslbcs.Interface
+	gdpClientset  gdpcs.Interface
 
 	amkoPodObjectMeta *metav1.ObjectMeta
 
@@ -54,19 +54,19 @@ func (c *amkoControlConfig) Clientset() *kubernetes.Clientset {
 	return c.clientset
 }
 
-func (c *amkoControlConfig) SetGSLBClientset(cs *gslbcs.Clientset) {
+func (c *amkoControlConfig) SetGSLBClientset(cs gslbcs.Interface) {
 	c.gslbClientset = cs
 }
 
-func (c *amkoControlConfig) GSLBClientset() *gslbcs.Clientset {
+func (c *amkoControlConfig) GSLBClientset() gslbcs.Interface {
 	return c.gslbClientset
 }
 
-func (c *amkoControlConfig) SetGDPClientset(cs *gdpcs.Clientset) {
+func (c *amkoControlConfig) SetGDPClientset(cs gdpcs.Interface) {
 	c.gdpClientset = cs
 }
 
-func (c *amkoControlConfig) GDPClientset() *gdpcs.Clientset {
+func (c *amkoControlConfig) GDPClientset() gdpcs.Interface {
 	return c.gdpClientset
 }
 
@@ -74,16 +74,20 @@ func (c *amkoControlConfig) SetPublishGSLBStatus(val bool) {
 	c.publishGSLBStatus = val
 }
 
+// PublishGSLBStatus returns true if the status of the GSLBConfig object can be updated, only the elected
+// replica updates it.
 func (c *amkoControlConfig) PublishGSLBStatus() bool {
-	return c.publishGSLBStatus
+	return c.publishGSLBStatus && IsReplicaElected()
 }
 
 func (c *amkoControlConfig) SetPublishGDPStatus(val bool) {
 	c.publishGDPStatus = val
 }
 
+// PublishGDPStatus returns true if the status of the GDP objects can be updated, only the elected
+// replica updates it.
 func (c *amkoControlConfig) PublishGDPStatus() bool {
-	return c.publishGDPStatus
+	return c.publishGDPStatus && IsReplicaElected()
 }
 
 func (c *amkoControlConfig) SetEventRecorder(id string, client kubernetes.Interface) {
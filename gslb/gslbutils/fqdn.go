@@ -16,9 +16,26 @@ package gslbutils
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 )
 
+// IsWildcardFqdn checks whether fqdn is a wildcard FQDN, i.e., of the form "*.<domain>". A GslbService
+// for a wildcard FQDN answers for all the FQDNs under <domain> which don't have a more specific GslbService.
+func IsWildcardFqdn(fqdn string) bool {
+	return strings.HasPrefix(fqdn, "*.") && len(fqdn) > 2
+}
+
+// GetWildcardFqdn returns the wildcard FQDN covering the siblings of hostname, e.g. "*.apps.example.com"
+// for "www.apps.example.com". An empty string is returned if hostname doesn't have a parent domain.
+func GetWildcardFqdn(hostname string) string {
+	labels := strings.SplitN(hostname, ".", 2)
+	if len(labels) != 2 || labels[1] == "" {
+		return ""
+	}
+	return "*." + labels[1]
+}
+
 type LocalFqdn struct {
 	Cluster string
 	Fqdn    string
@@ -19,9 +19,12 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
 	gdpv1alpha2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
@@ -29,52 +32,371 @@ import (
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
-type GDPObj struct {
-	Namespace string
-	Name      string
-	GDPLock   sync.RWMutex
+var (
+	// Need to keep this global since, it will be used across multiple layers and multiple handlers
+	Gfi    *GlobalFilter
+	gfOnce sync.Once
+)
+
+// ClusterProperties contains the properties for a cluster.
+type ClusterProperties struct {
+	// SyncVipsOnly advises AMKO to sync only the VIPs of the member objects of a GS
+	SyncVipsOnly bool
+	// Location is the geo location of the GS members from this cluster
+	Location *gslbalphav1.GeoLocation
+	// Drain disables the GS members from this cluster
+	Drain bool
 }
 
-var gdpObj GDPObj
+// GlobalFilter is the set of filters of all the accepted GDP objects. An object is selected if
+// any of the GDP filters selects it. The filters of the namespaced GDP objects come first, followed
+// by the filters of the GDP objects in avi-system, each ordered by their keys. This order is the
+// precedence with which the GDP properties are applied to a GslbService.
+type GlobalFilter struct {
+	filters map[string]*GDPFilter
+	lock    sync.RWMutex
+}
 
-func SetGDPObj(name, ns string) {
-	gdpObj.GDPLock.Lock()
-	defer gdpObj.GDPLock.Unlock()
-	gdpObj.Name = name
-	gdpObj.Namespace = ns
+// GetGlobalFilter returns the existing global filter
+func GetGlobalFilter() *GlobalFilter {
+	gfOnce.Do(func() {
+		Gfi = &GlobalFilter{
+			filters: make(map[string]*GDPFilter),
+		}
+	})
+	return Gfi
 }
 
-func GetGDPObj() (string, string) {
-	gdpObj.GDPLock.RLock()
-	defer gdpObj.GDPLock.RUnlock()
-	return gdpObj.Name, gdpObj.Namespace
+// GetGDPFilterKey returns the key of the filter for a GDP object. GDP objects in avi-system are
+// keyed by their names, and the namespaced GDP objects by their namespace and name.
+func GetGDPFilterKey(ns, name string) string {
+	if ns == AVISystem || ns == "" {
+		return name
+	}
+	return ns + "/" + name
 }
 
-func IsEmpty() bool {
-	gdpObj.GDPLock.RLock()
-	defer gdpObj.GDPLock.RUnlock()
+// SplitGDPFilterKey returns the namespace and name of the GDP object for a filter key.
+func SplitGDPFilterKey(key string) (string, string) {
+	if idx := strings.Index(key, "/"); idx != -1 {
+		return key[:idx], key[idx+1:]
+	}
+	return AVISystem, key
+}
 
-	if gdpObj.Name == "" && gdpObj.Namespace == "" {
-		return true
+// AddFilter adds or replaces the filter of a GDP object.
+func (gf *GlobalFilter) AddFilter(f *GDPFilter) {
+	gf.lock.Lock()
+	defer gf.lock.Unlock()
+	gf.filters[f.Name] = f
+}
+
+// DeleteFilter deletes the filter of a GDP object, returns false if the filter doesn't exist.
+func (gf *GlobalFilter) DeleteFilter(name string) bool {
+	gf.lock.Lock()
+	defer gf.lock.Unlock()
+	if _, ok := gf.filters[name]; !ok {
+		return false
+	}
+	delete(gf.filters, name)
+	return true
+}
+
+// GetFilter returns the filter of a GDP object, nil if the GDP object wasn't accepted.
+func (gf *GlobalFilter) GetFilter(name string) *GDPFilter {
+	gf.lock.RLock()
+	defer gf.lock.RUnlock()
+	return gf.filters[name]
+}
+
+// GetFilters returns all the GDP filters in the order of precedence.
+func (gf *GlobalFilter) GetFilters() []*GDPFilter {
+	gf.lock.RLock()
+	defer gf.lock.RUnlock()
+	names := make([]string, 0, len(gf.filters))
+	for name := range gf.filters {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		// the namespaced GDP objects have a higher precedence
+		iNamespaced, jNamespaced := gf.filters[names[i]].IsNamespaced(), gf.filters[names[j]].IsNamespaced()
+		if iNamespaced != jNamespaced {
+			return iNamespaced
+		}
+		return names[i] < names[j]
+	})
+	filters := make([]*GDPFilter, 0, len(names))
+	for _, name := range names {
+		filters = append(filters, gf.filters[name])
+	}
+	return filters
+}
+
+// GetFiltersByName returns the filters of the GDP objects in gdpNames in the order of precedence.
+// All the filters are returned if gdpNames is empty.
+func (gf *GlobalFilter) GetFiltersByName(gdpNames []string) []*GDPFilter {
+	if len(gdpNames) == 0 {
+		return gf.GetFilters()
+	}
+	filters := []*GDPFilter{}
+	for _, f := range gf.GetFilters() {
+		if PresentInList(f.Name, gdpNames) {
+			filters = append(filters, f)
+		}
+	}
+	return filters
+}
+
+// SortByPrecedence returns the GDP objects in gdpNames in the order of precedence. The GDP objects
+// without a filter are at the end, sorted by their names.
+func (gf *GlobalFilter) SortByPrecedence(gdpNames []string) []string {
+	sorted := make([]string, 0, len(gdpNames))
+	for _, f := range gf.GetFilters() {
+		if PresentInList(f.Name, gdpNames) {
+			sorted = append(sorted, f.Name)
+		}
+	}
+	rest := []string{}
+	for _, name := range gdpNames {
+		if !PresentInList(name, sorted) && !PresentInList(name, rest) {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(sorted, rest...)
+}
+
+// Len returns the number of accepted GDP objects.
+func (gf *GlobalFilter) Len() int {
+	gf.lock.RLock()
+	defer gf.lock.RUnlock()
+	return len(gf.filters)
+}
+
+func (gf *GlobalFilter) IsClusterAllowed(cname string) bool {
+	for _, f := range gf.GetFilters() {
+		if f.IsClusterAllowed(cname) {
+			return true
+		}
 	}
 	return false
 }
 
-var (
-	// Need to keep this global since, it will be used across multiple layers and multiple handlers
-	Gfi    *GlobalFilter
-	gfOnce sync.Once
-)
+// HasNSFilter returns true if any of the GDP objects has a namespace selector.
+func (gf *GlobalFilter) HasNSFilter() bool {
+	for _, f := range gf.GetFilters() {
+		if _, err := f.GetNSFilterSelector(); err == nil {
+			return true
+		}
+	}
+	return false
+}
 
-// ClusterProperties contains the properties for a cluster.
-type ClusterProperties struct {
-	// SyncVipsOnly advises AMKO to sync only the VIPs of the member objects of a GS
-	SyncVipsOnly bool
+// IsClusterSyncVIPOnly returns the sync type of a cluster from the first GDP filter (out of gdpNames)
+// which selects the cluster.
+func (gf *GlobalFilter) IsClusterSyncVIPOnly(cname string, gdpNames ...string) (bool, error) {
+	for _, f := range gf.GetFiltersByName(gdpNames) {
+		if syncVIPOnly, err := f.IsClusterSyncVIPOnly(cname); err == nil {
+			return syncVIPOnly, nil
+		}
+	}
+	return false, fmt.Errorf("cluster %s not present in global filter", cname)
+}
+
+// GetClusterLocation returns the geo location of a cluster from the first GDP filter (out of gdpNames)
+// which has a location for the cluster, nil if none has.
+func (gf *GlobalFilter) GetClusterLocation(cname string, gdpNames ...string) *gslbalphav1.GeoLocation {
+	for _, f := range gf.GetFiltersByName(gdpNames) {
+		if loc := f.getClusterLocation(cname); loc != nil {
+			return loc.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// IsClusterDrained returns true if any of the GDP filters (out of gdpNames) drains the cluster.
+func (gf *GlobalFilter) IsClusterDrained(cname string, gdpNames ...string) bool {
+	for _, f := range gf.GetFiltersByName(gdpNames) {
+		if f.isClusterDrained(cname) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTrafficWeight returns the traffic weight of a cluster from the first GDP filter (out of gdpNames)
+// which has a traffic split for the cluster.
+func (gf *GlobalFilter) GetTrafficWeight(cname string, gdpNames ...string) (uint32, error) {
+	for _, f := range gf.GetFiltersByName(gdpNames) {
+		if weight, ok := f.getTrafficSplit(cname); ok {
+			return weight.Weight, nil
+		}
+	}
+	Logf("cname: %s, msg: no weight available for this cluster", cname)
+	return 0, errors.New("no weight available for cluster " + cname)
+}
+
+// GetTrafficPriority returns the priority of a cluster from the first GDP filter (out of gdpNames)
+// which has a traffic split for the cluster.
+func (gf *GlobalFilter) GetTrafficPriority(cname string, gdpNames ...string) (uint32, error) {
+	for _, f := range gf.GetFiltersByName(gdpNames) {
+		if priority, ok := f.getTrafficSplit(cname); ok {
+			return priority.Priority, nil
+		}
+	}
+	Logf("cname: %s, msg: no priority available for this cluster", cname)
+	return 0, errors.New("no priority available for cluster " + cname)
+}
+
+// GetDefaultDomain returns the default domain of the GDP object (in avi-system) with the highest
+// precedence which has a default domain.
+func (gf *GlobalFilter) GetDefaultDomain() *string {
+	for _, f := range gf.GetFilters() {
+		if f.IsNamespaced() {
+			continue
+		}
+		if defaultDomain := f.GetDefaultDomain(); defaultDomain != nil {
+			return defaultDomain
+		}
+	}
+	return nil
+}
+
+// GetGuardrails returns the guardrails for the namespaced GDP objects from the GDP object (in avi-system)
+// with the highest precedence which has the guardrails, nil if none of them have.
+func (gf *GlobalFilter) GetGuardrails() *gdpv1alpha2.NamespacedPolicyGuardrails {
+	for _, f := range gf.GetFilters() {
+		if f.IsNamespaced() {
+			continue
+		}
+		f.Lock.RLock()
+		guardrails := f.Guardrails
+		f.Lock.RUnlock()
+		if guardrails != nil {
+			return guardrails
+		}
+	}
+	return nil
 }
 
-// GlobalFilter is all the filters at one place. It also holds a list of ApplicableClusters
+// IsClusterAllowedByGuardrails returns true if a namespaced GDP object can select the cluster.
+func IsClusterAllowedByGuardrails(guardrails *gdpv1alpha2.NamespacedPolicyGuardrails, cname string) bool {
+	if guardrails == nil {
+		return false
+	}
+	return len(guardrails.AllowedClusters) == 0 || PresentInList(cname, guardrails.AllowedClusters)
+}
+
+// IsTTLAllowedByGuardrails returns true if a namespaced GDP object can use the TTL.
+func IsTTLAllowedByGuardrails(guardrails *gdpv1alpha2.NamespacedPolicyGuardrails, ttl int) bool {
+	if guardrails == nil {
+		return false
+	}
+	if guardrails.TTLRange == nil {
+		return true
+	}
+	return ttl >= guardrails.TTLRange.Min && ttl <= guardrails.TTLRange.Max
+}
+
+// IsHmRefAllowedByGuardrails returns true if a namespaced GDP object can use the health monitor ref.
+func IsHmRefAllowedByGuardrails(guardrails *gdpv1alpha2.NamespacedPolicyGuardrails, hmRef string) bool {
+	if guardrails == nil {
+		return false
+	}
+	return PresentInList(hmRef, guardrails.AllowedHealthMonitorRefs)
+}
+
+// GetMergedFilter merges the filters of the GDP objects in gdpNames into a single filter. Each property
+// is taken from the GDP object with the highest precedence which has that property set.
+func (gf *GlobalFilter) GetMergedFilter(gdpNames []string) *GDPFilter {
+	merged := NewGDPFilter("")
+	hmSet := false
+	guardrails := gf.GetGuardrails()
+	for _, f := range gf.GetFiltersByName(gdpNames) {
+		namespaced := f.IsNamespaced()
+		f.Lock.RLock()
+		if merged.Name == "" {
+			merged.Name = f.Name
+		}
+		for c, p := range f.ApplicableClusters {
+			if namespaced && !IsClusterAllowedByGuardrails(guardrails, c) {
+				continue
+			}
+			if _, ok := merged.ApplicableClusters[c]; !ok {
+				merged.ApplicableClusters[c] = p
+			}
+		}
+		// the traffic split for a cluster is fetched from the first entry for that cluster
+		merged.TrafficSplit = append(merged.TrafficSplit, f.TrafficSplit...)
+		if namespaced {
+			// properties of a namespaced GDP object are honoured only within the guardrails
+			hmRefs := []string{}
+			for _, hmRef := range f.HealthMonitorRefs {
+				if IsHmRefAllowedByGuardrails(guardrails, hmRef) {
+					hmRefs = append(hmRefs, hmRef)
+				}
+			}
+			if !hmSet && len(hmRefs) > 0 {
+				merged.HealthMonitorRefs = hmRefs
+				hmSet = true
+			}
+			if merged.TTL == nil && f.TTL != nil && IsTTLAllowedByGuardrails(guardrails, int(*f.TTL)) {
+				merged.TTL = f.TTL
+			}
+		} else if !hmSet && (f.HealthMonitorTemplate != nil || len(f.HealthMonitorRefs) > 0) {
+			merged.HealthMonitorTemplate = f.HealthMonitorTemplate
+			merged.HealthMonitorRefs = f.HealthMonitorRefs
+			hmSet = true
+		}
+		if merged.SitePersistenceRef == nil {
+			merged.SitePersistenceRef = f.SitePersistenceRef
+		}
+		if merged.PkiProfileRef == nil {
+			merged.PkiProfileRef = f.PkiProfileRef
+		}
+		if merged.TTL == nil && !namespaced {
+			merged.TTL = f.TTL
+		}
+		if merged.GslbPoolAlgorithm == nil {
+			merged.GslbPoolAlgorithm = f.GslbPoolAlgorithm
+		}
+		if merged.GslbDownResponse == nil {
+			merged.GslbDownResponse = f.GslbDownResponse
+		}
+		if merged.ControlPlaneHmOnly == nil {
+			merged.ControlPlaneHmOnly = f.ControlPlaneHmOnly
+		}
+		if merged.DefaultDomain == nil && !namespaced {
+			merged.DefaultDomain = f.DefaultDomain
+		}
+		if merged.IPFamily == nil {
+			merged.IPFamily = f.IPFamily
+		}
+		merged.GSSettings.Merge(f.GSSettings)
+		f.Lock.RUnlock()
+	}
+	merged.ComputeChecksum()
+	return merged
+}
+
+// GetCopy returns a copy of all the GDP filters in the order of precedence.
+func (gf *GlobalFilter) GetCopy() []*GDPFilter {
+	filters := gf.GetFilters()
+	filterCopies := make([]*GDPFilter, len(filters))
+	for idx, f := range filters {
+		filterCopies[idx] = f.GetCopy()
+	}
+	return filterCopies
+}
+
+// GDPFilter contains all the filters of a GDP object. It also holds a list of ApplicableClusters
 // to which all the filters are applicable. This list cannot be empty.
-type GlobalFilter struct {
+type GDPFilter struct {
+	// Name is the key of the GDP object, see GetGDPFilterKey
+	Name string
+	// Namespace of the GDP object, the GDP objects outside avi-system only select the objects
+	// in their own namespace
+	Namespace string
 	// AppFilter contains rules for selecting applications
 	AppFilter *AppFilter
 	// NamespaceRules contains NamespaceSelector rules
@@ -102,56 +424,73 @@ type GlobalFilter struct {
 	ControlPlaneHmOnly *bool
 	// DefaultDomain will be used to generate hostname if openshift route uses subdomain
 	DefaultDomain *string
-	Checksum      uint32
-	// Respective filters for the namespaces.
-	// NSFilterMap map[string]*NSFilter
-	// GlobalLock is locked before accessing any of the filters.
-	GlobalLock sync.RWMutex
-}
-
-// GetGlobalFilter returns the existing global filter
-func GetGlobalFilter() *GlobalFilter {
-	gfOnce.Do(func() {
-		Gfi = GetNewGlobalFilter()
-	})
-	return Gfi
+	// IPFamily determines the address family (V4, V6 or V4_V6) of the GS pool members
+	IPFamily *string
+	// GSSettings are the GslbService properties set via the GDP object
+	GSSettings GSSettings
+	// Guardrails for the namespaced GDP objects, only set for the GDP objects in avi-system
+	Guardrails *gdpv1alpha2.NamespacedPolicyGuardrails
+	Checksum   uint32
+	// Lock is locked before accessing any of the filters.
+	Lock sync.RWMutex
 }
 
-func (gf *GlobalFilter) GetNSFilterLabel() (Label, error) {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetNSFilterSelector() (labels.Selector, error) {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	if gf.NSFilter == nil {
-		return Label{}, errors.New("no NSFilter present")
+		return nil, errors.New("no NSFilter present")
 	}
 
-	return gf.NSFilter.GetFilterLabel(), nil
+	return gf.NSFilter.GetFilterSelector(), nil
 }
 
-func (gf *GlobalFilter) GetAppFilterLabel() (Label, error) {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetAppFilterSelector() (labels.Selector, error) {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	if gf.AppFilter == nil {
-		return Label{}, errors.New("no appFilter present")
+		return nil, errors.New("no appFilter present")
 	}
 
-	return gf.AppFilter.Label, nil
+	return gf.AppFilter.Selector, nil
 }
 
-func (gf *GlobalFilter) IsClusterAllowed(cname string) bool {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) IsClusterAllowed(cname string) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
-	if ClusterContextPresentInList(cname, gf.ApplicableClusters) {
-		return true
+	return gf.isClusterAllowed(cname)
+}
+
+func (gf *GDPFilter) isClusterAllowed(cname string) bool {
+	if !ClusterContextPresentInList(cname, gf.ApplicableClusters) {
+		return false
 	}
-	return false
+	if gf.IsNamespaced() {
+		return IsClusterAllowedByGuardrails(GetGlobalFilter().GetGuardrails(), cname)
+	}
+	return true
+}
+
+// IsNamespaced returns true if the filter belongs to a GDP object outside avi-system.
+func (gf *GDPFilter) IsNamespaced() bool {
+	return gf.Namespace != "" && gf.Namespace != AVISystem
 }
 
-func (gf *GlobalFilter) AddNSToNSFilter(cname, ns string) error {
-	gf.GlobalLock.Lock()
-	defer gf.GlobalLock.Unlock()
+// IsObjInScope returns true if an object in cluster cname and namespace ns can be selected by this
+// filter. gf.Lock must be held by the caller.
+func (gf *GDPFilter) IsObjInScope(cname, ns string) bool {
+	if gf.IsNamespaced() && ns != gf.Namespace {
+		return false
+	}
+	return gf.isClusterAllowed(cname)
+}
+
+func (gf *GDPFilter) AddNSToNSFilter(cname, ns string) error {
+	gf.Lock.Lock()
+	defer gf.Lock.Unlock()
 
 	if gf.NSFilter == nil {
 		return errors.New("NSFilter empty in GlobalFilter, can't add namespace")
@@ -161,76 +500,93 @@ func (gf *GlobalFilter) AddNSToNSFilter(cname, ns string) error {
 	return nil
 }
 
-func (gf *GlobalFilter) GetAviHmRefs() []string {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetAviHmRefs() []string {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	aviHmRefs := make([]string, len(gf.HealthMonitorRefs))
 	copy(aviHmRefs, gf.HealthMonitorRefs)
 	return aviHmRefs
 }
 
-func (gf *GlobalFilter) GetAviHmTemplate() *string {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetAviHmTemplate() *string {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.HealthMonitorTemplate
 }
 
-func (gf *GlobalFilter) GetSitePersistence() *string {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetSitePersistence() *string {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.SitePersistenceRef
 }
 
-func (gf *GlobalFilter) GetPKIProfile() *string {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetPKIProfile() *string {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.PkiProfileRef
 }
 
-func (gf *GlobalFilter) GetTTL() *uint32 {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetTTL() *uint32 {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.TTL
 }
 
-func (gf *GlobalFilter) GetControlPlaneHmOnlyFlag() *bool {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetControlPlaneHmOnlyFlag() *bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.ControlPlaneHmOnly
 }
 
-func (gf *GlobalFilter) GetDefaultDomain() *string {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetDefaultDomain() *string {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.DefaultDomain
 }
 
-func (gf *GlobalFilter) GetGslbPoolAlgorithm() *gslbalphav1.PoolAlgorithmSettings {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetIPFamily() *string {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
+
+	return gf.IPFamily
+}
+
+func (gf *GDPFilter) GetGSSettings() GSSettings {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
+
+	return gf.GSSettings.DeepCopy()
+}
+
+func (gf *GDPFilter) GetGslbPoolAlgorithm() *gslbalphav1.PoolAlgorithmSettings {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.GslbPoolAlgorithm.DeepCopy()
 }
 
-func (gf *GlobalFilter) GetDownResponse() *gslbalphav1.DownResponse {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetDownResponse() *gslbalphav1.DownResponse {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	return gf.GslbDownResponse.DeepCopy()
 }
 
-func (gf *GlobalFilter) GetCopy() *GlobalFilter {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) GetCopy() *GDPFilter {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
-	newFilter := GlobalFilter{
+	newFilter := GDPFilter{
+		Name:                  gf.Name,
+		Namespace:             gf.Namespace,
+		Guardrails:            gf.Guardrails,
 		AppFilter:             gf.AppFilter,
 		NSFilter:              gf.NSFilter,
 		TrafficSplit:          gf.TrafficSplit,
@@ -245,16 +601,23 @@ func (gf *GlobalFilter) GetCopy() *GlobalFilter {
 		Checksum:              gf.Checksum,
 		ControlPlaneHmOnly:    gf.ControlPlaneHmOnly,
 		DefaultDomain:         gf.DefaultDomain,
+		IPFamily:              gf.IPFamily,
+		GSSettings:            gf.GSSettings.DeepCopy(),
 	}
 	return &newFilter
 }
 
 type AppFilter struct {
-	Label
+	Selector labels.Selector
+}
+
+// Matches returns true if the labels of an application satisfy the appSelector.
+func (appFilter *AppFilter) Matches(lbls map[string]string) bool {
+	return appFilter.Selector.Matches(labels.Set(lbls))
 }
 
 type NamespaceFilter struct {
-	Label
+	Selector labels.Selector
 	// SelectedNS contains a list of namespaces selected via this filter
 	// updated by the namespace event handlers
 	SelectedNS map[string][]string
@@ -270,10 +633,15 @@ func (nsFilter *NamespaceFilter) GetChecksum() uint32 {
 	return nsFilter.Checksum
 }
 
-func (nsFilter *NamespaceFilter) GetFilterLabel() Label {
+func (nsFilter *NamespaceFilter) GetFilterSelector() labels.Selector {
 	nsFilter.Lock.RLock()
 	defer nsFilter.Lock.RUnlock()
-	return nsFilter.Label
+	return nsFilter.Selector
+}
+
+// Matches returns true if the labels of a namespace satisfy the namespaceSelector.
+func (nsFilter *NamespaceFilter) Matches(lbls map[string]string) bool {
+	return nsFilter.Selector.Matches(labels.Set(lbls))
 }
 
 func (nsFilter *NamespaceFilter) AddNS(cname, ns string) {
@@ -292,50 +660,48 @@ func (nsFilter *NamespaceFilter) AddNS(cname, ns string) {
 	}
 }
 
-type Label struct {
-	Key   string
-	Value string
-}
-
-func getLabelKeyAndValue(lbl map[string]string) (string, string) {
-	for k, v := range lbl {
-		return k, v
+// GetLabelSelector builds the selector for the labels and the set-based label requirements of an
+// appSelector or a namespaceSelector. A nil selector is returned if neither of them is specified.
+func GetLabelSelector(lbl map[string]string, exprs []metav1.LabelSelectorRequirement) (labels.Selector, error) {
+	if len(lbl) == 0 && len(exprs) == 0 {
+		return nil, nil
 	}
-	return "", ""
+	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      lbl,
+		MatchExpressions: exprs,
+	})
 }
 
-func createNewNSFilter(lbl map[string]string) *NamespaceFilter {
-	k, v := getLabelKeyAndValue(lbl)
+func createNewNSFilter(selector labels.Selector) *NamespaceFilter {
 	nsFilter := NamespaceFilter{
-		Label: Label{
-			Key:   k,
-			Value: v,
-		},
+		Selector: selector,
 	}
-	// checksum for NSFilter only accounts for the key and label i.e., wrt
+	// checksum for NSFilter only accounts for the selector i.e., wrt
 	// any GDP changes and not namespace changes
-	cksum := utils.Hash(k + v)
+	cksum := utils.Hash(selector.String())
 	nsFilter.Checksum = cksum
 	return &nsFilter
 }
 
 // AddToFilter handles creation of new filters, cluster or otherwise.
-// Only one GDP object allowed per-cluster.
-func (gf *GlobalFilter) AddToFilter(gdp *gdpv1alpha2.GlobalDeploymentPolicy) {
-	gf.GlobalLock.Lock()
-	defer gf.GlobalLock.Unlock()
-	if len(gdp.Spec.MatchRules.AppSelector.Label) == 1 {
-		k, v := getLabelKeyAndValue(gdp.Spec.MatchRules.AppSelector.Label)
-		appFilter := AppFilter{
-			Label: Label{
-				Key:   k,
-				Value: v,
-			},
-		}
-		gf.AppFilter = &appFilter
+func (gf *GDPFilter) AddToFilter(gdp *gdpv1alpha2.GlobalDeploymentPolicy) {
+	gf.Lock.Lock()
+	defer gf.Lock.Unlock()
+	gf.Namespace = gdp.Namespace
+	if gdp.Namespace == AVISystem && gdp.Spec.NamespacedPolicyGuardrails != nil {
+		gf.Guardrails = gdp.Spec.NamespacedPolicyGuardrails.DeepCopy()
+	}
+	appSelector := gdp.Spec.MatchRules.AppSelector
+	if selector, err := GetLabelSelector(appSelector.Label, appSelector.MatchExpressions); err != nil {
+		Errf("ns: %s, gdp: %s, msg: invalid appSelector: %v", gdp.Namespace, gdp.Name, err)
+	} else if selector != nil {
+		gf.AppFilter = &AppFilter{Selector: selector}
 	}
-	if len(gdp.Spec.MatchRules.NamespaceSelector.Label) == 1 {
-		gf.NSFilter = createNewNSFilter(gdp.Spec.MatchRules.NamespaceSelector.Label)
+	nsSelector := gdp.Spec.MatchRules.NamespaceSelector
+	if selector, err := GetLabelSelector(nsSelector.Label, nsSelector.MatchExpressions); err != nil {
+		Errf("ns: %s, gdp: %s, msg: invalid namespaceSelector: %v", gdp.Namespace, gdp.Name, err)
+	} else if selector != nil {
+		gf.NSFilter = createNewNSFilter(selector)
 	}
 
 	if len(gf.ApplicableClusters) == 0 {
@@ -343,7 +709,7 @@ func (gf *GlobalFilter) AddToFilter(gdp *gdpv1alpha2.GlobalDeploymentPolicy) {
 	}
 	// Add applicable clusters
 	for _, cluster := range gdp.Spec.MatchClusters {
-		gf.ApplicableClusters[cluster.Cluster] = ClusterProperties{cluster.SyncVipOnly}
+		gf.ApplicableClusters[cluster.Cluster] = ClusterProperties{cluster.SyncVipOnly, cluster.Location.DeepCopy(), cluster.Drain}
 	}
 	// Add traffic split
 	for _, ts := range gdp.Spec.TrafficSplit {
@@ -380,6 +746,11 @@ func (gf *GlobalFilter) AddToFilter(gdp *gdpv1alpha2.GlobalDeploymentPolicy) {
 	gf.ControlPlaneHmOnly = gdp.Spec.ControlPlaneHmOnly
 
 	gf.DefaultDomain = gdp.Spec.DefaultDomain
+
+	gf.IPFamily = gdp.Spec.IPFamily
+
+	gf.GSSettings = GetGSSettingsFromSpec(gdp.Spec.HealthMonitorScope, gdp.Spec.MinMembers, gdp.Spec.WildcardMatch,
+		gdp.Spec.ResolveCname, gdp.Spec.UseEdnsClientSubnet, gdp.Spec.IsFederated, gdp.Spec.MinHealthMonitorsUp)
 	gf.ComputeChecksum()
 	Logf("ns: %s, object: NSFilter, msg: added/changed the global filter", gdp.ObjectMeta.Namespace)
 }
@@ -422,18 +793,36 @@ func getChecksumForDownResponse(dr *gslbalphav1.DownResponse) uint32 {
 	return cksum
 }
 
-func (gf *GlobalFilter) ComputeChecksum() {
+func getChecksumForGuardrails(guardrails *gdpv1alpha2.NamespacedPolicyGuardrails) uint32 {
+	if guardrails == nil {
+		return 0
+	}
+	clusters := make([]string, len(guardrails.AllowedClusters))
+	copy(clusters, guardrails.AllowedClusters)
+	sort.Strings(clusters)
+	hmRefs := make([]string, len(guardrails.AllowedHealthMonitorRefs))
+	copy(hmRefs, guardrails.AllowedHealthMonitorRefs)
+	sort.Strings(hmRefs)
+	cksum := utils.Hash("guardrails") + utils.Hash(utils.Stringify(clusters)) + utils.Hash(utils.Stringify(hmRefs))
+	if guardrails.TTLRange != nil {
+		cksum += utils.Hash(strconv.Itoa(guardrails.TTLRange.Min) + "-" + strconv.Itoa(guardrails.TTLRange.Max))
+	}
+	return cksum
+}
+
+func (gf *GDPFilter) ComputeChecksum() {
 	var cksum uint32
 	var hmRefs []string
 
 	if gf.AppFilter != nil {
-		cksum += utils.Hash(gf.AppFilter.Key + gf.AppFilter.Value)
+		cksum += utils.Hash(gf.AppFilter.Selector.String())
 	}
 	if gf.NSFilter != nil {
 		cksum += gf.NSFilter.GetChecksum()
 	}
 	for c, s := range gf.ApplicableClusters {
-		cksum += utils.Hash(c) + utils.Hash(utils.Stringify(s.SyncVipsOnly))
+		cksum += utils.Hash(c) + utils.Hash(utils.Stringify(s.SyncVipsOnly)) + utils.Hash(GetGeoLocationKey(s.Location)) +
+			utils.Hash(utils.Stringify(s.Drain))
 	}
 	for _, ts := range gf.TrafficSplit {
 		cksum += utils.Hash(ts.ClusterName + strconv.Itoa(int(ts.Weight)) + strconv.Itoa(int(ts.Priority)))
@@ -453,6 +842,10 @@ func (gf *GlobalFilter) ComputeChecksum() {
 	if gf.DefaultDomain != nil {
 		cksum += utils.Hash(*gf.DefaultDomain)
 	}
+	if gf.IPFamily != nil {
+		cksum += utils.Hash(*gf.IPFamily)
+	}
+	cksum += gf.GSSettings.GetChecksum()
 	cksum += getChecksumForPoolAlgorithm(gf.GslbPoolAlgorithm)
 	if gf.HealthMonitorTemplate != nil {
 		cksum += utils.Hash(*gf.HealthMonitorTemplate)
@@ -463,37 +856,26 @@ func (gf *GlobalFilter) ComputeChecksum() {
 		cksum += utils.Hash(utils.Stringify(hmRefs))
 	}
 	cksum += getChecksumForDownResponse(gf.GslbDownResponse)
+	cksum += getChecksumForGuardrails(gf.Guardrails)
 
 	gf.Checksum = cksum
 }
 
-func (gf *GlobalFilter) GetTrafficWeight(cname string) (uint32, error) {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+// getTrafficSplit returns the traffic split entry for a cluster, if present.
+func (gf *GDPFilter) getTrafficSplit(cname string) (ClusterTraffic, bool) {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 	for _, ts := range gf.TrafficSplit {
 		if ts.ClusterName == cname {
-			return ts.Weight, nil
+			return ts, true
 		}
 	}
-	Logf("cname: %s, msg: no weight available for this cluster", cname)
-	return 0, errors.New("no weight available for cluster " + cname)
+	return ClusterTraffic{}, false
 }
 
-func (gf *GlobalFilter) GetTrafficPriority(cname string) (uint32, error) {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
-	for _, ts := range gf.TrafficSplit {
-		if ts.ClusterName == cname {
-			return ts.Priority, nil
-		}
-	}
-	Logf("cname: %s, msg: no priority available for this cluster", cname)
-	return 0, errors.New("no priority available for cluster " + cname)
-}
-
-func (gf *GlobalFilter) IsClusterSyncVIPOnly(cname string) (bool, error) {
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+func (gf *GDPFilter) IsClusterSyncVIPOnly(cname string) (bool, error) {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	properties, exists := gf.ApplicableClusters[cname]
 	if !exists {
@@ -502,6 +884,18 @@ func (gf *GlobalFilter) IsClusterSyncVIPOnly(cname string) (bool, error) {
 	return properties.SyncVipsOnly, nil
 }
 
+func (gf *GDPFilter) getClusterLocation(cname string) *gslbalphav1.GeoLocation {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
+	return gf.ApplicableClusters[cname].Location
+}
+
+func (gf *GDPFilter) isClusterDrained(cname string) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
+	return gf.ApplicableClusters[cname].Drain
+}
+
 func PresentInList(key string, strList []string) bool {
 	for _, str := range strList {
 		if str == key {
@@ -551,22 +945,23 @@ func isTrafficWeightChanged(new, old *gdpv1alpha2.GlobalDeploymentPolicy) bool {
 	return false
 }
 
-func isSyncTypeChanged(new, old *gdpv1alpha2.GlobalDeploymentPolicy) []string {
-	// Return a list of clusters for which the sync type has changed
+func isClusterPropertyChanged(new, old *gdpv1alpha2.GlobalDeploymentPolicy) []string {
+	// Return a list of clusters for which the sync type, the location or the drain has changed
 	clustersToBeSynced := []string{}
-	clusters := make(map[string]bool)
+	clusters := make(map[string]gdpv1alpha2.ClusterProperty)
 	for _, c := range old.Spec.MatchClusters {
-		clusters[c.Cluster] = c.SyncVipOnly
+		clusters[c.Cluster] = c
 	}
 
 	for _, c := range new.Spec.MatchClusters {
-		oldSyncType, exists := clusters[c.Cluster]
+		oldProperty, exists := clusters[c.Cluster]
 		if !exists {
 			// cluster doesn't exist in the new gdp, it will be taken care of in the accepted/rejected
 			// logic anyway, so just continue
 			continue
 		}
-		if c.SyncVipOnly != oldSyncType {
+		if c.SyncVipOnly != oldProperty.SyncVipOnly || c.Drain != oldProperty.Drain ||
+			GetGeoLocationKey(c.Location) != GetGeoLocationKey(oldProperty.Location) {
 			clustersToBeSynced = append(clustersToBeSynced, c.Cluster)
 		}
 	}
@@ -665,27 +1060,47 @@ func IsControlPlaneHmOnlyChanged(old, new *gdpv1alpha2.GlobalDeploymentPolicy) b
 	return false
 }
 
+func IsIPFamilyChanged(old, new *gdpv1alpha2.GlobalDeploymentPolicy) bool {
+	if new.Spec.IPFamily == nil && old.Spec.IPFamily != nil {
+		return true
+	} else if new.Spec.IPFamily != nil && old.Spec.IPFamily == nil {
+		return true
+	} else if new.Spec.IPFamily != nil && old.Spec.IPFamily != nil && *new.Spec.IPFamily != *old.Spec.IPFamily {
+		return true
+	}
+	return false
+}
+
+func IsGSSettingsChanged(old, new *gdpv1alpha2.GlobalDeploymentPolicy) bool {
+	oldCksum := GetGSSettingsFromSpec(old.Spec.HealthMonitorScope, old.Spec.MinMembers, old.Spec.WildcardMatch,
+		old.Spec.ResolveCname, old.Spec.UseEdnsClientSubnet, old.Spec.IsFederated, old.Spec.MinHealthMonitorsUp).GetChecksum()
+	newCksum := GetGSSettingsFromSpec(new.Spec.HealthMonitorScope, new.Spec.MinMembers, new.Spec.WildcardMatch,
+		new.Spec.ResolveCname, new.Spec.UseEdnsClientSubnet, new.Spec.IsFederated, new.Spec.MinHealthMonitorsUp).GetChecksum()
+	return oldCksum != newCksum
+}
+
 func isAllGSPropertyChanged(new, old *gdpv1alpha2.GlobalDeploymentPolicy) bool {
 	return isHmRefsChanged(old, new) || isSitePersistenceChanged(old, new) ||
 		isTTLChanged(old, new) || isGslbPoolAlgorithmChanged(old, new) ||
 		isTrafficWeightChanged(new, old) || IsHmTemplateChanged(old, new) ||
 		IsDownResponseChanged(old, new) || isPkiProfileChanged(old, new) ||
-		IsControlPlaneHmOnlyChanged(old, new)
+		IsControlPlaneHmOnlyChanged(old, new) || IsIPFamilyChanged(old, new) ||
+		IsGSSettingsChanged(old, new)
 
 }
 
-// UpdateGlobalFilter takes two arguments: the old and the new GDP objects, and verifies
+// UpdateFilter takes two arguments: the old and the new GDP objects, and verifies
 // whether a change is required to any of the filters. If yes, it changes either the cluster
 // filter or one of the namespace filters.
-func (gf *GlobalFilter) UpdateGlobalFilter(oldGDP, newGDP *gdpv1alpha2.GlobalDeploymentPolicy) (bool, bool, []string) {
+func (gf *GDPFilter) UpdateFilter(oldGDP, newGDP *gdpv1alpha2.GlobalDeploymentPolicy) (bool, bool, []string) {
 	// Need to check for the NSFilterMap
-	nf := GetNewGlobalFilter()
+	nf := NewGDPFilter(gf.Name)
 	nf.AddToFilter(newGDP)
 
 	Logf("ns: %s, gdp: %s, msg: %s", oldGDP.ObjectMeta.Namespace, oldGDP.ObjectMeta.Name,
 		"got an update event")
-	gf.GlobalLock.Lock()
-	defer gf.GlobalLock.Unlock()
+	gf.Lock.Lock()
+	defer gf.Lock.Unlock()
 	Debugf("old checksum: %d, new checksum: %d", gf.Checksum, nf.Checksum)
 	if gf.Checksum == nf.Checksum {
 		// No updates needed, just return
@@ -707,30 +1122,21 @@ func (gf *GlobalFilter) UpdateGlobalFilter(oldGDP, newGDP *gdpv1alpha2.GlobalDep
 	gf.GslbDownResponse = nf.GslbDownResponse
 	gf.ControlPlaneHmOnly = nf.ControlPlaneHmOnly
 	gf.DefaultDomain = nf.DefaultDomain
+	gf.IPFamily = nf.IPFamily
+	gf.GSSettings = nf.GSSettings
+	gf.Guardrails = nf.Guardrails
 	gf.Checksum = nf.Checksum
 
-	clustersToBeSynced := isSyncTypeChanged(newGDP, oldGDP)
+	clustersToBeSynced := isClusterPropertyChanged(newGDP, oldGDP)
 
 	return true, isAllGSPropertyChanged(newGDP, oldGDP), clustersToBeSynced
 }
 
-// DeleteFromGlobalFilter deletes a filter pertaining to gdp.
-func (gf *GlobalFilter) DeleteFromGlobalFilter(gdp *gdpv1alpha2.GlobalDeploymentPolicy) {
-	gf.GlobalLock.Lock()
-	defer gf.GlobalLock.Unlock()
-	gf.AppFilter = nil
-	gf.NSFilter = nil
-	gf.ApplicableClusters = make(map[string]ClusterProperties)
-	gf.Checksum = 0
-	gf.TrafficSplit = []ClusterTraffic{}
-	gf.GslbDownResponse = &gslbalphav1.DownResponse{}
-}
-
-// GetNewGlobalFilter returns a new GlobalFilter. It is to be called only once with the
-// the GDP object as the input. Either the namespace of the GDP object is AVISystem
-// or its some other namespace. Based on that this GlobalFilter is created.
-func GetNewGlobalFilter() *GlobalFilter {
-	gf := &GlobalFilter{
+// NewGDPFilter returns a new filter for the GDP object with the given name. The filters
+// are populated via AddToFilter.
+func NewGDPFilter(name string) *GDPFilter {
+	gf := &GDPFilter{
+		Name:               name,
 		AppFilter:          nil,
 		NSFilter:           nil,
 		TrafficSplit:       []ClusterTraffic{},
@@ -743,6 +1149,7 @@ func GetNewGlobalFilter() *GlobalFilter {
 		GslbDownResponse:   nil,
 		ControlPlaneHmOnly: nil,
 		DefaultDomain:      nil,
+		IPFamily:           nil,
 	}
 	return gf
 }
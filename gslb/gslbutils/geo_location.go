@@ -0,0 +1,92 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gslbutils
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/vmware/alb-sdk/go/models"
+
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+const (
+	// NodeRegionLabel is the well known label on the nodes of a cluster from which the region of a
+	// member cluster is derived, if opted in via the useNodeRegion field of the member cluster.
+	NodeRegionLabel = "topology.kubernetes.io/region"
+
+	GeoLocationSourceUserConfigured = "GSLB_LOCATION_SRC_USER_CONFIGURED"
+)
+
+var clusterRegions = struct {
+	lock    sync.RWMutex
+	regions map[string]string
+}{regions: make(map[string]string)}
+
+// SetClusterRegion sets the region of a member cluster as derived from the labels of its nodes, an
+// empty region removes it.
+func SetClusterRegion(cname, region string) {
+	clusterRegions.lock.Lock()
+	defer clusterRegions.lock.Unlock()
+	if region == "" {
+		delete(clusterRegions.regions, cname)
+		return
+	}
+	clusterRegions.regions[cname] = region
+}
+
+func GetClusterRegion(cname string) string {
+	clusterRegions.lock.RLock()
+	defer clusterRegions.lock.RUnlock()
+	return clusterRegions.regions[cname]
+}
+
+// GetGeoLocationKey returns a string representation of the location which is used in the checksums.
+func GetGeoLocationKey(loc *gslbalphav1.GeoLocation) string {
+	if loc == nil {
+		return ""
+	}
+	key := loc.Name + "/" + loc.Region
+	if loc.Latitude != nil && loc.Longitude != nil {
+		key += "/" + strconv.FormatFloat(*loc.Latitude, 'f', -1, 64) + "/" + strconv.FormatFloat(*loc.Longitude, 'f', -1, 64)
+	}
+	return key
+}
+
+// BuildGslbGeoLocation returns the location of a GslbService pool member for the location of a
+// member cluster or a third party member.
+func BuildGslbGeoLocation(loc *gslbalphav1.GeoLocation) *models.GslbGeoLocation {
+	if loc == nil {
+		return nil
+	}
+	source := GeoLocationSourceUserConfigured
+	geoLocation := &models.GeoLocation{}
+	if loc.Name != "" {
+		name := loc.Name
+		geoLocation.Name = &name
+	}
+	if loc.Region != "" {
+		region := loc.Region
+		geoLocation.Tag = &region
+	}
+	if loc.Latitude != nil && loc.Longitude != nil {
+		latitude := float32(*loc.Latitude)
+		longitude := float32(*loc.Longitude)
+		geoLocation.Latitude = &latitude
+		geoLocation.Longitude = &longitude
+	}
+	return &models.GslbGeoLocation{Source: &source, Location: geoLocation}
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gslbutils
+
+import (
+	"sort"
+	"sync"
+)
+
+// GSGDPMap keeps a track of the GDP objects which select the members of each GslbService. The
+// first GDP object (in the order of precedence) owns the GslbService, the rest of the GDP objects
+// are in conflict with the owner for that GslbService.
+type GSGDPMap struct {
+	gsGDPs map[string][]string
+	// changedGDPs contains the GDP objects whose GslbServices have changed since the last
+	// time their status was published
+	changedGDPs map[string]struct{}
+	GlobalLock  sync.RWMutex
+}
+
+var gsGDPMap *GSGDPMap
+var gsGDPMapOnce sync.Once
+
+func GetGSGDPMap() *GSGDPMap {
+	gsGDPMapOnce.Do(func() {
+		gsGDPMap = &GSGDPMap{
+			gsGDPs:      make(map[string][]string),
+			changedGDPs: make(map[string]struct{}),
+		}
+	})
+	return gsGDPMap
+}
+
+func (gm *GSGDPMap) markChanged(gdps []string) {
+	for _, gdp := range gdps {
+		gm.changedGDPs[gdp] = struct{}{}
+	}
+}
+
+// SetGDPsForGS sets the GDP objects selecting the members of a GslbService, gdps must be in the
+// order of precedence. Returns true if the list of GDP objects changed.
+func (gm *GSGDPMap) SetGDPsForGS(gsName string, gdps []string) bool {
+	newGDPs := make([]string, len(gdps))
+	copy(newGDPs, gdps)
+
+	gm.GlobalLock.Lock()
+	defer gm.GlobalLock.Unlock()
+	oldGDPs := gm.gsGDPs[gsName]
+	if len(oldGDPs) == len(newGDPs) {
+		same := true
+		for idx := range oldGDPs {
+			if oldGDPs[idx] != newGDPs[idx] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return false
+		}
+	}
+	gm.markChanged(oldGDPs)
+	gm.markChanged(newGDPs)
+	gm.gsGDPs[gsName] = newGDPs
+	return true
+}
+
+func (gm *GSGDPMap) GetGDPsForGS(gsName string) []string {
+	gm.GlobalLock.RLock()
+	defer gm.GlobalLock.RUnlock()
+	gdps := make([]string, len(gm.gsGDPs[gsName]))
+	copy(gdps, gm.gsGDPs[gsName])
+	return gdps
+}
+
+func (gm *GSGDPMap) DeleteGS(gsName string) {
+	gm.GlobalLock.Lock()
+	defer gm.GlobalLock.Unlock()
+	gdps, ok := gm.gsGDPs[gsName]
+	if !ok {
+		return
+	}
+	gm.markChanged(gdps)
+	delete(gm.gsGDPs, gsName)
+}
+
+// MarkGDPChanged marks a GDP object for a status update, even if its GslbServices didn't change.
+func (gm *GSGDPMap) MarkGDPChanged(gdp string) {
+	gm.GlobalLock.Lock()
+	defer gm.GlobalLock.Unlock()
+	gm.changedGDPs[gdp] = struct{}{}
+}
+
+// PopChangedGDPs returns the GDP objects marked for a status update and clears the markers.
+func (gm *GSGDPMap) PopChangedGDPs() []string {
+	gm.GlobalLock.Lock()
+	defer gm.GlobalLock.Unlock()
+	gdps := make([]string, 0, len(gm.changedGDPs))
+	for gdp := range gm.changedGDPs {
+		gdps = append(gdps, gdp)
+	}
+	gm.changedGDPs = make(map[string]struct{})
+	sort.Strings(gdps)
+	return gdps
+}
+
+// GetGDPGslbServices returns the GslbServices owned by a GDP object, and the GslbServices for
+// which the GDP object is in conflict with the owner GDP object.
+func (gm *GSGDPMap) GetGDPGslbServices(gdp string) ([]string, []string) {
+	gm.GlobalLock.RLock()
+	defer gm.GlobalLock.RUnlock()
+	owned := []string{}
+	conflicts := []string{}
+	for gsName, gdps := range gm.gsGDPs {
+		if len(gdps) == 0 {
+			continue
+		}
+		if gdps[0] == gdp {
+			owned = append(owned, gsName)
+		} else if PresentInList(gdp, gdps) {
+			conflicts = append(conflicts, gsName+" (owned by "+gdps[0]+")")
+		}
+	}
+	sort.Strings(owned)
+	sort.Strings(conflicts)
+	return owned, conflicts
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gslbutils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// GSSettings are the properties of a GslbService which can be set via the GDP and GSLBHostRule
+// objects. A nil field takes AMKO's default for that property.
+type GSSettings struct {
+	HealthMonitorScope  *string
+	MinMembers          *uint32
+	WildcardMatch       *bool
+	ResolveCname        *bool
+	UseEdnsClientSubnet *bool
+	IsFederated         *bool
+	// MinHealthMonitorsUp is set on all the pools of the GslbService
+	MinHealthMonitorsUp *uint32
+	// PoolsMinHealthMonitorsUp is the minimum number of health monitors up of each pool of the
+	// GslbService, in the order of the pools. It is derived by AMKO and can't be set via the GDP
+	// and GSLBHostRule objects.
+	PoolsMinHealthMonitorsUp []uint32
+}
+
+// GetGSSettingsFromSpec builds the GSSettings from the fields of a GDP or a GSLBHostRule object.
+func GetGSSettingsFromSpec(hmScope *string, minMembers *int, wildcardMatch, resolveCname, useEdnsClientSubnet,
+	isFederated *bool, minHealthMonitorsUp *int) GSSettings {
+	s := GSSettings{
+		HealthMonitorScope:  copyString(hmScope),
+		WildcardMatch:       copyBool(wildcardMatch),
+		ResolveCname:        copyBool(resolveCname),
+		UseEdnsClientSubnet: copyBool(useEdnsClientSubnet),
+		IsFederated:         copyBool(isFederated),
+	}
+	if minMembers != nil {
+		val := uint32(*minMembers)
+		s.MinMembers = &val
+	}
+	if minHealthMonitorsUp != nil {
+		val := uint32(*minHealthMonitorsUp)
+		s.MinHealthMonitorsUp = &val
+	}
+	return s
+}
+
+func (s GSSettings) DeepCopy() GSSettings {
+	return GSSettings{
+		HealthMonitorScope:       copyString(s.HealthMonitorScope),
+		MinMembers:               copyUint32(s.MinMembers),
+		WildcardMatch:            copyBool(s.WildcardMatch),
+		ResolveCname:             copyBool(s.ResolveCname),
+		UseEdnsClientSubnet:      copyBool(s.UseEdnsClientSubnet),
+		IsFederated:              copyBool(s.IsFederated),
+		MinHealthMonitorsUp:      copyUint32(s.MinHealthMonitorsUp),
+		PoolsMinHealthMonitorsUp: copyUint32List(s.PoolsMinHealthMonitorsUp),
+	}
+}
+
+// Merge sets the fields of s which aren't set from o, so that the fields already set in s take
+// precedence.
+func (s *GSSettings) Merge(o GSSettings) {
+	if s.HealthMonitorScope == nil {
+		s.HealthMonitorScope = copyString(o.HealthMonitorScope)
+	}
+	if s.MinMembers == nil {
+		s.MinMembers = copyUint32(o.MinMembers)
+	}
+	if s.WildcardMatch == nil {
+		s.WildcardMatch = copyBool(o.WildcardMatch)
+	}
+	if s.ResolveCname == nil {
+		s.ResolveCname = copyBool(o.ResolveCname)
+	}
+	if s.UseEdnsClientSubnet == nil {
+		s.UseEdnsClientSubnet = copyBool(o.UseEdnsClientSubnet)
+	}
+	if s.IsFederated == nil {
+		s.IsFederated = copyBool(o.IsFederated)
+	}
+	if s.MinHealthMonitorsUp == nil {
+		s.MinHealthMonitorsUp = copyUint32(o.MinHealthMonitorsUp)
+	}
+}
+
+// WithDefaults returns the settings with the fields which aren't set taking AMKO's defaults,
+// minHealthMonitorsUp is the default for MinHealthMonitorsUp as it depends on the health monitors
+// of the GslbService.
+func (s GSSettings) WithDefaults(minHealthMonitorsUp uint32) GSSettings {
+	d := s.DeepCopy()
+	if d.HealthMonitorScope == nil {
+		hmScope := DefaultGSHealthMonitorScope
+		d.HealthMonitorScope = &hmScope
+	}
+	if d.MinMembers == nil {
+		minMembers := uint32(DefaultGSMinMembers)
+		d.MinMembers = &minMembers
+	}
+	if d.WildcardMatch == nil {
+		wildcardMatch := DefaultGSWildcardMatch
+		d.WildcardMatch = &wildcardMatch
+	}
+	if d.ResolveCname == nil {
+		resolveCname := DefaultGSResolveCname
+		d.ResolveCname = &resolveCname
+	}
+	if d.UseEdnsClientSubnet == nil {
+		useEdnsClientSubnet := DefaultGSUseEdnsClientSubnet
+		d.UseEdnsClientSubnet = &useEdnsClientSubnet
+	}
+	if d.IsFederated == nil {
+		isFederated := DefaultGSIsFederated
+		d.IsFederated = &isFederated
+	}
+	if d.MinHealthMonitorsUp == nil {
+		d.MinHealthMonitorsUp = &minHealthMonitorsUp
+	}
+	return d
+}
+
+// GetChecksum returns the checksum of the fields which are set.
+func (s GSSettings) GetChecksum() uint32 {
+	var cksum uint32
+	if s.HealthMonitorScope != nil {
+		cksum += utils.Hash("healthMonitorScope:" + *s.HealthMonitorScope)
+	}
+	if s.MinMembers != nil {
+		cksum += utils.Hash("minMembers:" + strconv.Itoa(int(*s.MinMembers)))
+	}
+	if s.WildcardMatch != nil {
+		cksum += utils.Hash("wildcardMatch:" + strconv.FormatBool(*s.WildcardMatch))
+	}
+	if s.ResolveCname != nil {
+		cksum += utils.Hash("resolveCname:" + strconv.FormatBool(*s.ResolveCname))
+	}
+	if s.UseEdnsClientSubnet != nil {
+		cksum += utils.Hash("useEdnsClientSubnet:" + strconv.FormatBool(*s.UseEdnsClientSubnet))
+	}
+	if s.IsFederated != nil {
+		cksum += utils.Hash("isFederated:" + strconv.FormatBool(*s.IsFederated))
+	}
+	if s.MinHealthMonitorsUp != nil {
+		cksum += utils.Hash("minHealthMonitorsUp:" + strconv.Itoa(int(*s.MinHealthMonitorsUp)))
+	}
+	if s.PoolsMinHealthMonitorsUp != nil {
+		poolsMinHmUp := make([]string, len(s.PoolsMinHealthMonitorsUp))
+		for idx, minHmUp := range s.PoolsMinHealthMonitorsUp {
+			poolsMinHmUp[idx] = strconv.Itoa(int(minHmUp))
+		}
+		cksum += utils.Hash("poolsMinHealthMonitorsUp:" + strings.Join(poolsMinHmUp, ","))
+	}
+	return cksum
+}
+
+func copyString(in *string) *string {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func copyBool(in *bool) *bool {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func copyUint32(in *uint32) *uint32 {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
+
+func copyUint32List(in []uint32) []uint32 {
+	if in == nil {
+		return nil
+	}
+	out := make([]uint32, len(in))
+	copy(out, in)
+	return out
+}
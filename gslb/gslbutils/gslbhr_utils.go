@@ -15,8 +15,11 @@
 package gslbutils
 
 import (
+	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -32,11 +35,14 @@ type GSHostRules struct {
 	SitePersistence    *gslbhralphav1.SitePersistence
 	TTL                *uint32
 	TrafficSplit       []gslbhralphav1.TrafficSplitElem
+	MemberTrafficSplit []gslbhralphav1.MemberTrafficSplitElem
 	PublicIP           []gslbhralphav1.PublicIPElem
 	ThirdPartyMembers  []gslbhralphav1.ThirdPartyMember
 	GslbPoolAlgorithm  *gslbhralphav1.PoolAlgorithmSettings
 	GslbDownResponse   *gslbhralphav1.DownResponse
 	ControlPlaneHmOnly *bool
+	IPFamily           *string
+	GSSettings         GSSettings
 	Checksum           uint32
 	Lock               *sync.RWMutex
 }
@@ -59,7 +65,9 @@ func (in *GSHostRules) DeepCopyInto(out *GSHostRules) {
 	if in.ThirdPartyMembers != nil {
 		in, out := &in.ThirdPartyMembers, &out.ThirdPartyMembers
 		*out = make([]gslbhralphav1.ThirdPartyMember, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.HmRefs != nil {
 		in, out := &in.HmRefs, &out.HmRefs
@@ -71,6 +79,11 @@ func (in *GSHostRules) DeepCopyInto(out *GSHostRules) {
 		*out = make([]gslbhralphav1.TrafficSplitElem, len((*in)))
 		copy(*out, *in)
 	}
+	if in.MemberTrafficSplit != nil {
+		in, out := &in.MemberTrafficSplit, &out.MemberTrafficSplit
+		*out = make([]gslbhralphav1.MemberTrafficSplitElem, len((*in)))
+		copy(*out, *in)
+	}
 	if in.PublicIP != nil {
 		in, out := &in.PublicIP, &out.PublicIP
 		*out = make([]gslbhralphav1.PublicIPElem, len((*in)))
@@ -86,6 +99,12 @@ func (in *GSHostRules) DeepCopyInto(out *GSHostRules) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(string)
+		**out = **in
+	}
+	out.GSSettings = in.GSSettings.DeepCopy()
 	out.Lock = new(sync.RWMutex)
 
 	out.GslbPoolAlgorithm = in.GslbPoolAlgorithm.DeepCopy()
@@ -117,9 +136,16 @@ func (ghr *GSHostRules) CalculateAndSetChecksum() {
 		clusterWeights = append(clusterWeights, c.Cluster+weight+priority)
 	}
 	sort.Strings(clusterWeights)
+	memberWeights := []string{}
+	for _, m := range ghr.MemberTrafficSplit {
+		weight := strconv.Itoa(int(m.Weight))
+		priority := strconv.Itoa(int(m.Priority))
+		memberWeights = append(memberWeights, m.Cluster+m.Namespace+m.Kind+m.Name+weight+priority)
+	}
+	sort.Strings(memberWeights)
 	thirdPartyMembers := []string{}
 	for _, tp := range ghr.ThirdPartyMembers {
-		thirdPartyMembers = append(thirdPartyMembers, tp.Site+tp.VIP+tp.PublicIP)
+		thirdPartyMembers = append(thirdPartyMembers, tp.Site+tp.VIP+tp.PublicIP+GetGeoLocationKey(tp.Location))
 	}
 	sort.Strings(thirdPartyMembers)
 	ipWeights := []string{}
@@ -133,11 +159,16 @@ func (ghr *GSHostRules) CalculateAndSetChecksum() {
 	if ghr.ControlPlaneHmOnly != nil {
 		cksum += utils.Hash(utils.Stringify(*ghr.ControlPlaneHmOnly))
 	}
+	if ghr.IPFamily != nil {
+		cksum += utils.Hash(*ghr.IPFamily)
+	}
+	cksum += ghr.GSSettings.GetChecksum()
 
 	cksum += utils.Hash(utils.Stringify(ghr.HmRefs)) +
 		utils.Hash(sitePersistence) +
 		utils.Hash(utils.Stringify(ttl)) +
 		utils.Hash(utils.Stringify(clusterWeights)) +
+		utils.Hash(utils.Stringify(memberWeights)) +
 		utils.Hash(utils.Stringify(thirdPartyMembers)) +
 		getChecksumForPoolAlgorithm(ghr.GslbPoolAlgorithm) +
 		getChecksumForDownResponse(ghr.GslbDownResponse) +
@@ -159,6 +190,10 @@ func GetGSHostRuleForGSLBHR(gslbhr *gslbhralphav1.GSLBHostRule) *GSHostRules {
 	gsHostRules := GSHostRules{
 		GSFqdn:             gslbhrSpec.Fqdn,
 		ControlPlaneHmOnly: gslbhrSpec.ControlPlaneHmOnly,
+		IPFamily:           gslbhrSpec.IPFamily,
+		GSSettings: GetGSSettingsFromSpec(gslbhrSpec.HealthMonitorScope, gslbhrSpec.MinMembers,
+			gslbhrSpec.WildcardMatch, gslbhrSpec.ResolveCname, gslbhrSpec.UseEdnsClientSubnet,
+			gslbhrSpec.IsFederated, gslbhrSpec.MinHealthMonitorsUp),
 	}
 	if gslbhrSpec.SitePersistence != nil {
 		gsHostRules.SitePersistence = &gslbhralphav1.SitePersistence{
@@ -175,6 +210,8 @@ func GetGSHostRuleForGSLBHR(gslbhr *gslbhralphav1.GSLBHostRule) *GSHostRules {
 	copy(gsHostRules.ThirdPartyMembers, gslbhrSpec.ThirdPartyMembers)
 	gsHostRules.TrafficSplit = make([]gslbhralphav1.TrafficSplitElem, len(gslbhrSpec.TrafficSplit))
 	copy(gsHostRules.TrafficSplit, gslbhrSpec.TrafficSplit)
+	gsHostRules.MemberTrafficSplit = make([]gslbhralphav1.MemberTrafficSplitElem, len(gslbhrSpec.MemberTrafficSplit))
+	copy(gsHostRules.MemberTrafficSplit, gslbhrSpec.MemberTrafficSplit)
 	gsHostRules.HmRefs = make([]string, len(gslbhrSpec.HealthMonitorRefs))
 	copy(gsHostRules.HmRefs, gslbhrSpec.HealthMonitorRefs)
 	gsHostRules.GslbPoolAlgorithm = gslbhrSpec.PoolAlgorithmSettings.DeepCopy()
@@ -190,9 +227,65 @@ func GetGSHostRuleForGSLBHR(gslbhr *gslbhralphav1.GSLBHostRule) *GSHostRules {
 	return &gsHostRules
 }
 
+// Kinds of the member objects which can be specified in a GSLBHostRule's memberTrafficSplit.
+const (
+	MemberKindIngress             = "Ingress"
+	MemberKindRoute               = "Route"
+	MemberKindService             = "Service"
+	MemberKindMultiClusterIngress = "MultiClusterIngress"
+	MemberKindHTTPRoute           = "HTTPRoute"
+)
+
+// GetObjTypeForMemberKind returns the GS member object type for a member kind.
+func GetObjTypeForMemberKind(kind string) (string, error) {
+	switch kind {
+	case MemberKindIngress:
+		return IngressType, nil
+	case MemberKindRoute:
+		return RouteType, nil
+	case MemberKindService:
+		return SvcType, nil
+	case MemberKindMultiClusterIngress:
+		return MCIType, nil
+	case MemberKindHTTPRoute:
+		return HTTPRouteType, nil
+	}
+	return "", fmt.Errorf("member kind %s is invalid, must be one of %s, %s, %s, %s or %s", kind, MemberKindIngress,
+		MemberKindRoute, MemberKindService, MemberKindMultiClusterIngress, MemberKindHTTPRoute)
+}
+
+// GetMemberObjName returns the name of the k8s object for a GS member. The ingress, multi-cluster
+// ingress and HTTPRoute members are named as "name/hostname".
+func GetMemberObjName(objType, name string) string {
+	if objType == IngressType || objType == MCIType || objType == HTTPRouteType {
+		return strings.Split(name, "/")[0]
+	}
+	return name
+}
+
+// GetMemberTrafficSplit returns the element of memberTrafficSplit which applies to the GS member
+// identified by cname, ns, objType and name, nil if there's none.
+func GetMemberTrafficSplit(memberTrafficSplit []gslbhralphav1.MemberTrafficSplitElem, cname, ns, objType,
+	name string) *gslbhralphav1.MemberTrafficSplitElem {
+
+	objName := GetMemberObjName(objType, name)
+	for idx, m := range memberTrafficSplit {
+		if m.Cluster != cname || m.Namespace != ns || m.Name != objName {
+			continue
+		}
+		if mObjType, err := GetObjTypeForMemberKind(m.Kind); err == nil && mObjType == objType {
+			return &memberTrafficSplit[idx]
+		}
+	}
+	return nil
+}
+
 type GSFqdnHostRules struct {
 	GSHostRuleList map[string]*GSHostRules
-	GlobalLock     sync.RWMutex
+	// TrafficShifts has the traffic split set by the GSLBTrafficShift objects for each fqdn, which
+	// overrides the traffic split of the GSLBHostRule for the same clusters
+	TrafficShifts map[string][]gslbhralphav1.TrafficSplitElem
+	GlobalLock    sync.RWMutex
 }
 
 var gsFqdnHostRules *GSFqdnHostRules
@@ -201,7 +294,10 @@ var ghrSyncOnce sync.Once
 func GetGSHostRulesList() *GSFqdnHostRules {
 	ghrSyncOnce.Do(func() {
 		hostRules := make(map[string]*GSHostRules)
-		gsFqdnHostRules = &GSFqdnHostRules{GSHostRuleList: hostRules}
+		gsFqdnHostRules = &GSFqdnHostRules{
+			GSHostRuleList: hostRules,
+			TrafficShifts:  make(map[string][]gslbhralphav1.TrafficSplitElem),
+		}
 	})
 	return gsFqdnHostRules
 }
@@ -250,3 +346,58 @@ func (ghrules *GSFqdnHostRules) DeleteGSHostRulesForFQDN(fqdn string) {
 
 	delete(ghrules.GSHostRuleList, fqdn)
 }
+
+// SetTrafficShiftForFQDN sets the traffic split of a GSLBTrafficShift object for an fqdn, returns
+// false if it's unchanged.
+func (ghrules *GSFqdnHostRules) SetTrafficShiftForFQDN(fqdn string, trafficSplit []gslbhralphav1.TrafficSplitElem) bool {
+	ghrules.GlobalLock.Lock()
+	defer ghrules.GlobalLock.Unlock()
+
+	if existing, ok := ghrules.TrafficShifts[fqdn]; ok && reflect.DeepEqual(existing, trafficSplit) {
+		return false
+	}
+	split := make([]gslbhralphav1.TrafficSplitElem, len(trafficSplit))
+	copy(split, trafficSplit)
+	ghrules.TrafficShifts[fqdn] = split
+	return true
+}
+
+// DeleteTrafficShiftForFQDN removes the traffic split of a GSLBTrafficShift object for an fqdn,
+// returns false if there wasn't one.
+func (ghrules *GSFqdnHostRules) DeleteTrafficShiftForFQDN(fqdn string) bool {
+	ghrules.GlobalLock.Lock()
+	defer ghrules.GlobalLock.Unlock()
+
+	if _, ok := ghrules.TrafficShifts[fqdn]; !ok {
+		return false
+	}
+	delete(ghrules.TrafficShifts, fqdn)
+	return true
+}
+
+// ApplyTrafficShift overrides the traffic split in ghr with the traffic split of a GSLBTrafficShift
+// object for the fqdn, cluster by cluster. Returns false if there's no traffic shift for the fqdn.
+func (ghrules *GSFqdnHostRules) ApplyTrafficShift(fqdn string, ghr *GSHostRules) bool {
+	ghrules.GlobalLock.RLock()
+	defer ghrules.GlobalLock.RUnlock()
+
+	shift, ok := ghrules.TrafficShifts[fqdn]
+	if !ok {
+		return false
+	}
+	trafficSplit := make([]gslbhralphav1.TrafficSplitElem, 0, len(ghr.TrafficSplit)+len(shift))
+	for _, ts := range ghr.TrafficSplit {
+		shifted := false
+		for _, s := range shift {
+			if s.Cluster == ts.Cluster {
+				shifted = true
+				break
+			}
+		}
+		if !shifted {
+			trafficSplit = append(trafficSplit, ts)
+		}
+	}
+	ghr.TrafficSplit = append(trafficSplit, shift...)
+	return true
+}
@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	routev1 "github.com/openshift/api/route/v1"
@@ -65,7 +66,18 @@ func SetInformersPerCluster(clusterName string, info *utils.Informers) {
 	InformersPerCluster.AviCacheAdd(clusterName, info)
 }
 
+// DeleteInformersPerCluster removes the informers of a member cluster removed from the GSLBConfig object.
+func DeleteInformersPerCluster(clusterName string) {
+	if InformersPerCluster == nil {
+		return
+	}
+	InformersPerCluster.AviCacheDelete(clusterName)
+}
+
 func GetInformersPerCluster(clusterName string) *utils.Informers {
+	if InformersPerCluster == nil {
+		return nil
+	}
 	info, ok := InformersPerCluster.AviCacheGet(clusterName)
 	if !ok {
 		utils.AviLog.Warnf("Failed to get informer for cluster %v", clusterName)
@@ -120,7 +132,8 @@ func ExtractMultiClusterKey(key string) (string, string, string, string, string,
 	segments := strings.Split(key, "/")
 	var operation, objType, cluster, ns, name, hostname, tenant string
 	if segments[1] == IngressType ||
-		segments[1] == MCIType {
+		segments[1] == MCIType ||
+		segments[1] == HTTPRouteType {
 		if len(segments) == IngMultiClusterKeyLen {
 			operation, objType, cluster, ns, name, hostname, tenant = segments[0], segments[1], segments[2], segments[3], segments[4], segments[5], segments[6]
 			name += "/" + hostname
@@ -190,11 +203,21 @@ func SplitMultiClusterNS(name string) (string, string, error) {
 }
 
 func RouteGetIPAddr(route *routev1.Route) (string, bool) {
+	ipAddrs, ok := RouteGetIPAddrs(route)
+	if !ok {
+		return "", false
+	}
+	return ipAddrs[0], true
+}
+
+// RouteGetIPAddrs returns all the IP addresses (IPv4 and IPv6) populated by ako in a route's status
+// field for the route's hostname. Returns false if no IP address is present.
+func RouteGetIPAddrs(route *routev1.Route) ([]string, bool) {
 	hostname := route.Spec.Host
 	if hostname == "" {
 		hostname = GetHostnameforSubdomain(route.Spec.Subdomain)
 	}
-	// Return true if the IP address is present in an route's status field, else return false
+	ipAddrs := []string{}
 	routeStatus := route.Status
 	for _, ingr := range routeStatus.Ingress {
 		// check if the status message was populated by ako
@@ -213,12 +236,21 @@ func RouteGetIPAddr(route *routev1.Route) (string, bool) {
 			}
 			// Check if this is a IP address
 			addr := net.ParseIP(condition.Message)
-			if addr != nil {
-				return condition.Message, true
+			if addr != nil && !PresentInList(condition.Message, ipAddrs) {
+				ipAddrs = append(ipAddrs, condition.Message)
 			}
 		}
 	}
-	return "", false
+	return ipAddrs, len(ipAddrs) != 0
+}
+
+// GetIPVersion returns the address type (V4 or V6) of an IP address.
+func GetIPVersion(ipAddr string) string {
+	ip := net.ParseIP(ipAddr)
+	if ip != nil && ip.To4() == nil {
+		return IPVersionV6
+	}
+	return IPVersionV4
 }
 
 func GetHostnameforSubdomain(subdomain string) string {
@@ -239,6 +271,8 @@ func GetHostnameforSubdomain(subdomain string) string {
 type IngressHostIP struct {
 	Hostname string
 	IPAddr   string
+	// IPAddrs contains all the status IPs (IPv4 and IPv6) for the hostname, IPAddr being the first one
+	IPAddrs []string
 }
 
 func getHostListFromIngress(ingress *networkingv1.Ingress) []string {
@@ -251,6 +285,20 @@ func getHostListFromIngress(ingress *networkingv1.Ingress) []string {
 	return hostList
 }
 
+// getIngressRuleHost returns the rule host of an ingress for the hostname of a status entry, empty if
+// there's none. The status hostname of a wildcard rule host, e.g. "*.apps.example.com", can either be
+// the wildcard host itself or its domain, "apps.example.com", as the API server only accepts DNS-1123
+// subdomains as the status hostnames. An exact match takes precedence.
+func getIngressRuleHost(hostList []string, statusHostname string) string {
+	if utils.HasElem(hostList, statusHostname) {
+		return statusHostname
+	}
+	if utils.HasElem(hostList, "*."+statusHostname) {
+		return "*." + statusHostname
+	}
+	return ""
+}
+
 func IngressGetIPAddrs(ingress *networkingv1.Ingress) []IngressHostIP {
 	ingHostIP := []IngressHostIP{}
 	hostList := getHostListFromIngress(ingress)
@@ -273,10 +321,27 @@ func IngressGetIPAddrs(ingress *networkingv1.Ingress) []IngressHostIP {
 			Warnf("Hostname is empty in ingress %s", ingress.Name)
 			continue
 		}
-		if utils.HasElem(hostList, ingr.Hostname) {
+		hostname := getIngressRuleHost(hostList, ingr.Hostname)
+		if hostname == "" {
+			continue
+		}
+		// a dual-stack ingress will have multiple status IPs for the same hostname
+		found := false
+		for idx := range ingHostIP {
+			if ingHostIP[idx].Hostname != hostname {
+				continue
+			}
+			found = true
+			if !PresentInList(ingr.IP, ingHostIP[idx].IPAddrs) {
+				ingHostIP[idx].IPAddrs = append(ingHostIP[idx].IPAddrs, ingr.IP)
+			}
+			break
+		}
+		if !found {
 			ingHostIP = append(ingHostIP, IngressHostIP{
-				Hostname: ingr.Hostname,
+				Hostname: hostname,
 				IPAddr:   ingr.IP,
+				IPAddrs:  []string{ingr.IP},
 			})
 		}
 	}
@@ -297,7 +362,7 @@ var Debugf = utils.AviLog.Debugf
 
 func GetGSLBServiceChecksum(serverList, domainList, memberObjs, hmNames []string,
 	persistenceProfileRef *string, ttl *uint32, pa *gslbalphav1.PoolAlgorithmSettings,
-	downResponse *gslbalphav1.DownResponse, pkiProfileRef *string, createdBy string) uint32 {
+	downResponse *gslbalphav1.DownResponse, pkiProfileRef *string, gsSettings GSSettings, createdBy string) uint32 {
 
 	sort.Strings(serverList)
 	sort.Strings(domainList)
@@ -324,9 +389,31 @@ func GetGSLBServiceChecksum(serverList, domainList, memberObjs, hmNames []string
 	}
 	cksum += getChecksumForPoolAlgorithm(pa)
 	cksum += getChecksumForDownResponse(downResponse)
+	cksum += gsSettings.GetChecksum()
 	return cksum
 }
 
+// GetChecksumForIPFamily returns the checksum for the address family of the GS pool members. The
+// family is derived from the member addresses, so that the graph and the GS fetched from the
+// controller compute the same value. Only V4 members add nothing, to keep the existing checksums.
+func GetChecksumForIPFamily(memberAddrs []string) uint32 {
+	var v4Found, v6Found bool
+	for _, addr := range memberAddrs {
+		if GetIPVersion(addr) == IPVersionV6 {
+			v6Found = true
+		} else {
+			v4Found = true
+		}
+	}
+	if !v6Found {
+		return 0
+	}
+	if v4Found {
+		return utils.Hash(gslbalphav1.IPFamilyDualStack)
+	}
+	return utils.Hash(gslbalphav1.IPFamilyV6)
+}
+
 // description is taken as []string
 // For path based Hms, the checksum is computed for all paths
 func GetGSLBHmChecksum(hmType string, port int32, description []string, createdBy string) uint32 {
@@ -375,6 +462,17 @@ func GetGSLBConfigNameAndNS() (string, string) {
 	return gcObj.configObj.Name, gcObj.configObj.Namespace
 }
 
+// GetGSLBConfigStatusMsg returns the status message of the accepted GSLBConfig object.
+func GetGSLBConfigStatusMsg() string {
+	gcObj.configLock.Lock()
+	defer gcObj.configLock.Unlock()
+
+	if gcObj.configObj == nil {
+		return ""
+	}
+	return gcObj.configObj.Status.State
+}
+
 func updateGSLBConfigStatusMsg(msg string) {
 	gcObj.configLock.Lock()
 	defer gcObj.configLock.Unlock()
@@ -444,6 +542,44 @@ func UpdateGSLBConfigStatus(msg string) error {
 	return nil
 }
 
+// UpdateGSLBConfigAdoptionConflicts sets the existing GslbServices which couldn't be adopted in the
+// status of the GSLBConfig object, an empty list clears them.
+func UpdateGSLBConfigAdoptionConflicts(conflicts []gslbalphav1.AdoptionConflict) error {
+	if !AMKOControlConfig().PublishGSLBStatus() {
+		return nil
+	}
+	gcObj.configLock.Lock()
+	if gcObj.configObj == nil {
+		gcObj.configLock.Unlock()
+		return nil
+	}
+	name, ns := gcObj.configObj.Name, gcObj.configObj.Namespace
+	gcObj.configLock.Unlock()
+
+	// a null value removes the field in a merge patch
+	var conflictsVal interface{}
+	if len(conflicts) != 0 {
+		conflictsVal = conflicts
+	}
+	patchPayload, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"adoptionConflicts": conflictsVal,
+		},
+	})
+	if err != nil {
+		Errf("Error in marshalling adoption conflicts for GC object: %v", err)
+		return nil
+	}
+	updatedGC, updateErr := AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBConfigs(ns).Patch(context.TODO(),
+		name, types.MergePatchType, patchPayload, metav1.PatchOptions{})
+	if updateErr != nil {
+		Errf("error in updating the adoption conflicts of the GSLBConfig object: %s", updateErr.Error())
+		return errors.New("error in GSLBConfig object update, " + updateErr.Error())
+	}
+	SetGSLBConfigObj(updatedGC)
+	return nil
+}
+
 // gslbConfigSet and its setter and getter functions, to be used by the AddGSLBConfig method. This value
 // is set to true once a GSLB Configuration has been successfully done.
 var gslbConfigSet bool = false
@@ -531,15 +667,72 @@ func CheckTenant(namespace, cname, tenant string) bool {
 }
 
 var allClusterContexts []string
+var clusterContextsLock sync.RWMutex
 
 func AddClusterContext(cc string) {
-	if IsClusterContextPresent(cc) {
+	clusterContextsLock.Lock()
+	defer clusterContextsLock.Unlock()
+	if PresentInList(cc, allClusterContexts) {
 		return
 	}
 	allClusterContexts = append(allClusterContexts, cc)
 }
 
+// RemoveClusterContext removes a member cluster context, once the cluster is removed from the
+// GSLBConfig object.
+func RemoveClusterContext(cc string) {
+	clusterContextsLock.Lock()
+	defer clusterContextsLock.Unlock()
+	for i, context := range allClusterContexts {
+		if context == cc {
+			allClusterContexts = append(allClusterContexts[:i], allClusterContexts[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetClusterContexts returns the contexts of all the member clusters.
+func GetClusterContexts() []string {
+	clusterContextsLock.RLock()
+	defer clusterContextsLock.RUnlock()
+	return append([]string{}, allClusterContexts...)
+}
+
+var memberClusterSettings = struct {
+	lock     sync.RWMutex
+	clusters map[string]gslbalphav1.MemberCluster
+}{clusters: make(map[string]gslbalphav1.MemberCluster)}
+
+// SetMemberClusterSettings sets the per cluster settings of the member clusters from the GSLBConfig
+// object, like useNodeRegion and drain.
+func SetMemberClusterSettings(memberClusters []gslbalphav1.MemberCluster) {
+	memberClusterSettings.lock.Lock()
+	defer memberClusterSettings.lock.Unlock()
+	memberClusterSettings.clusters = make(map[string]gslbalphav1.MemberCluster)
+	for _, c := range memberClusters {
+		memberClusterSettings.clusters[c.ClusterContext] = c
+	}
+}
+
+// IsNodeRegionEnabled returns true if the region of a member cluster has to be derived from the labels
+// of its nodes.
+func IsNodeRegionEnabled(cname string) bool {
+	memberClusterSettings.lock.RLock()
+	defer memberClusterSettings.lock.RUnlock()
+	return memberClusterSettings.clusters[cname].UseNodeRegion
+}
+
+// IsMemberClusterDrained returns true if a member cluster is drained in the GSLBConfig object, which
+// disables its GS members in all the GslbServices.
+func IsMemberClusterDrained(cname string) bool {
+	memberClusterSettings.lock.RLock()
+	defer memberClusterSettings.lock.RUnlock()
+	return memberClusterSettings.clusters[cname].Drain
+}
+
 func IsClusterContextPresent(cc string) bool {
+	clusterContextsLock.RLock()
+	defer clusterContextsLock.RUnlock()
 	for _, context := range allClusterContexts {
 		if context == cc {
 			return true
@@ -719,6 +912,65 @@ func GetCustomFqdnMode() bool {
 	return customFqdnMode
 }
 
+// IsGatewayAPIEnabled returns true if Gateway API HTTPRoutes have to be considered as GSLB
+// members.
+func IsGatewayAPIEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("GATEWAY_API_ENABLED"))
+	return ok
+}
+
+// IsDryRunEnabled returns true if AMKO has to only plan the operations on the Avi controller without
+// executing them.
+func IsDryRunEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("DRY_RUN"))
+	return ok
+}
+
+// IsWebhookEnabled returns true if the validating admission webhook server for the AMKO CRDs has
+// to be started.
+func IsWebhookEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("WEBHOOK_ENABLED"))
+	return ok
+}
+
+// IsLeaderElectionEnabled returns true if the AMKO replicas have to elect a leader via a Lease object,
+// only the elected replica then executes the operations on the Avi controller.
+func IsLeaderElectionEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv("LEADER_ELECTION_ENABLED"))
+	return ok
+}
+
+var replicaElected int32
+
+// SetReplicaElected sets whether this AMKO replica holds the leader election lease.
+func SetReplicaElected(elected bool) {
+	var val int32
+	if elected {
+		val = 1
+	}
+	atomic.StoreInt32(&replicaElected, val)
+}
+
+// IsReplicaElected returns true if this AMKO replica can execute the operations on the Avi controller
+// and update the status of the AMKO objects, which is always the case without leader election.
+func IsReplicaElected() bool {
+	return !IsLeaderElectionEnabled() || atomic.LoadInt32(&replicaElected) == 1
+}
+
+// GetWebhookPort returns the port on which the validating admission webhook server listens.
+func GetWebhookPort() string {
+	if port := os.Getenv("WEBHOOK_PORT"); port != "" {
+		return port
+	}
+	return DefaultWebhookPort
+}
+
+// GetMemberKubeConfigFile returns the path at which the member clusters' kubeconfig secret is mounted.
+// If set, the changes to the secret are picked up without a restart.
+func GetMemberKubeConfigFile() string {
+	return os.Getenv("GSLB_CONFIG_FILE")
+}
+
 var isTestMode bool
 
 func SetTestMode(t bool) {
@@ -832,4 +1084,16 @@ const (
 	ControllerAnnotation  = "ako.vmware.com/controller-cluster-uuid"
 	TenantAnnotation      = "ako.vmware.com/tenant-name"
 	PassthroughAnnotation = "passthrough.ako.vmware.com/enabled"
+	// HealthMonitorPortsAnnotation selects the ports of a LoadBalancer service which have to be
+	// health monitored, e.g. "443/TCP,53/UDP". A port without a protocol is considered as TCP.
+	HealthMonitorPortsAnnotation = "amko.vmware.com/health-monitor-ports"
+	// DrainAnnotation set to "true" on an Ingress, Route, HTTPRoute or Service disables its GslbService
+	// members, e.g. for a maintenance of the application in a cluster.
+	DrainAnnotation = "amko.vmware.com/drain"
 )
+
+// IsDrainAnnotationSet returns true if the annotations have the drain annotation set to true.
+func IsDrainAnnotationSet(annotations map[string]string) bool {
+	drain, err := strconv.ParseBool(annotations[DrainAnnotation])
+	return err == nil && drain
+}
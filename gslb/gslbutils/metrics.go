@@ -0,0 +1,187 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package gslbutils
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	MetricsNamespace = "amko"
+
+	MetricsResultSuccess = "success"
+	MetricsResultError   = "error"
+)
+
+var (
+	metricsRegistry     *prometheus.Registry
+	metricsRegistryOnce sync.Once
+
+	keyProcessingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "key_processing_duration_seconds",
+			Help:      "Time taken to process a key in a pipeline layer queue.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		[]string{"queue"},
+	)
+	keysProcessed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "keys_processed_total",
+			Help:      "Number of keys processed in a pipeline layer queue, by result.",
+		},
+		[]string{"queue", "result"},
+	)
+	aviRestRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "avi_rest_requests_total",
+			Help:      "Number of REST calls made to the Avi controller, by method, object type and status.",
+		},
+		[]string{"method", "model", "status"},
+	)
+	aviRestRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "avi_rest_request_duration_seconds",
+			Help:      "Latency of REST calls made to the Avi controller.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		},
+		[]string{"method", "model"},
+	)
+	retries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "retries_total",
+			Help:      "Number of keys published to a retry queue.",
+		},
+		[]string{"queue"},
+	)
+	memberClusterInformerSynced = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "member_cluster_informer_synced",
+			Help:      "Whether the informer caches for a member cluster have synced (1) or not (0).",
+		},
+		[]string{"cluster"},
+	)
+)
+
+// IsPrometheusEnabled returns true if the /metrics endpoint has to be exposed on the AMKO API server.
+func IsPrometheusEnabled() bool {
+	return os.Getenv("PROMETHEUS_ENABLED") == "true"
+}
+
+// GetMetricsRegistry returns the registry which holds all AMKO metrics. The metrics are always
+// recorded, the registry is only served if prometheus is enabled.
+func GetMetricsRegistry() *prometheus.Registry {
+	metricsRegistryOnce.Do(func() {
+		metricsRegistry = prometheus.NewRegistry()
+		metricsRegistry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+			keyProcessingDuration,
+			keysProcessed,
+			aviRestRequests,
+			aviRestRequestDuration,
+			retries,
+			memberClusterInformerSynced,
+		)
+	})
+	return metricsRegistry
+}
+
+// RegisterGaugeFunc registers a gauge whose value is computed by fn at scrape time. Registering
+// the same gauge (name and labels) again is a no-op.
+func RegisterGaugeFunc(name, help string, labels prometheus.Labels, fn func() float64) {
+	gauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   MetricsNamespace,
+		Name:        name,
+		Help:        help,
+		ConstLabels: labels,
+	}, fn)
+	if err := GetMetricsRegistry().Register(gauge); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			Warnf("metric: %s, labels: %v, msg: error in registering metric: %v", name, labels, err)
+		}
+	}
+}
+
+// RegisterWorkQueueMetrics registers the depth gauges for the given worker queues. Must be called
+// only after the shared work queues have been initialized.
+func RegisterWorkQueueMetrics(queues ...*utils.WorkerQueue) {
+	for _, q := range queues {
+		queue := q
+		RegisterGaugeFunc("workqueue_depth", "Number of keys waiting to be processed in a pipeline layer queue.",
+			prometheus.Labels{"queue": queue.WorkqueueName}, func() float64 {
+				depth := 0
+				for _, wq := range queue.Workqueue {
+					depth += wq.Len()
+				}
+				return float64(depth)
+			})
+	}
+}
+
+// InstrumentSyncFunc wraps the sync function of a worker queue to record the processing latency
+// and the result for each key.
+func InstrumentSyncFunc(queueName string, syncFunc func(interface{}, *sync.WaitGroup) error) func(interface{}, *sync.WaitGroup) error {
+	return func(key interface{}, wg *sync.WaitGroup) error {
+		start := time.Now()
+		err := syncFunc(key, wg)
+		keyProcessingDuration.WithLabelValues(queueName).Observe(time.Since(start).Seconds())
+		result := MetricsResultSuccess
+		if err != nil {
+			result = MetricsResultError
+		}
+		keysProcessed.WithLabelValues(queueName, result).Inc()
+		return err
+	}
+}
+
+// ObserveAviRestRequest records a REST call made to the Avi controller.
+func ObserveAviRestRequest(method, model, status string, duration time.Duration) {
+	aviRestRequests.WithLabelValues(method, model, status).Inc()
+	aviRestRequestDuration.WithLabelValues(method, model).Observe(duration.Seconds())
+}
+
+// IncRetryCounter increments the retry counter for a retry queue.
+func IncRetryCounter(queueName string) {
+	retries.WithLabelValues(queueName).Inc()
+}
+
+// SetMemberClusterInformerSynced records the informer cache sync state of a member cluster.
+func SetMemberClusterInformerSynced(cname string, synced bool) {
+	val := 0.0
+	if synced {
+		val = 1.0
+	}
+	memberClusterInformerSynced.WithLabelValues(cname).Set(val)
+}
+
+// DeleteMemberClusterInformerSynced drops the informer cache sync state of a removed member cluster.
+func DeleteMemberClusterInformerSynced(cname string) {
+	memberClusterInformerSynced.DeleteLabelValues(cname)
+}
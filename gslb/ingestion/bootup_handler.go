@@ -64,8 +64,11 @@ type AMKOClusterReconciler struct {
 
 func CreateController() {
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Metrics: server.Options{BindAddress: metricsAddr},
-		Scheme:  clusterScheme,
+		Metrics:                 server.Options{BindAddress: metricsAddr},
+		Scheme:                  clusterScheme,
+		LeaderElection:          gslbutils.IsLeaderElectionEnabled(),
+		LeaderElectionID:        gslbutils.FederatorLeaderElectionName,
+		LeaderElectionNamespace: gslbutils.AVISystem,
 	})
 	if err != nil {
 		gslbutils.Errf("unable to create manager for AMKOCluster controller: %v", err)
@@ -89,6 +92,10 @@ func CreateController() {
 
 var currentLeader bool
 
+// amkoClusterClient is used to publish the member cluster states to the AMKOCluster status, set only
+// for the leader AMKO
+var amkoClusterClient client.Client
+
 func (r *AMKOClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	gslbutils.Debugf("Starting AMKOCluster reconciliation")
 
@@ -194,6 +201,7 @@ func HandleBootup(cfg *restclient.Config) (bool, error) {
 	amkoCluster := amkoClusterList.Items[0]
 	if amkoCluster.Spec.IsLeader {
 		currentLeader = true
+		amkoClusterClient = clusterClient
 		gslbutils.Logf("AMKOCluster object found and AMKO would start as leader")
 		gslbutils.LeaderClusterContext = amkoCluster.Spec.ClusterContext
 	} else {
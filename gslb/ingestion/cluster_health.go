@@ -0,0 +1,446 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	amkov1alpha1 "github.com/vmware/global-load-balancing-services-for-kubernetes/federator/api/v1alpha1"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/federator/controllers"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Connectivity states of a member cluster. A cluster turns Unhealthy if its API server can't be reached
+// or its informers can't watch the objects. Once reachable again, it stays Recovering till the deletion
+// of all its stale objects is confirmed, and then turns Healthy.
+const (
+	ClusterHealthy    = "Healthy"
+	ClusterUnhealthy  = "Unhealthy"
+	ClusterRecovering = "Recovering"
+
+	clusterAPITimeout = 10 * time.Second
+)
+
+// staleObject is an object whose delete event was received, but the deletion is yet to be confirmed
+// by the member cluster.
+type staleObject struct {
+	objType  string
+	ns       string
+	name     string
+	obj      interface{}
+	deleteFn func(obj interface{})
+	since    time.Time
+}
+
+type clusterHealth struct {
+	ctrl           *GSLBMemberController
+	state          string
+	reason         string
+	lastTransition time.Time
+	staleObjs      map[string]*staleObject
+}
+
+var (
+	clusterHealthMap  = make(map[string]*clusterHealth)
+	clusterHealthLock sync.Mutex
+	// staleObjectGracePeriod is the time for which the stale objects of a cluster which is not
+	// healthy are kept, 0 keeps them till their deletion is confirmed
+	staleObjectGracePeriod time.Duration
+	// publishedMemberStatus is the last member cluster status published to the AMKOCluster object
+	publishedMemberStatus []amkov1alpha1.MemberClusterStatus
+)
+
+func getStaleObjKey(objType, ns, name string) string {
+	return objType + "/" + ns + "/" + name
+}
+
+// SetStaleObjectGracePeriod sets the time in seconds for which the stale objects of a member cluster
+// are kept, if their deletion can't be confirmed.
+func SetStaleObjectGracePeriod(seconds int) {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	if seconds < 0 {
+		gslbutils.Warnf("staleObjectGracePeriod: %d, msg: invalid grace period, will keep the stale objects", seconds)
+		seconds = 0
+	}
+	staleObjectGracePeriod = time.Duration(seconds) * time.Second
+}
+
+// registerClusterHealth starts tracking the connectivity of a member cluster. The stale objects of
+// a cluster which is reconnected are retained.
+func registerClusterHealth(c *GSLBMemberController) {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	if h, ok := clusterHealthMap[c.name]; ok {
+		h.ctrl = c
+		return
+	}
+	clusterHealthMap[c.name] = &clusterHealth{
+		ctrl:           c,
+		state:          ClusterHealthy,
+		lastTransition: time.Now(),
+		staleObjs:      make(map[string]*staleObject),
+	}
+}
+
+// unregisterClusterHealth stops tracking the connectivity of a member cluster removed from the
+// GSLBConfig object, its stale objects are dropped as all its objects are removed.
+func unregisterClusterHealth(cname string) {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	delete(clusterHealthMap, cname)
+}
+
+// GetMemberClusterState returns the connectivity state of a member cluster and the number of its
+// stale objects.
+func GetMemberClusterState(cname string) (string, int) {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	h, ok := clusterHealthMap[cname]
+	if !ok {
+		return "", 0
+	}
+	return h.state, len(h.staleObjs)
+}
+
+func isClusterHealthy(cname string) bool {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	h, ok := clusterHealthMap[cname]
+	// the clusters not being tracked yet are still syncing, the deletes can be trusted
+	return !ok || h.state == ClusterHealthy
+}
+
+// allMemberClustersSynced returns true if all the member clusters are connected and healthy, i.e.
+// the graph layer has the objects of all the member clusters.
+func allMemberClustersSynced() bool {
+	pendingClustersLock.Lock()
+	pending := len(pendingClusters)
+	pendingClustersLock.Unlock()
+	if pending != 0 {
+		return false
+	}
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	for _, h := range clusterHealthMap {
+		if h.state != ClusterHealthy {
+			return false
+		}
+	}
+	return true
+}
+
+// setClusterState must be called with clusterHealthLock held.
+func (h *clusterHealth) setClusterState(state, reason string) {
+	if h.state == state {
+		h.reason = reason
+		return
+	}
+	gslbutils.Logf("cluster: %s, oldState: %s, newState: %s, reason: %s, staleObjects: %d, msg: member cluster state changed",
+		h.ctrl.name, h.state, state, reason, len(h.staleObjs))
+	eventType := corev1.EventTypeNormal
+	if state == ClusterUnhealthy {
+		eventType = corev1.EventTypeWarning
+	}
+	gslbutils.AMKOControlConfig().PodEventf(eventType, gslbutils.MemberClusterHealth,
+		fmt.Sprintf("Member cluster %s is %s: %s", h.ctrl.name, state, reason))
+	h.state = state
+	h.reason = reason
+	h.lastTransition = time.Now()
+}
+
+func setClusterUnhealthy(cname, reason string) {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	if h, ok := clusterHealthMap[cname]; ok {
+		h.setClusterState(ClusterUnhealthy, reason)
+	}
+}
+
+// addStaleObject keeps an object as stale, returns false if the cluster isn't tracked.
+func addStaleObject(cname, objType, ns, name string, obj interface{}, deleteFn func(obj interface{})) bool {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+	h, ok := clusterHealthMap[cname]
+	if !ok {
+		return false
+	}
+	key := getStaleObjKey(objType, ns, name)
+	if _, ok := h.staleObjs[key]; !ok {
+		h.staleObjs[key] = &staleObject{since: time.Now()}
+	}
+	h.staleObjs[key].objType = objType
+	h.staleObjs[key].ns = ns
+	h.staleObjs[key].name = name
+	h.staleObjs[key].obj = obj
+	h.staleObjs[key].deleteFn = deleteFn
+	gslbutils.Logf("cluster: %s, ns: %s, objType: %s, name: %s, state: %s, msg: deletion not confirmed, keeping the object as stale",
+		cname, ns, objType, name, h.state)
+	return true
+}
+
+// guardDelete wraps the delete handler of an object type. The deletion is applied right away only if
+// the cluster is healthy and the informer observed the deletion. Otherwise, like for the deletes
+// synthesized by an informer re-list, the object is kept as stale till the cluster confirms it.
+func (c *GSLBMemberController) guardDelete(objType string, deleteFn func(obj interface{})) func(obj interface{}) {
+	return func(obj interface{}) {
+		tombstone, isTombstone := obj.(cache.DeletedFinalStateUnknown)
+		if isTombstone {
+			obj = tombstone.Obj
+		}
+		if !isTombstone && isClusterHealthy(c.name) {
+			deleteFn(obj)
+			return
+		}
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			gslbutils.Errf("cluster: %s, objType: %s, msg: can't process the delete event: %v", c.name, objType, err)
+			return
+		}
+		if !addStaleObject(c.name, objType, objMeta.GetNamespace(), objMeta.GetName(), obj, deleteFn) {
+			deleteFn(obj)
+		}
+	}
+}
+
+// watchErrorHandler marks the cluster unhealthy if its informers can't list or watch the objects.
+func (c *GSLBMemberController) watchErrorHandler(r *cache.Reflector, err error) {
+	cache.DefaultWatchErrorHandler(context.TODO(), r, err)
+	if err == io.EOF || k8serrors.IsResourceExpired(err) || k8serrors.IsGone(err) {
+		// the watch was closed or has to be restarted, the cluster is reachable
+		return
+	}
+	setClusterUnhealthy(c.name, fmt.Sprintf("informer watch error: %v", err))
+}
+
+// isObjPresentInCluster fetches an object from the API server of the member cluster, the informer
+// caches are not used as they can't be trusted after a re-list.
+func (c *GSLBMemberController) isObjPresentInCluster(objType, ns, name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.TODO(), clusterAPITimeout)
+	defer cancel()
+
+	var err error
+	switch objType {
+	case gslbutils.IngressType:
+		_, err = c.informers.ClientSet.NetworkingV1().Ingresses(ns).Get(ctx, name, metav1.GetOptions{})
+	case gslbutils.SvcType:
+		_, err = c.informers.ClientSet.CoreV1().Services(ns).Get(ctx, name, metav1.GetOptions{})
+	case gslbutils.NamespaceType:
+		_, err = c.informers.ClientSet.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	case gslbutils.RouteType:
+		if c.informers.OshiftClient == nil {
+			return false, nil
+		}
+		_, err = c.informers.OshiftClient.RouteV1().Routes(ns).Get(ctx, name, metav1.GetOptions{})
+	case gslbutils.MCIType:
+		if c.hrAlphaClientSet == nil {
+			return false, nil
+		}
+		_, err = c.hrAlphaClientSet.AkoV1alpha1().MultiClusterIngresses(ns).Get(ctx, name, metav1.GetOptions{})
+	case gslbutils.HTTPRouteType:
+		if c.gatewayAPIInformers == nil || c.gatewayAPIInformers.dynamicClient == nil {
+			return false, nil
+		}
+		_, err = c.gatewayAPIInformers.dynamicClient.Resource(HTTPRouteGVR).Namespace(ns).Get(ctx, name,
+			metav1.GetOptions{})
+	default:
+		return false, fmt.Errorf("unknown object type %s", objType)
+	}
+	if err == nil {
+		return true, nil
+	}
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (c *GSLBMemberController) probeCluster() error {
+	ctx, cancel := context.WithTimeout(context.TODO(), clusterAPITimeout)
+	defer cancel()
+	_, err := c.informers.ClientSet.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
+	return err
+}
+
+// confirmStaleObjects checks the stale objects of a reachable cluster. The objects not present in the
+// cluster are deleted, the ones still present are dropped from the stale list and are synced back by
+// the informers. Returns an error if the cluster couldn't be reached.
+func confirmStaleObjects(c *GSLBMemberController, staleObjs []*staleObject) error {
+	for _, obj := range staleObjs {
+		present, err := c.isObjPresentInCluster(obj.objType, obj.ns, obj.name)
+		if err != nil {
+			return err
+		}
+		key := getStaleObjKey(obj.objType, obj.ns, obj.name)
+		clusterHealthLock.Lock()
+		h, ok := clusterHealthMap[c.name]
+		if !ok || h.staleObjs[key] != obj {
+			// the cluster was removed or the object was updated in the meantime
+			clusterHealthLock.Unlock()
+			continue
+		}
+		delete(h.staleObjs, key)
+		clusterHealthLock.Unlock()
+		if present {
+			gslbutils.Logf("cluster: %s, ns: %s, objType: %s, name: %s, msg: stale object still present, not deleting",
+				c.name, obj.ns, obj.objType, obj.name)
+			continue
+		}
+		gslbutils.Logf("cluster: %s, ns: %s, objType: %s, name: %s, msg: deletion of the stale object confirmed",
+			c.name, obj.ns, obj.objType, obj.name)
+		obj.deleteFn(obj.obj)
+	}
+	return nil
+}
+
+// removeExpiredStaleObjects deletes the stale objects kept beyond the grace period.
+func removeExpiredStaleObjects(cname string) {
+	var expired []*staleObject
+	clusterHealthLock.Lock()
+	h, ok := clusterHealthMap[cname]
+	if !ok || staleObjectGracePeriod == 0 {
+		clusterHealthLock.Unlock()
+		return
+	}
+	for key, obj := range h.staleObjs {
+		if time.Since(obj.since) > staleObjectGracePeriod {
+			expired = append(expired, obj)
+			delete(h.staleObjs, key)
+		}
+	}
+	clusterHealthLock.Unlock()
+
+	for _, obj := range expired {
+		gslbutils.Warnf("cluster: %s, ns: %s, objType: %s, name: %s, msg: grace period for the stale object expired, deleting",
+			cname, obj.ns, obj.objType, obj.name)
+		obj.deleteFn(obj.obj)
+	}
+}
+
+func checkClusterHealth(cname string) {
+	clusterHealthLock.Lock()
+	h, ok := clusterHealthMap[cname]
+	if !ok {
+		clusterHealthLock.Unlock()
+		return
+	}
+	c := h.ctrl
+	clusterHealthLock.Unlock()
+
+	if err := c.probeCluster(); err != nil {
+		setClusterUnhealthy(cname, fmt.Sprintf("API server not reachable: %v", err))
+		removeExpiredStaleObjects(cname)
+		return
+	}
+
+	clusterHealthLock.Lock()
+	if h.state == ClusterUnhealthy {
+		h.setClusterState(ClusterRecovering, "API server reachable, confirming the stale objects")
+	}
+	staleObjs := make([]*staleObject, 0, len(h.staleObjs))
+	for _, obj := range h.staleObjs {
+		staleObjs = append(staleObjs, obj)
+	}
+	clusterHealthLock.Unlock()
+
+	if err := confirmStaleObjects(c, staleObjs); err != nil {
+		setClusterUnhealthy(cname, fmt.Sprintf("can't confirm the stale objects: %v", err))
+		removeExpiredStaleObjects(cname)
+		return
+	}
+
+	clusterHealthLock.Lock()
+	if h.state == ClusterRecovering && len(h.staleObjs) == 0 {
+		h.setClusterState(ClusterHealthy, "API server reachable")
+	}
+	clusterHealthLock.Unlock()
+}
+
+// CheckMemberClusterHealth checks the connectivity to all the member clusters and moves them through
+// the connectivity states. The changes are published to the AMKOCluster status.
+func CheckMemberClusterHealth() {
+	clusterHealthLock.Lock()
+	clusters := make([]string, 0, len(clusterHealthMap))
+	for cname := range clusterHealthMap {
+		clusters = append(clusters, cname)
+	}
+	clusterHealthLock.Unlock()
+
+	for _, cname := range clusters {
+		checkClusterHealth(cname)
+	}
+	publishMemberClusterStatus()
+}
+
+func getMemberClusterStatus() []amkov1alpha1.MemberClusterStatus {
+	clusterHealthLock.Lock()
+	defer clusterHealthLock.Unlock()
+
+	status := make([]amkov1alpha1.MemberClusterStatus, 0, len(clusterHealthMap))
+	for cname, h := range clusterHealthMap {
+		status = append(status, amkov1alpha1.MemberClusterStatus{
+			Cluster:            cname,
+			State:              h.state,
+			Reason:             h.reason,
+			StaleObjects:       len(h.staleObjs),
+			LastTransitionTime: metav1.NewTime(h.lastTransition.Truncate(time.Second)),
+		})
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Cluster < status[j].Cluster
+	})
+	return status
+}
+
+// publishMemberClusterStatus updates the member cluster states in the AMKOCluster status, if changed.
+func publishMemberClusterStatus() {
+	if amkoClusterClient == nil || !gslbutils.IsReplicaElected() {
+		return
+	}
+	status := getMemberClusterStatus()
+	if reflect.DeepEqual(status, publishedMemberStatus) {
+		return
+	}
+	var amkoClusterList amkov1alpha1.AMKOClusterList
+	err := amkoClusterClient.List(context.TODO(), &amkoClusterList, &client.ListOptions{
+		Namespace: controllers.AviSystemNS,
+	})
+	if err != nil || len(amkoClusterList.Items) != 1 {
+		gslbutils.Errf("msg: can't fetch the AMKOCluster object to update the member cluster status, err: %v", err)
+		return
+	}
+	amkoCluster := amkoClusterList.Items[0]
+	patch := client.MergeFrom(amkoCluster.DeepCopy())
+	amkoCluster.Status.MemberClusters = status
+	if err := amkoClusterClient.Status().Patch(context.TODO(), &amkoCluster, patch); err != nil {
+		gslbutils.Errf("ns: %s, AMKOCluster: %s, msg: can't update the member cluster status: %v",
+			amkoCluster.Namespace, amkoCluster.Name, err)
+		return
+	}
+	publishedMemberStatus = status
+}
@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	filter "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/filter"
 )
@@ -69,13 +70,15 @@ func AddLBSvcEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Reso
 				AddOrUpdateLBSvcStore(rejectedLBSvcStore, svc, c.name)
 				gslbutils.Logf("cluster: %s, ns: %s, svc: %s, msg: %s\n", c.name,
 					svc.ObjectMeta.Namespace, svc.ObjectMeta.Name, "rejected ADD svc key because it couldn't pass through filter")
+				RevalidateGSLBHostRulesForMember(c.name, svc.Namespace, gslbutils.SvcType, svc.Name, c.workqueue, numWorkers)
 				return
 			}
 			AddOrUpdateLBSvcStore(acceptedLBSvcStore, svc, c.name)
+			RevalidateGSLBHostRulesForMember(c.name, svc.Namespace, gslbutils.SvcType, svc.Name, c.workqueue, numWorkers)
 			publishKeyToGraphLayer(numWorkers, gslbutils.SvcType, c.name, svc.ObjectMeta.Namespace,
 				svc.ObjectMeta.Name, gslbutils.ObjectAdd, svcMeta.Hostname, svcMeta.Tenant, c.workqueue)
 		},
-		DeleteFunc: func(obj interface{}) {
+		DeleteFunc: c.guardDelete(gslbutils.SvcType, func(obj interface{}) {
 			svc, ok := obj.(*corev1.Service)
 			if !ok {
 				gslbutils.Debugf("object %v is not of type Service", svc)
@@ -101,7 +104,7 @@ func AddLBSvcEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Reso
 					svc.ObjectMeta.Name, gslbutils.ObjectDelete, hostName, fetchedSvc.Tenant, c.workqueue)
 			}
 			return
-		},
+		}),
 		UpdateFunc: func(old, curr interface{}) {
 			oldSvc := old.(*corev1.Service)
 			svc := curr.(*corev1.Service)
@@ -182,12 +185,16 @@ func filterAndAddIngressMeta(ingressHostMetaObjs []k8sobjects.IngressHostMeta, c
 			AddOrUpdateIngressStore(rejectedIngStore, ihm, c.name)
 			gslbutils.Logf("cluster: %s, ns: %s, ingress: %s, msg: %s, ing: %v\n", c.name, ihm.Namespace,
 				ihm.ObjName, "rejected ADD ingress key because it couldn't pass through the filter", ihm)
+			if !fullsync {
+				RevalidateGSLBHostRulesForMember(c.name, ihm.Namespace, gslbutils.IngressType, ihm.ObjName, c.workqueue, numWorkers)
+			}
 			continue
 		}
 		AddOrUpdateIngressStore(acceptedIngStore, ihm, c.name)
 		if !fullsync {
 			publishKeyToGraphLayer(numWorkers, gslbutils.IngressType, c.name,
 				ihm.Namespace, ihm.ObjName, gslbutils.ObjectAdd, ihm.Hostname, ihm.Tenant, c.workqueue)
+			RevalidateGSLBHostRulesForMember(c.name, ihm.Namespace, gslbutils.IngressType, ihm.ObjName, c.workqueue, numWorkers)
 		}
 	}
 }
@@ -355,7 +362,7 @@ func AddIngressEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Re
 			namespaceTenant := gslbutils.GetTenantInNamespaceAnnotation(ingr.Namespace, c.name)
 			filterAndAddIngressMeta(ingressHostMetaObjs, c, acceptedIngStore, rejectedIngStore, numWorkers, false, namespaceTenant)
 		},
-		DeleteFunc: func(obj interface{}) {
+		DeleteFunc: c.guardDelete(gslbutils.IngressType, func(obj interface{}) {
 			ingr, ok := obj.(*networkingv1.Ingress)
 			if !ok {
 				containerutils.AviLog.Errorf("Unable to convert obj type interface to networking/v1 ingress")
@@ -364,7 +371,7 @@ func AddIngressEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Re
 			// Delete from all ingress stores
 			ingressHostMetaObjs := k8sobjects.GetIngressHostMeta(ingr, c.name)
 			deleteIngressMeta(ingressHostMetaObjs, c, acceptedIngStore, rejectedIngStore, numWorkers)
-		},
+		}),
 		UpdateFunc: func(old, curr interface{}) {
 			oldIngr, okOld := old.(*networkingv1.Ingress)
 			ingr, okNew := curr.(*networkingv1.Ingress)
@@ -409,13 +416,15 @@ func AddRouteEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Reso
 				AddOrUpdateRouteStore(rejectedRouteStore, route, c.name)
 				gslbutils.Logf("cluster: %s, ns: %s, route: %s, msg: %s\n", c.name,
 					route.ObjectMeta.Namespace, route.ObjectMeta.Name, "rejected ADD route key because it couldn't pass through filter")
+				RevalidateGSLBHostRulesForMember(c.name, route.Namespace, gslbutils.RouteType, route.Name, c.workqueue, numWorkers)
 				return
 			}
 			AddOrUpdateRouteStore(acceptedRouteStore, route, c.name)
+			RevalidateGSLBHostRulesForMember(c.name, route.Namespace, gslbutils.RouteType, route.Name, c.workqueue, numWorkers)
 			publishKeyToGraphLayer(numWorkers, gslbutils.RouteType, c.name, route.ObjectMeta.Namespace,
 				route.ObjectMeta.Name, gslbutils.ObjectAdd, routeMeta.Hostname, routeMeta.Tenant, c.workqueue)
 		},
-		DeleteFunc: func(obj interface{}) {
+		DeleteFunc: c.guardDelete(gslbutils.RouteType, func(obj interface{}) {
 			route, ok := obj.(*routev1.Route)
 			if !ok {
 				gslbutils.Debugf("object %v type is not Route", route)
@@ -432,7 +441,7 @@ func AddRouteEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Reso
 				publishKeyToGraphLayer(numWorkers, gslbutils.RouteType, c.name, route.ObjectMeta.Namespace,
 					route.ObjectMeta.Name, gslbutils.ObjectDelete, routeMeta.Hostname, fetchedRoute.Tenant, c.workqueue)
 			}
-		},
+		}),
 		UpdateFunc: func(old, curr interface{}) {
 			oldRoute := old.(*routev1.Route)
 			route := curr.(*routev1.Route)
@@ -468,8 +477,8 @@ func AddRouteEventHandler(numWorkers uint32, c *GSLBMemberController) cache.Reso
 				if fetchedObj, ok := acceptedRouteStore.GetClusterNSObjectByName(c.name, route.GetObjectMeta().GetNamespace(),
 					route.GetObjectMeta().GetName()); ok {
 					fetchedRoute := fetchedObj.(k8sobjects.RouteMeta)
-					// check if tenant has changed for route
-					if fetchedRoute.Tenant != routeMeta.Tenant {
+					// check if tenant or the wildcard FQDN (and hence, the GS) has changed for route
+					if fetchedRoute.Tenant != routeMeta.Tenant || fetchedRoute.WildcardFqdn != routeMeta.WildcardFqdn {
 						oper := gslbutils.ObjectDelete
 						publishKeyToGraphLayer(numWorkers, gslbutils.RouteType, c.name, fetchedRoute.Namespace, fetchedRoute.Name,
 							oper, fetchedRoute.Hostname, fetchedRoute.Tenant, c.workqueue)
@@ -527,7 +536,7 @@ func AddNamespaceEventHandler(numWorkers uint32, c *GSLBMemberController) cache.
 			WriteChangedObjsToQueue(c.workqueue, numWorkers, false, []string{})
 			AddOrUpdateNSStore(acceptedNSStore, ns, c.name)
 		},
-		DeleteFunc: func(obj interface{}) {
+		DeleteFunc: c.guardDelete(gslbutils.NamespaceType, func(obj interface{}) {
 			ns, ok := obj.(*corev1.Namespace)
 			if !ok {
 				gslbutils.Debugf("unable to convert obj %v type interface to Namespace", obj)
@@ -542,7 +551,7 @@ func AddNamespaceEventHandler(numWorkers uint32, c *GSLBMemberController) cache.
 			DeleteFromNSStore(acceptedNSStore, ns, c.name)
 			DeleteFromNSStore(rejectedNSStore, ns, c.name)
 			namespaceTenantStore.DeleteNSObj(c.name, ns.Name)
-		},
+		}),
 		UpdateFunc: func(old, curr interface{}) {
 			oldNS, okOld := old.(*corev1.Namespace)
 			ns, okNew := curr.(*corev1.Namespace)
@@ -656,7 +665,8 @@ func ReApplyObjectsOnHostRule(hr *akov1beta1.HostRule, add bool, cname, lfqdn, g
 		primaryFqdn = gfqdn
 	}
 	var key string
-	objs := []string{gdpalphav2.RouteObj, gdpalphav2.IngressObj, gdpalphav2.LBSvcObj, gslbutils.MCIType}
+	objs := []string{gdpalphav2.RouteObj, gdpalphav2.IngressObj, gdpalphav2.LBSvcObj, gslbutils.MCIType,
+		gslbutils.HTTPRouteType}
 	for _, o := range objs {
 		objKey, acceptedStore, rejectedStore, err := GetObjTypeStores(o)
 		if err != nil {
@@ -692,7 +702,7 @@ func ReApplyObjectsOnHostRule(hr *akov1beta1.HostRule, add bool, cname, lfqdn, g
 					}
 					bkt := utils.Bkt(ns, numWorkers)
 
-					if o == gdpalphav2.IngressObj {
+					if o == gdpalphav2.IngressObj || o == gslbutils.HTTPRouteType {
 						ingName := gslbutils.GetIngressNameFromSname(sname)
 						key = gslbutils.MultiClusterKeyForHostRule(gslbutils.ObjectAdd, objKey, cname, ns, ingName, lfqdn, gfqdn, tenant)
 					} else {
@@ -730,7 +740,7 @@ func ReApplyObjectsOnHostRule(hr *akov1beta1.HostRule, add bool, cname, lfqdn, g
 					}
 
 					bkt := utils.Bkt(ns, numWorkers)
-					if o == gdpalphav2.IngressObj {
+					if o == gdpalphav2.IngressObj || o == gslbutils.HTTPRouteType {
 						ingName := gslbutils.GetIngressNameFromSname(sname)
 						key = gslbutils.MultiClusterKeyForHostRule(gslbutils.ObjectDelete, objKey, cname, ns, ingName, lfqdn, gfqdn, tenant)
 					} else {
@@ -768,7 +778,7 @@ func ReApplyObjectsOnHostRule(hr *akov1beta1.HostRule, add bool, cname, lfqdn, g
 					}
 
 					bkt := utils.Bkt(ns, numWorkers)
-					if o == gdpalphav2.IngressObj {
+					if o == gdpalphav2.IngressObj || o == gslbutils.HTTPRouteType {
 						ingName := gslbutils.GetIngressNameFromSname(sname)
 						key = gslbutils.MultiClusterKeyForHostRule(gslbutils.ObjectUpdate, objKey, cname, ns, ingName, lfqdn, gfqdn, tenant)
 					} else {
@@ -1009,12 +1019,16 @@ func filterAndAddMultiClusterIngressMeta(ingressHostMetaObjs []k8sobjects.MultiC
 			AddOrUpdateMultiClusterIngressStore(rejectedIngStore, ihm, c.name)
 			gslbutils.Logf("cluster: %s, ns: %s, ingress: %s, msg: %s, ing: %v\n", c.name, ihm.Namespace,
 				ihm.ObjName, "rejected ADD ingress key because it couldn't pass through the filter", ihm)
+			if !fullsync {
+				RevalidateGSLBHostRulesForMember(c.name, ihm.Namespace, gslbutils.MCIType, ihm.ObjName, c.workqueue, numWorkers)
+			}
 			continue
 		}
 		AddOrUpdateMultiClusterIngressStore(acceptedIngStore, ihm, c.name)
 		if !fullsync {
 			publishKeyToGraphLayer(numWorkers, gslbutils.MCIType, c.name,
 				ihm.Namespace, ihm.ObjName, gslbutils.ObjectAdd, ihm.Hostname, ihm.Tenant, c.workqueue)
+			RevalidateGSLBHostRulesForMember(c.name, ihm.Namespace, gslbutils.MCIType, ihm.ObjName, c.workqueue, numWorkers)
 		}
 	}
 }
@@ -1176,7 +1190,7 @@ func AddMultiClusterIngressEventHandler(numWorkers uint32, c *GSLBMemberControll
 			namespaceTenant := gslbutils.GetTenantInNamespaceAnnotation(mciObj.Namespace, c.name)
 			filterAndAddMultiClusterIngressMeta(ingressHostMetaObjs, c, acceptedStore, rejectedStore, numWorkers, false, namespaceTenant)
 		},
-		DeleteFunc: func(obj interface{}) {
+		DeleteFunc: c.guardDelete(gslbutils.MCIType, func(obj interface{}) {
 			mciObj, ok := obj.(*akov1alpha1.MultiClusterIngress)
 			if !ok {
 				containerutils.AviLog.Errorf("Unable to convert obj type interface to multi-cluster ingress")
@@ -1185,7 +1199,7 @@ func AddMultiClusterIngressEventHandler(numWorkers uint32, c *GSLBMemberControll
 			// Delete from all ingress stores
 			ingressHostMetaObjs := k8sobjects.GetHostMetaForMultiClusterIngress(mciObj, c.name)
 			deleteMultiClusterIngressMeta(ingressHostMetaObjs, c, acceptedStore, rejectedStore, numWorkers)
-		},
+		}),
 		UpdateFunc: func(old, curr interface{}) {
 			oldMCIObj, okOld := old.(*akov1alpha1.MultiClusterIngress)
 			mciObj, okNew := curr.(*akov1alpha1.MultiClusterIngress)
@@ -1204,3 +1218,234 @@ func AddMultiClusterIngressEventHandler(numWorkers uint32, c *GSLBMemberControll
 	}
 	return mciEventHandler
 }
+
+func filterAndAddHTTPRouteMeta(routeHostMetaObjs []k8sobjects.HTTPRouteHostMeta, c *GSLBMemberController,
+	acceptedStore, rejectedStore *store.ClusterStore, numWorkers uint32, fullsync bool, namespaceTenant string) {
+	for _, hrhm := range routeHostMetaObjs {
+		if hrhm.IPAddr == "" || hrhm.Hostname == "" {
+			gslbutils.Debugf("cluster: %s, ns: %s, httproute: %s, msg: %s\n",
+				c.name, hrhm.Namespace, hrhm.RouteName,
+				"rejected ADD httproute because IP address/Hostname not found in gateway status field")
+			continue
+		}
+		if namespaceTenant != "" && hrhm.Tenant != namespaceTenant {
+			gslbutils.Debugf("cluster: %s, ns: %s, httproute: %s, msg: %s\n",
+				c.name, hrhm.Namespace, hrhm.RouteName, "rejected ADD httproute because gateway tenant is different from namespace")
+			continue
+		}
+		if namespaceTenant == "" {
+			hrhm.Tenant = gslbutils.GetTenant()
+		}
+		if !filter.ApplyFilter(filter.FilterArgs{
+			Obj:     hrhm,
+			Cluster: c.name,
+		}) {
+			AddOrUpdateHTTPRouteStore(rejectedStore, hrhm, c.name)
+			gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, msg: %s\n", c.name, hrhm.Namespace,
+				hrhm.ObjName, "rejected ADD httproute key because it couldn't pass through the filter")
+			if !fullsync {
+				RevalidateGSLBHostRulesForMember(c.name, hrhm.Namespace, gslbutils.HTTPRouteType, hrhm.ObjName, c.workqueue, numWorkers)
+			}
+			continue
+		}
+		AddOrUpdateHTTPRouteStore(acceptedStore, hrhm, c.name)
+		if !fullsync {
+			publishKeyToGraphLayer(numWorkers, gslbutils.HTTPRouteType, c.name,
+				hrhm.Namespace, hrhm.ObjName, gslbutils.ObjectAdd, hrhm.Hostname, hrhm.Tenant, c.workqueue)
+			RevalidateGSLBHostRulesForMember(c.name, hrhm.Namespace, gslbutils.HTTPRouteType, hrhm.ObjName, c.workqueue, numWorkers)
+		}
+	}
+}
+
+func deleteHTTPRouteMeta(routeHostMetaObjs []k8sobjects.HTTPRouteHostMeta, c *GSLBMemberController, acceptedStore,
+	rejectedStore *store.ClusterStore, numWorkers uint32) {
+	for _, hrhm := range routeHostMetaObjs {
+		fetchedObj, isAccepted := acceptedStore.GetClusterNSObjectByName(c.name, hrhm.Namespace,
+			hrhm.ObjName)
+		DeleteFromHTTPRouteStore(acceptedStore, hrhm, c.name)
+		DeleteFromHTTPRouteStore(rejectedStore, hrhm, c.name)
+
+		// Only if the object was part of the accepted list previously, we will send a delete key
+		// otherwise we will assume that the object was already deleted
+		if isAccepted {
+			fetchedRouteHost := fetchedObj.(k8sobjects.HTTPRouteHostMeta)
+			publishKeyToGraphLayer(numWorkers, gslbutils.HTTPRouteType, c.name,
+				hrhm.Namespace, hrhm.ObjName, gslbutils.ObjectDelete, hrhm.Hostname, fetchedRouteHost.Tenant, c.workqueue)
+		}
+	}
+}
+
+func filterAndUpdateHTTPRouteMeta(oldMetaObjs, newMetaObjs []k8sobjects.HTTPRouteHostMeta, c *GSLBMemberController,
+	acceptedStore, rejectedStore *store.ClusterStore, numWorkers uint32, namespaceTenant string) {
+
+	for _, hrhm := range oldMetaObjs {
+		// Check whether this exists in the new httproute host list, if not, we need
+		// to delete this object
+		newHrhm, found := hrhm.HostInList(newMetaObjs)
+		if !found {
+			deleteHTTPRouteMeta([]k8sobjects.HTTPRouteHostMeta{hrhm}, c, acceptedStore, rejectedStore, numWorkers)
+			continue
+		}
+		// httproute host exists, check if that got updated
+		if hrhm.GetHTTPRouteHostCksum() == newHrhm.GetHTTPRouteHostCksum() {
+			// no changes, just continue
+			continue
+		}
+		if namespaceTenant != "" && namespaceTenant != newHrhm.Tenant {
+			gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, namespaceTenant: %s, gatewayTenant: %s, msg: %s\n",
+				c.name, newHrhm.Namespace, newHrhm.RouteName, namespaceTenant, newHrhm.Tenant,
+				"rejected update httproute because gateway tenant is different from namespace")
+			continue
+		}
+		if namespaceTenant == "" {
+			newHrhm.Tenant = gslbutils.GetTenant()
+		}
+		// there are changes, need to send an update key, but first apply the filter
+		if !filter.ApplyFilter(filter.FilterArgs{
+			Obj:     newHrhm,
+			Cluster: c.name,
+		}) {
+			// See if the object was already accepted, if yes, need to delete the key
+			fetchedObj, ok := acceptedStore.GetClusterNSObjectByName(c.name,
+				hrhm.Namespace, hrhm.ObjName)
+			AddOrUpdateHTTPRouteStore(rejectedStore, newHrhm, c.name)
+			if !ok {
+				continue
+			}
+			// Else, delete this object from the accepted list and add a delete key for it to the queue
+			DeleteFromHTTPRouteStore(acceptedStore, newHrhm, c.name)
+			fetchedRouteHost := fetchedObj.(k8sobjects.HTTPRouteHostMeta)
+			publishKeyToGraphLayer(numWorkers, gslbutils.HTTPRouteType, fetchedRouteHost.Cluster,
+				fetchedRouteHost.Namespace, fetchedRouteHost.ObjName, gslbutils.ObjectDelete,
+				fetchedRouteHost.Hostname, fetchedRouteHost.Tenant, c.workqueue)
+			continue
+		}
+
+		// check if the object existed in the accepted store
+		oper := gslbutils.ObjectAdd
+		if fetchedObj, ok := acceptedStore.GetClusterNSObjectByName(c.name, newHrhm.Namespace, newHrhm.ObjName); ok {
+			fetchedRouteHost := fetchedObj.(k8sobjects.HTTPRouteHostMeta)
+			// check if tenant has changed for the httproute host
+			if fetchedRouteHost.Tenant != newHrhm.Tenant {
+				publishKeyToGraphLayer(numWorkers, gslbutils.HTTPRouteType, c.name, fetchedRouteHost.Namespace,
+					fetchedRouteHost.ObjName, gslbutils.ObjectDelete, fetchedRouteHost.Hostname,
+					fetchedRouteHost.Tenant, c.workqueue)
+			} else {
+				oper = gslbutils.ObjectUpdate
+			}
+		}
+		// passed through the filter, if the object was already part of rejected store, we need
+		// to move it from the rejected to accepted store
+		AddOrUpdateHTTPRouteStore(acceptedStore, newHrhm, c.name)
+		rejectedStore.DeleteClusterNSObj(c.name, hrhm.Namespace, hrhm.ObjName)
+		publishKeyToGraphLayer(numWorkers, gslbutils.HTTPRouteType, c.name, newHrhm.Namespace, newHrhm.ObjName,
+			oper, newHrhm.Hostname, newHrhm.Tenant, c.workqueue)
+	}
+	// Check if there are any new httproute host objects, if yes, we have to add those
+	newObjs := []k8sobjects.HTTPRouteHostMeta{}
+	for _, hrhm := range newMetaObjs {
+		if _, found := hrhm.HostInList(oldMetaObjs); !found {
+			newObjs = append(newObjs, hrhm)
+		}
+	}
+	filterAndAddHTTPRouteMeta(newObjs, c, acceptedStore, rejectedStore, numWorkers, false, namespaceTenant)
+}
+
+func AddHTTPRouteEventHandler(numWorkers uint32, c *GSLBMemberController) cache.ResourceEventHandler {
+	acceptedStore := store.GetAcceptedHTTPRouteStore()
+	rejectedStore := store.GetRejectedHTTPRouteStore()
+
+	gslbutils.Logf("Adding HTTPRoute handler")
+	routeEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			route, ok := obj.(*gatewayv1.HTTPRoute)
+			if !ok {
+				containerutils.AviLog.Errorf("Unable to convert obj type interface to gateway/v1 httproute")
+				return
+			}
+			metaObjs := k8sobjects.GetHostMetaForHTTPRoute(route, c.name, c.gatewayGetter(nil, false))
+			namespaceTenant := gslbutils.GetTenantInNamespaceAnnotation(route.Namespace, c.name)
+			filterAndAddHTTPRouteMeta(metaObjs, c, acceptedStore, rejectedStore, numWorkers, false, namespaceTenant)
+		},
+		DeleteFunc: c.guardDelete(gslbutils.HTTPRouteType, func(obj interface{}) {
+			route, ok := obj.(*gatewayv1.HTTPRoute)
+			if !ok {
+				containerutils.AviLog.Errorf("Unable to convert obj type interface to gateway/v1 httproute")
+				return
+			}
+			metaObjs := k8sobjects.GetHostMetaForHTTPRoute(route, c.name, c.gatewayGetter(nil, false))
+			deleteHTTPRouteMeta(metaObjs, c, acceptedStore, rejectedStore, numWorkers)
+		}),
+		UpdateFunc: func(old, curr interface{}) {
+			oldRoute, okOld := old.(*gatewayv1.HTTPRoute)
+			route, okNew := curr.(*gatewayv1.HTTPRoute)
+			if !okOld || !okNew {
+				gslbutils.Errf("Unable to convert obj type interface to gateway/v1 httproute")
+				return
+			}
+			if oldRoute.ResourceVersion != route.ResourceVersion {
+				oldMetaObjs := k8sobjects.GetHostMetaForHTTPRoute(oldRoute, c.name, c.gatewayGetter(nil, false))
+				newMetaObjs := k8sobjects.GetHostMetaForHTTPRoute(route, c.name, c.gatewayGetter(nil, false))
+				namespaceTenant := gslbutils.GetTenantInNamespaceAnnotation(route.Namespace, c.name)
+				filterAndUpdateHTTPRouteMeta(oldMetaObjs, newMetaObjs, c, acceptedStore, rejectedStore,
+					numWorkers, namespaceTenant)
+			}
+		},
+	}
+	return routeEventHandler
+}
+
+// AddGatewayEventHandler re-evaluates the httproutes attached to a gateway whenever the gateway
+// changes, as the addresses, listeners and annotations of the httproute hosts come from the gateway.
+func AddGatewayEventHandler(numWorkers uint32, c *GSLBMemberController) cache.ResourceEventHandler {
+	acceptedStore := store.GetAcceptedHTTPRouteStore()
+	rejectedStore := store.GetRejectedHTTPRouteStore()
+
+	// oldGw is the gateway as known before this event, nil if it didn't exist
+	reApplyHTTPRoutes := func(oldGw, gw *gatewayv1.Gateway, deleted bool) {
+		for _, route := range c.getHTTPRoutesForGateway(gw) {
+			var oldMetaObjs []k8sobjects.HTTPRouteHostMeta
+			if oldGw != nil {
+				oldMetaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, c.name, c.gatewayGetter(oldGw, false))
+			} else {
+				oldMetaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, c.name, c.gatewayGetter(gw, true))
+			}
+			newMetaObjs := k8sobjects.GetHostMetaForHTTPRoute(route, c.name, c.gatewayGetter(gw, deleted))
+			namespaceTenant := gslbutils.GetTenantInNamespaceAnnotation(route.Namespace, c.name)
+			filterAndUpdateHTTPRouteMeta(oldMetaObjs, newMetaObjs, c, acceptedStore, rejectedStore,
+				numWorkers, namespaceTenant)
+		}
+	}
+
+	gslbutils.Logf("Adding Gateway handler")
+	gwEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			gw, ok := obj.(*gatewayv1.Gateway)
+			if !ok {
+				containerutils.AviLog.Errorf("Unable to convert obj type interface to gateway/v1 gateway")
+				return
+			}
+			reApplyHTTPRoutes(nil, gw, false)
+		},
+		DeleteFunc: func(obj interface{}) {
+			gw, ok := obj.(*gatewayv1.Gateway)
+			if !ok {
+				containerutils.AviLog.Errorf("Unable to convert obj type interface to gateway/v1 gateway")
+				return
+			}
+			reApplyHTTPRoutes(gw, gw, true)
+		},
+		UpdateFunc: func(old, curr interface{}) {
+			oldGw, okOld := old.(*gatewayv1.Gateway)
+			gw, okNew := curr.(*gatewayv1.Gateway)
+			if !okOld || !okNew {
+				gslbutils.Errf("Unable to convert obj type interface to gateway/v1 gateway")
+				return
+			}
+			if oldGw.ResourceVersion != gw.ResourceVersion {
+				reApplyHTTPRoutes(oldGw, gw, false)
+			}
+		},
+	}
+	return gwEventHandler
+}
@@ -16,7 +16,6 @@ package ingestion
 
 import (
 	"context"
-	"errors"
 	"strings"
 
 	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
@@ -28,13 +27,15 @@ import (
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/k8sobjects"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
-	gdpalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	k8scache "k8s.io/client-go/tools/cache"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func fetchAndApplyAllIngresses(c *GSLBMemberController, nsList *corev1.NamespaceList) {
@@ -158,6 +159,31 @@ func fetchAndApplyAllMultiClusterIngresses(c *GSLBMemberController, nsList *core
 	}
 }
 
+// fetchAndApplyAllHTTPRoutes picks up the httproutes from the informer cache, as there's no typed
+// clientset for the gateway API. The informers are synced before the boot up sync starts.
+func fetchAndApplyAllHTTPRoutes(c *GSLBMemberController, nsList *corev1.NamespaceList) {
+	acceptedStore := store.GetAcceptedHTTPRouteStore()
+	rejectedStore := store.GetRejectedHTTPRouteStore()
+
+	for _, namespace := range nsList.Items {
+		objList, err := c.gatewayAPIInformers.HTTPRouteInformer.GetIndexer().ByIndex(k8scache.NamespaceIndex, namespace.Name)
+		if err != nil {
+			gslbutils.Errf("process: fullsync, namespace: %s, msg: error in fetching the httproute list, %s",
+				namespace.Name, err.Error())
+			continue
+		}
+		for _, obj := range objList {
+			route, ok := obj.(*gatewayv1.HTTPRoute)
+			if !ok {
+				continue
+			}
+			hrhms := k8sobjects.GetHostMetaForHTTPRoute(route, c.GetName(), c.gatewayGetter(nil, false))
+			namespaceTenant := gslbutils.GetTenantInNamespaceAnnotation(route.Namespace, c.name)
+			filterAndAddHTTPRouteMeta(hrhms, c, acceptedStore, rejectedStore, 0, true, namespaceTenant)
+		}
+	}
+}
+
 func checkGslbHostRulesAndInitialize() error {
 	gslbutils.Logf("process: fullsync, msg: will fetch GSLBHostRules")
 	gslbhrList, err := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBHostRules(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
@@ -197,8 +223,32 @@ func checkGslbHostRulesAndInitialize() error {
 	return nil
 }
 
+// checkGslbTrafficShiftsAndInitialize sets the traffic split of the GSLBTrafficShift objects in progress
+// or completed, so that the GslbServices built during the boot up sync already have it. The traffic
+// split of the GSLBTrafficShift objects for a GDP object is set once the GslbServices are built.
+func checkGslbTrafficShiftsAndInitialize() {
+	gslbutils.Logf("process: fullsync, msg: will fetch GSLBTrafficShifts")
+	gtsList, err := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBTrafficShifts(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		gslbutils.Warnf("process: fullsync, msg: error in fetching GSLBTrafficShift List API, will continue: %v", err)
+		return
+	}
+	for i := range gtsList.Items {
+		ts := &gtsList.Items[i]
+		if ts.Status.Phase != gslbalphav1.TrafficShiftProgressing && ts.Status.Phase != gslbalphav1.TrafficShiftCompleted {
+			continue
+		}
+		if err := ValidateGSLBTrafficShift(ts); err != nil {
+			gslbutils.Errf("process: fullsync, gslbTrafficShift: %s/%s, msg: error in accepting the GSLBTrafficShift: %v",
+				ts.Namespace, ts.Name, err)
+			continue
+		}
+		applyTrafficShift(ts)
+	}
+}
+
 func checkGDPsAndInitialize() error {
-	gdpList, err := gslbutils.AMKOControlConfig().GDPClientset().AmkoV1alpha2().GlobalDeploymentPolicies(gslbutils.AVISystem).List(context.TODO(), metav1.ListOptions{})
+	gdpList, err := gslbutils.AMKOControlConfig().GDPClientset().AmkoV1alpha2().GlobalDeploymentPolicies("").List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		return nil
 	}
@@ -208,46 +258,42 @@ func checkGDPsAndInitialize() error {
 		return nil
 	}
 
-	// check if any of these GDP objects have "success" in their fields
-	var successGDP *gdpalphav1.GlobalDeploymentPolicy
-
-	for _, gdp := range gdpList.Items {
-		if gdp.Status.ErrorStatus == GDPSuccess {
-			if successGDP == nil {
-				successGDP = &gdp
-			} else {
-				// there are more than two accepted GDPs, which pertains to an undefined state
-				gslbutils.Errf("ns: %s, msg: more than one GDP objects which were accepted, undefined state, can't do a full sync",
-					gslbutils.AVISystem)
-				return errors.New("more than one GDP objects in accepted state")
-			}
+	// all the GDP objects are added, the ones failing the sanity checks are rejected by AddGDPObj.
+	// The GDP objects in avi-system are added first, as they set the guardrails for the namespaced
+	// GDP objects.
+	for idx := range gdpList.Items {
+		if gdpList.Items[idx].Namespace == gslbutils.AVISystem {
+			AddGDPObj(&gdpList.Items[idx], nil, 0, true)
 		}
 	}
-
-	if successGDP != nil {
-		AddGDPObj(successGDP, nil, 0, true)
-		return nil
-	}
-
-	// no success GDPs, check if only one exists
-	if len(gdpList.Items) > 1 {
-		return errors.New("more than one GDP objects")
+	for idx := range gdpList.Items {
+		if gdpList.Items[idx].Namespace != gslbutils.AVISystem {
+			AddGDPObj(&gdpList.Items[idx], nil, 0, true)
+		}
 	}
-
-	AddGDPObj(&gdpList.Items[0], nil, 0, true)
 	return nil
 }
 
 func resyncMemberCluster() {
-	if len(pendingClusters) == 0 {
+	// pendingClustersLock isn't held while connecting to the member clusters, as an unreachable
+	// member cluster would block the GSLBConfig updates
+	resyncMemberClusterLock.Lock()
+	defer resyncMemberClusterLock.Unlock()
+	pending := getPendingClusters()
+	if len(pending) == 0 {
 		gslbutils.Debugf("Skipping ResyncMemberCluster, no cluster pending")
 		return
 	}
 
-	gslbutils.Logf("Starting cluster sync, will sync objects from pending member clusters: %v", pendingClusters)
+	gslbutils.Logf("Starting cluster sync, will sync objects from pending member clusters: %v", pending)
 	clients := make(map[string]*kubernetes.Clientset)
 	aviCtrlList := make([]*GSLBMemberController, 0)
-	for cluster := range pendingClusters {
+	for _, cluster := range pending {
+		if !gslbutils.IsClusterContextPresent(cluster.clusterName) {
+			// removed from the GSLBConfig object while it was pending
+			removeFromPendingClusters(cluster)
+			continue
+		}
 		gslbutils.Logf("cluster: %s, msg: %s", cluster.clusterName, "initializing")
 		cfg, err := BuildContextConfig(cluster.kubeconfig, cluster.clusterName)
 		if err != nil {
@@ -271,14 +317,19 @@ func resyncMemberCluster() {
 			tenant, _ := ns.Annotations[gslbutils.TenantAnnotation]
 			nt.AddOrUpdate(aviCtrl.name, ns.Name, tenant)
 		}
-		delete(pendingClusters, cluster)
+		if !removeFromPendingClusters(cluster) {
+			// removed from the GSLBConfig object while it was being initialized
+			gslbutils.Logf("cluster: %s, msg: removed while initializing, skipping the sync", cluster.clusterName)
+			gslbutils.DeleteInformersPerCluster(cluster.clusterName)
+			continue
+		}
 		if aviCtrl != nil {
 			aviCtrlList = append(aviCtrlList, aviCtrl)
 		}
 	}
 	// Start informers and wait for cache sync
 	for _, aviCtrl := range aviCtrlList {
-		aviCtrl.Start(stopCh)
+		aviCtrl.Start(getMemberClusterStopCh(aviCtrl.GetName()))
 		gslbutils.Logf("cluster: %s, msg: informer caches synced for resync", aviCtrl.GetName())
 	}
 
@@ -346,8 +397,7 @@ func clusterSync(ctrlList []*GSLBMemberController, gsCache *avicache.AviCache) {
 		}
 
 		for _, ns := range selectedNamespaces.Items {
-			_, err := gf.GetNSFilterLabel()
-			if err == nil {
+			if gf.HasNSFilter() {
 				nsMeta := k8sobjects.GetNSMeta(&ns, c.GetName())
 				if !filter.ApplyFilter(filter.FilterArgs{
 					Obj:     nsMeta,
@@ -376,6 +426,9 @@ func clusterSync(ctrlList []*GSLBMemberController, gsCache *avicache.AviCache) {
 		if c.informers.MultiClusterIngressInformer != nil {
 			fetchAndApplyAllMultiClusterIngresses(c, selectedNamespaces)
 		}
+		if c.gatewayAPIInformers != nil {
+			fetchAndApplyAllHTTPRoutes(c, selectedNamespaces)
+		}
 	}
 
 	// Generate models
@@ -398,6 +451,7 @@ func bootupSync(ctrlList []*GSLBMemberController, gsCache *avicache.AviCache) {
 		// Undefined state, panic
 		gslbutils.LogAndPanic(err.Error())
 	}
+	checkGslbTrafficShiftsAndInitialize()
 	clusterSync(ctrlList, gsCache)
 }
 
@@ -439,6 +493,14 @@ func GenerateModels(gsCache *avicache.AviCache) {
 			gslbutils.MCIType, ingName+"/"+tenant))
 	}
 
+	httpRouteList := store.GetAcceptedHTTPRouteStore().GetAllClusterNSObjects()
+	for _, routeName := range httpRouteList {
+		route := strings.Split(routeName, "/")
+		tenant := gslbutils.GetTenantInNamespace(route[1], route[0])
+		nodes.DequeueIngestion(gslbutils.MultiClusterKeyWithObjName(gslbutils.ObjectAdd,
+			gslbutils.HTTPRouteType, routeName+"/"+tenant))
+	}
+
 	gslbutils.Logf("keys for GS graphs published to layer 3")
 
 	sharedQ := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
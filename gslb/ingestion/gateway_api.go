@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/cache"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/k8sobjects"
+)
+
+const (
+	// HTTPRouteGatewayIndex indexes the HTTPRoutes by their parent gateways (namespace/name).
+	HTTPRouteGatewayIndex = "gateway"
+
+	gatewayAPIResyncPeriod = time.Second * 30
+)
+
+var (
+	GatewayGVR   = gatewayv1.SchemeGroupVersion.WithResource("gateways")
+	HTTPRouteGVR = gatewayv1.SchemeGroupVersion.WithResource("httproutes")
+)
+
+// GatewayAPIInformers holds the gateway and httproute informers for a member cluster. Only the types
+// of the gateway API are available to AMKO, so, the informers are built on top of the dynamic client
+// and the objects are converted to their typed counterparts before they are added to the cache.
+type GatewayAPIInformers struct {
+	GatewayInformer   cache.SharedIndexInformer
+	HTTPRouteInformer cache.SharedIndexInformer
+	dynamicClient     dynamic.Interface
+}
+
+// NewGatewayAPIInformers verifies that the gateway API is available in the member cluster and
+// returns the informers for gateways and httproutes.
+func NewGatewayAPIInformers(dynamicClient dynamic.Interface, cname string) (*GatewayAPIInformers, error) {
+	for _, gvr := range []schema.GroupVersionResource{GatewayGVR, HTTPRouteGVR} {
+		_, err := dynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll).List(context.TODO(),
+			metav1.ListOptions{Limit: 1})
+		if err != nil {
+			return nil, fmt.Errorf("cluster: %s, resource: %s, gateway API not available: %v", cname,
+				gvr.Resource, err)
+		}
+	}
+	return &GatewayAPIInformers{
+		GatewayInformer: newGatewayAPIInformer(dynamicClient, GatewayGVR, cache.Indexers{},
+			func() interface{} { return &gatewayv1.Gateway{} }),
+		HTTPRouteInformer: newGatewayAPIInformer(dynamicClient, HTTPRouteGVR,
+			cache.Indexers{
+				cache.NamespaceIndex:  cache.MetaNamespaceIndexFunc,
+				HTTPRouteGatewayIndex: httpRouteGatewayIndexFunc,
+			},
+			func() interface{} { return &gatewayv1.HTTPRoute{} }),
+		dynamicClient: dynamicClient,
+	}, nil
+}
+
+func newGatewayAPIInformer(dynamicClient dynamic.Interface, gvr schema.GroupVersionResource,
+	indexers cache.Indexers, newObj func() interface{}) cache.SharedIndexInformer {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (k8sruntime.Object, error) {
+			return dynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return dynamicClient.Resource(gvr).Namespace(metav1.NamespaceAll).Watch(context.TODO(), options)
+		},
+	}
+	informer := cache.NewSharedIndexInformer(lw, &unstructured.Unstructured{}, gatewayAPIResyncPeriod, indexers)
+	informer.SetTransform(func(obj interface{}) (interface{}, error) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return obj, nil
+		}
+		typedObj := newObj()
+		if err := k8sruntime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), typedObj); err != nil {
+			return nil, fmt.Errorf("resource: %s, ns: %s, name: %s, error in converting object: %v",
+				gvr.Resource, u.GetNamespace(), u.GetName(), err)
+		}
+		return typedObj, nil
+	})
+	return informer
+}
+
+func httpRouteGatewayIndexFunc(obj interface{}) ([]string, error) {
+	route, ok := obj.(*gatewayv1.HTTPRoute)
+	if !ok {
+		return []string{}, nil
+	}
+	gateways := []string{}
+	for _, parentRef := range route.Spec.ParentRefs {
+		if !k8sobjects.IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gwKey := k8sobjects.GetParentRefNamespace(parentRef, route.Namespace) + "/" + string(parentRef.Name)
+		if !gslbutils.PresentInList(gwKey, gateways) {
+			gateways = append(gateways, gwKey)
+		}
+	}
+	return gateways, nil
+}
+
+// gatewayGetter returns a function to fetch the gateways from the informer cache of this cluster.
+// overrideGw, if not nil, is returned instead of the cached copy for a matching namespace/name,
+// which is required to evaluate the httproutes against an old or a deleted gateway.
+func (c *GSLBMemberController) gatewayGetter(overrideGw *gatewayv1.Gateway, gwDeleted bool) k8sobjects.GatewayGetter {
+	return func(ns, name string) (*gatewayv1.Gateway, bool) {
+		if overrideGw != nil && overrideGw.Namespace == ns && overrideGw.Name == name {
+			if gwDeleted {
+				return nil, false
+			}
+			return overrideGw, true
+		}
+		if c.gatewayAPIInformers == nil {
+			return nil, false
+		}
+		obj, exists, err := c.gatewayAPIInformers.GatewayInformer.GetIndexer().GetByKey(ns + "/" + name)
+		if err != nil || !exists {
+			return nil, false
+		}
+		gw, ok := obj.(*gatewayv1.Gateway)
+		return gw, ok
+	}
+}
+
+// getHTTPRoutesForGateway returns all the httproutes which have the gateway as one of their parents.
+func (c *GSLBMemberController) getHTTPRoutesForGateway(gw *gatewayv1.Gateway) []*gatewayv1.HTTPRoute {
+	routes := []*gatewayv1.HTTPRoute{}
+	objs, err := c.gatewayAPIInformers.HTTPRouteInformer.GetIndexer().ByIndex(HTTPRouteGatewayIndex,
+		gw.Namespace+"/"+gw.Name)
+	if err != nil {
+		gslbutils.Errf("cluster: %s, ns: %s, gateway: %s, msg: error in fetching httproutes for gateway: %v",
+			c.name, gw.Namespace, gw.Name, err)
+		return routes
+	}
+	for _, obj := range objs {
+		if route, ok := obj.(*gatewayv1.HTTPRoute); ok {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	avictrl "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/filterstore"
@@ -57,8 +58,10 @@ type objectDeletionInfo struct {
 }
 
 const (
-	AlreadyExistsErr = "a GDP object already exists, can't add another"
-	GDPSuccess       = "success"
+	GDPSuccess = "success"
+	// GDPStatusSyncInterval is the interval at which the GslbServices owned by each GDP object
+	// are published on its status
+	GDPStatusSyncInterval = 10 * time.Second
 )
 
 // GDPAddfn is a type of function which handles an add or a delete of a GDP
@@ -84,9 +87,38 @@ type GDPController struct {
 func (gdpController *GDPController) Run(stopCh <-chan struct{}) error {
 	defer runtime.HandleCrash()
 	gslbutils.Logf("object: GDPController, msg: %s", "starting the workers")
-	<-stopCh
-	gslbutils.Logf("object: GDPController, msg: %s", "shutting down the workers")
-	return nil
+	ticker := time.NewTicker(GDPStatusSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			gdpController.syncGDPGslbServicesStatus()
+		case <-stopCh:
+			gslbutils.Logf("object: GDPController, msg: %s", "shutting down the workers")
+			return nil
+		}
+	}
+}
+
+// syncGDPGslbServicesStatus publishes the GslbServices owned by the GDP objects, and the conflicts
+// with other GDP objects, for all the GDP objects whose GslbServices have changed.
+func (gdpController *GDPController) syncGDPGslbServicesStatus() {
+	changedGDPs := gslbutils.GetGSGDPMap().PopChangedGDPs()
+	if !gslbutils.AMKOControlConfig().PublishGDPStatus() {
+		return
+	}
+	for _, gdpKey := range changedGDPs {
+		if gslbutils.GetGlobalFilter().GetFilter(gdpKey) == nil {
+			continue
+		}
+		ns, gdpName := gslbutils.SplitGDPFilterKey(gdpKey)
+		gdpObj, err := gdpController.gdpLister.GlobalDeploymentPolicies(ns).Get(gdpName)
+		if err != nil {
+			gslbutils.Warnf("gdp: %s, msg: couldn't fetch the GDP object to update the status: %v", gdpKey, err)
+			continue
+		}
+		updateGDPStatus(gdpObj.DeepCopy(), GDPSuccess)
+	}
 }
 
 func AddOrUpdateNSStore(clusterNSStore *store.ObjectStore, ns *corev1.Namespace, cname string) {
@@ -124,7 +156,8 @@ func MoveObjs(objList []string, fromStore *store.ClusterStore, toStore *store.Cl
 	var err error
 	for _, multiClusterObjName := range objList {
 		if objType == gslbutils.IngressType ||
-			objType == gslbutils.MCIType {
+			objType == gslbutils.MCIType ||
+			objType == gslbutils.HTTPRouteType {
 			var hostName string
 			cname, ns, objName, hostName, err = gslbutils.SplitMultiClusterIngHostName(multiClusterObjName)
 			if err != nil {
@@ -155,7 +188,8 @@ func splitName(objType, objName string) (string, string, string, error) {
 	var cname, ns, sname, hostname string
 	var err error
 	if objType == gdpalphav2.IngressObj ||
-		objType == gslbutils.MCIType {
+		objType == gslbutils.MCIType ||
+		objType == gslbutils.HTTPRouteType {
 		cname, ns, sname, hostname, err = gslbutils.SplitMultiClusterIngHostName(objName)
 		sname += "/" + hostname
 	} else {
@@ -185,6 +219,10 @@ func GetObjTypeStores(objType string) (string, *store.ClusterStore, *store.Clust
 		acceptedObjStore = store.GetAcceptedMultiClusterIngressStore()
 		rejectedObjStore = store.GetRejectedMultiClusterIngressStore()
 		objKey = gslbutils.MCIType
+	} else if objType == gslbutils.HTTPRouteType {
+		acceptedObjStore = store.GetAcceptedHTTPRouteStore()
+		rejectedObjStore = store.GetRejectedHTTPRouteStore()
+		objKey = gslbutils.HTTPRouteType
 	} else {
 		gslbutils.Errf("Unknown Object type: %s", objType)
 		return "", nil, nil, errors.New("unknown object type " + objType)
@@ -333,6 +371,31 @@ func validLabel(label map[string]string) error {
 }
 
 func GDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync bool) error {
+	// for GDP objects, the refs have to be fetched from the controller with infinite retries
+	return gdpSanityChecks(gdp, fullSync, true, true)
+}
+
+// gdpSanityChecks validates a GDP object, infiniteRetry determines whether the Avi controller calls
+// to fetch the refs are retried until they succeed. The refs fetched from the controller are added
+// to the caches only if addToCache is true.
+func gdpSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync, infiniteRetry, addToCache bool) error {
+	// GDP objects outside avi-system are bounded by the guardrails
+	if gdp.Namespace != gslbutils.AVISystem {
+		if err := namespacedGDPSanityChecks(gdp); err != nil {
+			return err
+		}
+	} else if guardrails := gdp.Spec.NamespacedPolicyGuardrails; guardrails != nil {
+		for _, cluster := range guardrails.AllowedClusters {
+			if !gslbutils.IsClusterContextPresent(cluster) {
+				return fmt.Errorf("cluster context %s in namespacedPolicyGuardrails not present in GSLBConfig", cluster)
+			}
+		}
+		if guardrails.TTLRange != nil && guardrails.TTLRange.Min > guardrails.TTLRange.Max {
+			return fmt.Errorf("invalid ttlRange in namespacedPolicyGuardrails, min %d is greater than max %d",
+				guardrails.TTLRange.Min, guardrails.TTLRange.Max)
+		}
+	}
+
 	// MatchRules checks
 	mr := gdp.Spec.MatchRules
 	// no app selector and no namespace selector means, no objects selected
@@ -341,17 +404,26 @@ func GDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync bool) erro
 			return errors.New(err.Error() + " for appSelector")
 		}
 	}
+	if _, err := gslbutils.GetLabelSelector(mr.AppSelector.Label, mr.AppSelector.MatchExpressions); err != nil {
+		return errors.New("invalid appSelector: " + err.Error())
+	}
 	if len(mr.NamespaceSelector.Label) > 0 {
 		if err := validLabel(mr.NamespaceSelector.Label); err != nil {
 			return errors.New(err.Error() + "for namespaceSelector")
 		}
 	}
+	if _, err := gslbutils.GetLabelSelector(mr.NamespaceSelector.Label, mr.NamespaceSelector.MatchExpressions); err != nil {
+		return errors.New("invalid namespaceSelector: " + err.Error())
+	}
 
 	// MatchClusters checks, empty matchClusters are allowed
 	for _, cluster := range gdp.Spec.MatchClusters {
 		if !gslbutils.IsClusterContextPresent(cluster.Cluster) {
 			return fmt.Errorf("cluster context %s not present in GSLBConfig", cluster.Cluster)
 		}
+		if err := isGeoLocationValid(cluster.Location); err != nil {
+			return fmt.Errorf("invalid location of cluster %s: %v", cluster.Cluster, err)
+		}
 	}
 
 	// TrafficSplit checks
@@ -375,12 +447,12 @@ func GDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync bool) erro
 
 	// Health monitor validity
 	if gdp.Spec.HealthMonitorTemplate != nil {
-		if err := validateAndAddHmTemplateToCache(*gdp.Spec.HealthMonitorTemplate, true, fullSync, gdp.Namespace); err != nil {
+		if err := validateHmTemplate(*gdp.Spec.HealthMonitorTemplate, infiniteRetry, fullSync, addToCache, gdp.Namespace); err != nil {
 			return err
 		}
 	} else if len(gdp.Spec.HealthMonitorRefs) != 0 {
 		for _, hmRef := range gdp.Spec.HealthMonitorRefs {
-			if !isHealthMonitorRefValid(hmRef, true, fullSync, gdp.Namespace) {
+			if !isHealthMonitorRefValid(hmRef, infiniteRetry, fullSync, gdp.Namespace) {
 				return fmt.Errorf("health monitor ref %s is invalid", hmRef)
 			}
 		}
@@ -395,7 +467,7 @@ func GDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync bool) erro
 	if gdp.Spec.SitePersistenceRef != nil && *gdp.Spec.SitePersistenceRef == "" {
 		return fmt.Errorf("empty string as site persistence reference not supported")
 	} else if gdp.Spec.SitePersistenceRef != nil {
-		if !isSitePersistenceProfilePresent(*gdp.Spec.SitePersistenceRef, true, fullSync, gdp.Namespace) {
+		if !isSitePersistenceProfilePresent(*gdp.Spec.SitePersistenceRef, infiniteRetry, fullSync, addToCache, gdp.Namespace) {
 			return fmt.Errorf("site persistence ref %s not present", *gdp.Spec.SitePersistenceRef)
 		}
 	}
@@ -404,7 +476,7 @@ func GDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync bool) erro
 	if gdp.Spec.PKIProfileRef != nil && *gdp.Spec.PKIProfileRef == "" {
 		return fmt.Errorf("empty string as pki profile reference not supported")
 	} else if gdp.Spec.PKIProfileRef != nil {
-		if !isPKIProfilePresent(*gdp.Spec.PKIProfileRef, true, fullSync, gdp.Namespace) {
+		if !isPKIProfilePresent(*gdp.Spec.PKIProfileRef, infiniteRetry, fullSync, addToCache, gdp.Namespace) {
 			return fmt.Errorf("pki profile ref %s not present", *gdp.Spec.PKIProfileRef)
 		}
 	}
@@ -417,11 +489,30 @@ func GDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy, fullSync bool) erro
 		}
 	}
 
+	if err := isIPFamilyValid(gdp.Spec.IPFamily); err != nil {
+		return err
+	}
+
+	if err := isGSSettingsValid(gdp.Spec.HealthMonitorScope, gdp.Spec.MinMembers, gdp.Spec.MinHealthMonitorsUp); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func updateGDPStatus(gdp *gdpalphav2.GlobalDeploymentPolicy, msg string) {
 	gdp.Status.ErrorStatus = msg
+	gdp.Status.GslbServices = nil
+	gdp.Status.Conflicts = nil
+	if msg == GDPSuccess {
+		owned, conflicts := gslbutils.GetGSGDPMap().GetGDPGslbServices(gslbutils.GetGDPFilterKey(gdp.Namespace, gdp.Name))
+		if len(owned) != 0 {
+			gdp.Status.GslbServices = owned
+		}
+		if len(conflicts) != 0 {
+			gdp.Status.Conflicts = conflicts
+		}
+	}
 
 	// Always check this flag before writing the status on the GDP object. The reason is, for unit tests,
 	// the fake client doesn't have CRD capability and hence, can't do a runtime create/update of CRDs.
@@ -434,29 +525,6 @@ func updateGDPStatus(gdp *gdpalphav2.GlobalDeploymentPolicy, msg string) {
 	}
 }
 
-func filterExists(f *gslbutils.GlobalFilter) bool {
-	// Check if a filter already exists for this namespace
-	// Check if AppFilter or NSFilter is set
-	if f != nil {
-		f.GlobalLock.RLock()
-		defer f.GlobalLock.RUnlock()
-		if f.AppFilter != nil {
-			gslbutils.Debugf("no app filter")
-			return true
-		}
-		if f.NSFilter != nil {
-			f.NSFilter.Lock.RLock()
-			defer f.NSFilter.Lock.RUnlock()
-			if len(f.NSFilter.SelectedNS) > 0 {
-				gslbutils.Debugf("no ns filter")
-				return true
-			}
-		}
-	}
-	// For all other conditions, return false
-	return false
-}
-
 func deleteNamespacedObjsAndWriteToQueue(objType string, k8swq []workqueue.RateLimitingInterface, numWorkers uint32, cname, ns string) {
 	gslbutils.Logf("ns: %s, objType: %s, msg: checking if objects need to be deleted", ns, objType)
 	objKey, acceptedObjStore, rejectedObjStore, err := GetObjTypeStores(objType)
@@ -564,6 +632,7 @@ func DeleteNamespacedObjsFromAllStores(k8swq []workqueue.RateLimitingInterface,
 	deleteNamespacedObjsAndWriteToQueue(gdpalphav2.LBSvcObj, k8swq, numWorkers, nsMeta.Cluster, nsMeta.Name)
 	deleteNamespacedObjsAndWriteToQueue(gdpalphav2.IngressObj, k8swq, numWorkers, nsMeta.Cluster, nsMeta.Name)
 	deleteNamespacedObjsAndWriteToQueue(gslbutils.MCIType, k8swq, numWorkers, nsMeta.Cluster, nsMeta.Name)
+	deleteNamespacedObjsAndWriteToQueue(gslbutils.HTTPRouteType, k8swq, numWorkers, nsMeta.Cluster, nsMeta.Name)
 
 	gslbutils.Logf("cluster: %s, ns: %s, msg: completed namespace deletion for all object types", nsMeta.Cluster, nsMeta.Name)
 }
@@ -574,6 +643,7 @@ func WriteChangedObjsToQueue(k8swq []workqueue.RateLimitingInterface, numWorkers
 	writeChangedObjToQueue(gdpalphav2.LBSvcObj, k8swq, numWorkers, allGSPropertyChanged, clustersToBeSynced)
 	writeChangedObjToQueue(gdpalphav2.IngressObj, k8swq, numWorkers, allGSPropertyChanged, clustersToBeSynced)
 	writeChangedObjToQueue(gslbutils.MCIType, k8swq, numWorkers, allGSPropertyChanged, clustersToBeSynced)
+	writeChangedObjToQueue(gslbutils.HTTPRouteType, k8swq, numWorkers, allGSPropertyChanged, clustersToBeSynced)
 }
 
 func applyAndUpdateNamespaces() {
@@ -612,38 +682,9 @@ func applyAndUpdateNamespaces() {
 	}
 }
 
-func applyAndRejectNamespaces(gf *gslbutils.GlobalFilter, gdp *gdpalphav2.GlobalDeploymentPolicy) {
-	acceptedNSStore := store.GetAcceptedNSStore()
-	rejectedNSStore := store.GetRejectedNSStore()
-
-	// Since, we have just deleted a GDP object, we need to just check the acceptedNSStore
-	acceptedList, _ := filterstore.GetAllFilteredNamespaces(filter.ApplyFilter, acceptedNSStore)
-	if len(acceptedList) == 0 {
-		gslbutils.Logf("accepted list of namespaces is empty, nothing to be done")
-		return
-	}
-	MoveNSObjs(acceptedList, acceptedNSStore, rejectedNSStore)
-	gslbutils.Logf("objList: %v, msg: moved namespaces from accepted to rejected store", acceptedList)
-}
-
-func applyAndAcceptNamespaces() {
-	acceptedNSStore := store.GetAcceptedNSStore()
-	rejectedNSStore := store.GetRejectedNSStore()
-
-	// Since, we have just added a fresh GDP object, all the previous namespaces will be in rejected store
-	// so, apply and move the objects from rejected store
-	acceptedList, rejectedList := filterstore.GetAllFilteredNamespaces(filter.ApplyFilter, rejectedNSStore)
-	if len(rejectedList) == 0 {
-		gslbutils.Logf("rejected list of namespaces is empty, nothing to be done")
-		return
-	}
-
-	MoveNSObjs(acceptedList, rejectedNSStore, acceptedNSStore)
-	gslbutils.Logf("objList: %v, msg: moved these namespaces from rejected to accepted store", acceptedList)
-}
-
-// AddGDPObj creates a new GlobalFilter if not present on the first GDP object. Subsequent
-// adds for GDP objects must fail as only one GDP object is allowed globally.
+// AddGDPObj creates a new filter for a GDP object and adds it to the GlobalFilter. Multiple GDP
+// objects are allowed, an object is accepted if it is selected by any of the GDP objects. GDP objects
+// outside avi-system are only accepted within the guardrails set by the GDP objects in avi-system.
 func AddGDPObj(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32, fullSync bool) {
 	gdp, ok := obj.(*gdpalphav2.GlobalDeploymentPolicy)
 	if !ok {
@@ -651,22 +692,16 @@ func AddGDPObj(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWork
 		return
 	}
 
-	// GDPs for all other namespaces are rejected
-	if gdp.ObjectMeta.Namespace != gslbutils.AVISystem {
-		return
+	namespaced := gdp.ObjectMeta.Namespace != gslbutils.AVISystem
+	if namespaced {
+		// namespaced GDP objects are stored, so that they can be re-evaluated if the guardrails change
+		getNamespacedGDPStore().addOrUpdate(gdp)
 	}
 
 	gf := gslbutils.GetGlobalFilter()
-	if filterExists(gf) {
-		// check if this is the same GDP as the already accepted GDP object
-		name, ns := gslbutils.GetGDPObj()
-		if name == gdp.ObjectMeta.GetName() && ns == gdp.ObjectMeta.GetNamespace() {
-			// this object is already added, no need to update the status, just return
-			return
-		}
-		msg := "a GDP object already exists, can't add another"
-		gslbutils.Errf(msg)
-		updateGDPStatus(gdp, msg)
+	gdpKey := gslbutils.GetGDPFilterKey(gdp.ObjectMeta.Namespace, gdp.ObjectMeta.Name)
+	if gf.GetFilter(gdpKey) != nil {
+		// this object is already added, no need to update the status, just return
 		return
 	}
 	err := GDPSanityChecks(gdp, fullSync)
@@ -680,16 +715,23 @@ func AddGDPObj(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWork
 	gslbutils.Logf("ns: %s, gdp: %s, msg: %s", gdp.ObjectMeta.Namespace, gdp.ObjectMeta.Name,
 		"GDP object added")
 
-	gslbutils.Logf("creating a new filter")
-	gf.AddToFilter(gdp)
-	// First apply the filter on the namespaces
-	applyAndAcceptNamespaces()
+	gslbutils.Logf("gdp: %s, msg: creating a new filter", gdpKey)
+	gdpFilter := gslbutils.NewGDPFilter(gdpKey)
+	gdpFilter.AddToFilter(gdp)
+	gf.AddFilter(gdpFilter)
+	if !namespaced {
+		// the guardrails for the namespaced GDP objects might have changed
+		reevaluateNamespacedGDPs(fullSync)
+	}
+	// First apply the filters on the namespaces, the already accepted namespaces might be
+	// selected by this GDP object too
+	applyAndUpdateNamespaces()
 	// for bootup sync, k8swq will be nil, in which case, the movement of objects will be taken
 	// care of by the bootupSync function
 	if k8swq != nil {
-		WriteChangedObjsToQueue(k8swq, numWorkers, false, []string{})
+		// if other GDP objects exist, the GS properties of the accepted objects have to be re-evaluated
+		WriteChangedObjsToQueue(k8swq, numWorkers, gf.Len() > 1, []string{})
 	}
-	gslbutils.SetGDPObj(gdp.GetObjectMeta().GetName(), gdp.GetObjectMeta().GetNamespace())
 }
 
 // UpdateGDPObj updates the global and the namespace filters if a the GDP object
@@ -704,13 +746,17 @@ func UpdateGDPObj(old, new interface{}, k8swq []workqueue.RateLimitingInterface,
 		return
 	}
 
+	namespaced := newGdp.ObjectMeta.Namespace != gslbutils.AVISystem
+	if namespaced {
+		getNamespacedGDPStore().addOrUpdate(newGdp)
+	}
+
 	gf := gslbutils.GetGlobalFilter()
-	// update only the accepted GDP
-	if !gslbutils.IsEmpty() {
-		if name, ns := gslbutils.GetGDPObj(); name != newGdp.GetObjectMeta().GetName() && ns != newGdp.GetObjectMeta().GetNamespace() {
-			gslbutils.Errf("A GDP object already exists, updates will be ignored for other GDP objects")
-			return
-		}
+	gdpFilter := gf.GetFilter(gslbutils.GetGDPFilterKey(newGdp.ObjectMeta.Namespace, newGdp.ObjectMeta.Name))
+	if gdpFilter == nil {
+		// this GDP object wasn't accepted earlier, process it as a new GDP object
+		AddGDPObj(newGdp, k8swq, numWorkers, false)
+		return
 	}
 
 	// Remove the template saved in the cache if required
@@ -726,40 +772,48 @@ func UpdateGDPObj(old, new interface{}, k8swq []workqueue.RateLimitingInterface,
 	}
 	updateGDPStatus(newGdp, "success")
 
-	if gdpChanged, allGSPropertyChanged, clustersToBeSynced := gf.UpdateGlobalFilter(oldGdp, newGdp); gdpChanged {
+	if gdpChanged, allGSPropertyChanged, clustersToBeSynced := gdpFilter.UpdateFilter(oldGdp, newGdp); gdpChanged {
 		gslbutils.Logf("GDP object changed, will go through the objects again")
+		if !namespaced {
+			// the guardrails for the namespaced GDP objects might have changed
+			reevaluateNamespacedGDPs(false)
+		}
 		// first apply and update the namespaces in the filter
 		applyAndUpdateNamespaces()
-		WriteChangedObjsToQueue(k8swq, numWorkers, allGSPropertyChanged, clustersToBeSynced)
+		// with multiple GDP objects, a change in the selectors of one GDP object can change the
+		// GDP objects selecting an already accepted object
+		WriteChangedObjsToQueue(k8swq, numWorkers, allGSPropertyChanged || gf.Len() > 1, clustersToBeSynced)
 	}
 }
 
-// DeleteGDPObj requires to delete the filters that were previously created. If a GDP
-// object is deleted, the previously accepted and rejected objects need to pass through
-// this filter again to find out which filter is applicable, the global one or the
-// local one.
+// DeleteGDPObj requires to delete the filter that was previously created for the GDP object.
+// If a GDP object is deleted, the previously accepted and rejected objects need to pass
+// through the filters of the remaining GDP objects again.
 func DeleteGDPObj(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32) {
 	gdp := obj.(*gdpalphav2.GlobalDeploymentPolicy)
 	gslbutils.Logf("ns: %s, gdp: %s, msg: %s", gdp.ObjectMeta.Namespace, gdp.ObjectMeta.Name,
 		"deleted GDP object")
 
-	if name, ns := gslbutils.GetGDPObj(); name != gdp.GetObjectMeta().GetName() && ns != gdp.GetObjectMeta().GetNamespace() {
-		gslbutils.Errf("won't delete the filter as GDP object deleted wasn't accepted")
-		return
+	namespaced := gdp.ObjectMeta.Namespace != gslbutils.AVISystem
+	if namespaced {
+		getNamespacedGDPStore().delete(gdp)
 	}
 
 	gf := gslbutils.GetGlobalFilter()
-	if gf == nil {
-		gslbutils.Errf("object: GlobalFilter, msg: global filter not initialized, can't delete")
+	gdpKey := gslbutils.GetGDPFilterKey(gdp.ObjectMeta.Namespace, gdp.ObjectMeta.Name)
+	if !gf.DeleteFilter(gdpKey) {
+		gslbutils.Errf("gdp: %s, msg: won't delete the filter as GDP object deleted wasn't accepted", gdpKey)
 		return
 	}
-	applyAndRejectNamespaces(gf, gdp)
-	gf.DeleteFromGlobalFilter(gdp)
-	// remove all namespaces from filter and re-apply
+	if !namespaced {
+		// the namespaced GDP objects might not be within the remaining guardrails
+		reevaluateNamespacedGDPs(false)
+	}
+	// remove all namespaces from the filters and re-apply the remaining filters
 	k8sobjects.RemoveAllSelectedNamespaces()
-	WriteChangedObjsToQueue(k8swq, numWorkers, false, []string{})
-
-	gslbutils.SetGDPObj("", "")
+	applyAndUpdateNamespaces()
+	// if other GDP objects exist, the GS properties of the accepted objects have to be re-evaluated
+	WriteChangedObjsToQueue(k8swq, numWorkers, gf.Len() > 0, []string{})
 }
 
 // InitializeGDPController handles initialization of a controller which handles
@@ -20,7 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"sort"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
@@ -38,6 +38,7 @@ import (
 	oshiftclient "github.com/openshift/client-go/route/clientset/versioned"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	restclient "k8s.io/client-go/rest"
@@ -70,6 +71,13 @@ import (
 
 var pendingClusters map[KubeClusterDetails]struct{}
 
+// pendingClustersLock protects pendingClusters, as the member clusters can be added and removed
+// at runtime
+var pendingClustersLock sync.Mutex
+
+// resyncMemberClusterLock serializes the initialization and sync of the pending member clusters.
+var resyncMemberClusterLock sync.Mutex
+
 const (
 	BootupMsg              = "starting up amko"
 	BootupSyncMsg          = "syncing all objects"
@@ -170,14 +178,9 @@ func getGSLBConfigChecksum(gc *gslbalphav1.GSLBConfig) uint32 {
 		return cksum
 	}
 
+	// member clusters are not part of the checksum, as the changes to them are applied without a restart
 	cksum += utils.Hash(gcSpec.GSLBLeader.ControllerIP) + utils.Hash(gcSpec.GSLBLeader.ControllerVersion) +
-		utils.Hash(gcSpec.GSLBLeader.Credentials)
-	memberClusters := []string{}
-	for _, c := range gcSpec.MemberClusters {
-		memberClusters = append(memberClusters, c.ClusterContext)
-	}
-	sort.Strings(memberClusters)
-	cksum += utils.Hash(utils.Stringify(memberClusters)) + utils.Hash(strconv.Itoa(gcSpec.RefreshInterval))
+		utils.Hash(gcSpec.GSLBLeader.Credentials) + utils.Hash(strconv.Itoa(gcSpec.RefreshInterval))
 	return cksum
 }
 
@@ -245,7 +248,34 @@ func GetNewController(kubeclientset kubernetes.Interface, gslbclientset gslbcs.I
 				return
 			}
 
+			if oldGc.Spec.StaleObjectGracePeriod != newGc.Spec.StaleObjectGracePeriod {
+				gslbutils.Logf("staleObjectGracePeriod: %d, msg: stale object grace period changed",
+					newGc.Spec.StaleObjectGracePeriod)
+				SetStaleObjectGracePeriod(newGc.Spec.StaleObjectGracePeriod)
+			}
+
+			if !reflect.DeepEqual(oldGc.Spec.DeletionProtection, newGc.Spec.DeletionProtection) {
+				gslbutils.Logf("msg: deletion protection changed")
+				avirest.SetDeletionProtection(newGc.Spec.DeletionProtection)
+			}
+			if oldGc.Spec.AdoptExistingGslbServices != newGc.Spec.AdoptExistingGslbServices {
+				gslbutils.Logf("adoptExistingGslbServices: %v, msg: adoption of the existing GslbServices changed",
+					newGc.Spec.AdoptExistingGslbServices)
+				avirest.SetGSAdoption(newGc.Spec.AdoptExistingGslbServices)
+				if newGc.Spec.AdoptExistingGslbServices {
+					nodes.PublishAllGraphKeys()
+				}
+			}
+			if !reflect.DeepEqual(oldGc.Spec.OrphanCleanup, newGc.Spec.OrphanCleanup) {
+				gslbutils.Logf("msg: orphan cleanup changed")
+				avirest.SetOrphanCleanup(newGc.Spec.OrphanCleanup)
+			}
+			if heldID, ok := newGc.Annotations[gslbutils.ReleaseHeldDeletionsAnnotation]; ok {
+				avirest.ReleaseHeldDeletions(heldID)
+			}
+
 			if getGSLBConfigChecksum(oldGc) == getGSLBConfigChecksum(newGc) {
+				UpdateMemberClusters(oldGc.Spec.MemberClusters, newGc.Spec.MemberClusters)
 				return
 			}
 			gslbutils.Warnf("an update has been made to the GSLBConfig object, AMKO needs a reboot to register the changes")
@@ -365,6 +395,10 @@ func CheckAndSetGslbLeader() error {
 }
 
 func ResyncNodesToRestLayer() {
+	if !gslbutils.IsReplicaElected() {
+		gslbutils.Debugf("msg: this AMKO replica is a standby, skipping the resync")
+		return
+	}
 	prevStateCtrl := gslbutils.IsControllerLeader()
 	err := CheckAndSetGslbLeader()
 	if err != nil {
@@ -389,6 +423,17 @@ func ResyncNodesToRestLayer() {
 		gslbutils.SetResyncRequired(true)
 	}
 
+	// the existing GslbServices which couldn't be adopted might have changed
+	avirest.RetryAdoptionConflicts()
+	// the health monitors are fetched once for the drift check and the orphan sweep
+	if hmCache, err := avicache.FetchHMCache(); err != nil {
+		gslbutils.Errf("msg: error in fetching the health monitors, skipping the drift check and the orphan sweep: %v", err)
+	} else {
+		// the health monitors edited or deleted outside of AMKO are corrected
+		CheckHmDrift(hmCache)
+		sweepOrphans(hmCache)
+	}
+
 	if !gslbutils.IsResyncRequired() {
 		gslbutils.Logf("resync not required")
 		return
@@ -402,6 +447,20 @@ func ResyncNodesToRestLayer() {
 	gslbutils.SetResyncRequired(false)
 }
 
+// sweepOrphans looks for the GslbServices and health monitors left behind on the controller, hmCache
+// has the health monitors fetched from the controller. The sweep is skipped while a member cluster
+// isn't synced, as the GslbServices of its objects aren't built.
+func sweepOrphans(hmCache *avicache.AviHmCache) {
+	if !avirest.IsOrphanCleanupEnabled() {
+		return
+	}
+	if !allMemberClustersSynced() {
+		gslbutils.Logf("msg: all member clusters aren't synced, skipping the orphan sweep")
+		return
+	}
+	avirest.SweepOrphans(avicache.PopulateGSCache(false), hmCache)
+}
+
 // CacheRefreshRoutine fetches the objects in the AVI controller and finds out
 // the delta between the existing and the new objects.
 func CacheRefreshRoutine() {
@@ -445,7 +504,6 @@ func CacheRefreshRoutine() {
 			PublishChangeToRestLayer(key, sharedQ)
 		}
 	}
-
 	gslbutils.Logf("AVI Cache refresh done")
 }
 
@@ -457,12 +515,17 @@ func GenerateKubeConfig() error {
 		utils.AviLog.Fatal("GSLB_CONFIG environment variable not set, exiting...")
 		return errors.New("GSLB_CONFIG environment variable not set, exiting")
 	}
+	return writeKubeConfig(membersKubeConfig)
+}
+
+func writeKubeConfig(kubeConfigData string) error {
 	f, err := os.Create(gslbutils.GSLBKubePath)
 	if err != nil {
 		return errors.New("Error in creating file: " + err.Error())
 	}
+	defer f.Close()
 
-	_, err = f.WriteString(membersKubeConfig)
+	_, err = f.WriteString(kubeConfigData)
 	if err != nil {
 		return errors.New("Error in writing to config file: " + err.Error())
 	}
@@ -506,6 +569,10 @@ func parseControllerDetails(gc *gslbalphav1.GSLBConfig) error {
 }
 
 func GetUUIDFromGSLBConfig(gcObj *gslbalphav1.GSLBConfig) error {
+	if !gslbutils.IsReplicaElected() {
+		// only the elected replica generates the UUID, else the replicas could set different ones
+		gcObj = waitForAmkoUuid(gcObj)
+	}
 	annotation := gcObj.GetAnnotations()
 	// if a valid UUID is present in the GSLBConfig object, we set it for the current AMKO instance
 	if v, ok := annotation[gslbutils.AmkoUuid]; ok {
@@ -529,6 +596,27 @@ func GetUUIDFromGSLBConfig(gcObj *gslbalphav1.GSLBConfig) error {
 	return nil
 }
 
+// waitForAmkoUuid waits till the UUID annotation is set on the GSLBConfig object, or this replica is
+// elected, and returns the latest GSLBConfig object.
+func waitForAmkoUuid(gcObj *gslbalphav1.GSLBConfig) *gslbalphav1.GSLBConfig {
+	for {
+		if _, ok := gcObj.GetAnnotations()[gslbutils.AmkoUuid]; ok || gslbutils.IsReplicaElected() {
+			return gcObj
+		}
+		gslbutils.Logf("ns: %s, gslbConfig: %s, msg: waiting for the elected AMKO replica to set the annotation %s",
+			gcObj.Namespace, gcObj.Name, gslbutils.AmkoUuid)
+		time.Sleep(gslbutils.LeaderElectionRetryPeriod)
+		gc, err := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBConfigs(gcObj.Namespace).Get(context.TODO(),
+			gcObj.Name, metav1.GetOptions{})
+		if err != nil {
+			gslbutils.Errf("ns: %s, gslbConfig: %s, msg: error in fetching the GSLBConfig object: %v", gcObj.Namespace,
+				gcObj.Name, err)
+			continue
+		}
+		gcObj = gc
+	}
+}
+
 // AddGSLBConfigObject parses the gslb config object and starts informers
 // for the member clusters.
 func AddGSLBConfigObject(obj interface{}, initializeGSLBMemberClusters InitializeGSLBMemberClustersFn) error {
@@ -566,6 +654,11 @@ func AddGSLBConfigObject(obj interface{}, initializeGSLBMemberClusters Initializ
 
 	utils.AviLog.SetLevel(gc.Spec.LogLevel)
 	gslbutils.SetCustomFqdnMode(gc.Spec.UseCustomGlobalFqdn)
+	SetStaleObjectGracePeriod(gc.Spec.StaleObjectGracePeriod)
+	avirest.SetDeletionProtection(gc.Spec.DeletionProtection)
+	avirest.SetGSAdoption(gc.Spec.AdoptExistingGslbServices)
+	avirest.SetOrphanCleanup(gc.Spec.OrphanCleanup)
+	gslbutils.SetMemberClusterSettings(gc.Spec.MemberClusters)
 
 	gslbutils.Debugf("ns: %s, gslbConfig: %s, msg: %s", gc.ObjectMeta.Namespace, gc.ObjectMeta.Name,
 		"got an add event")
@@ -634,7 +727,7 @@ func AddGSLBConfigObject(obj interface{}, initializeGSLBMemberClusters Initializ
 	// Start informers WITHOUT event handlers
 	gslbutils.Logf("starting informers for all member clusters without event handlers")
 	for _, aviCtrl := range aviCtrlList {
-		aviCtrl.Start(stopCh)
+		aviCtrl.Start(getMemberClusterStopCh(aviCtrl.GetName()))
 		gslbutils.Logf("cluster: %s, msg: informers started and caches synced", aviCtrl.GetName())
 	}
 
@@ -654,6 +747,7 @@ func AddGSLBConfigObject(obj interface{}, initializeGSLBMemberClusters Initializ
 
 	// Perform initial full sync while informers are running (but no event handlers yet)
 	gslbutils.Logf("performing initial boot-up sync with active informers")
+	electedAtBootup := gslbutils.IsReplicaElected()
 	bootupSync(aviCtrlList, newCache)
 	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.GSLBConfigValidation, "Initial bootup sync completed.")
 	gslbutils.UpdateGSLBConfigStatus(BootupSyncEndMsg)
@@ -673,9 +767,14 @@ func AddGSLBConfigObject(obj interface{}, initializeGSLBMemberClusters Initializ
 	// Initialize a periodic worker to sync member clusters which failed to connect during initial bootup
 	// To Do: make this customisable through a field in gslb config
 	resyncMemberWorker := gslbutils.NewFullSyncThread(time.Duration(gslbutils.DefaultClusterConnectInterval))
-	resyncMemberWorker.SyncFunction = resyncMemberCluster
+	resyncMemberWorker.SyncFunction = syncMemberClusters
 	go resyncMemberWorker.Run()
 
+	// Initialize a periodic worker to track the connectivity to the member clusters
+	clusterHealthWorker := gslbutils.NewFullSyncThread(time.Duration(gslbutils.DefaultClusterHealthCheckInterval))
+	clusterHealthWorker.SyncFunction = CheckMemberClusterHealth
+	go clusterHealthWorker.Run()
+
 	gcChan := gslbutils.GetGSLBConfigObjectChan()
 	*gcChan <- true
 
@@ -683,6 +782,9 @@ func AddGSLBConfigObject(obj interface{}, initializeGSLBMemberClusters Initializ
 	gslbutils.SetGSLBConfig(true)
 	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.GSLBConfigValidation, "GSLB Configuration validated and accepted.")
 	gslbutils.UpdateGSLBConfigStatus(AcceptedMsg)
+	if gslbutils.IsLeaderElectionEnabled() {
+		setBootupDone(electedAtBootup)
+	}
 
 	// Set the workers for the node/graph layer
 	// During test mode, the graph layer workers are already initialized
@@ -697,7 +799,7 @@ var graphOnce sync.Once
 func StartGraphLayerWorkers() {
 	graphOnce.Do(func() {
 		ingestionSharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
-		ingestionSharedQueue.SyncFunc = nodes.SyncFromIngestionLayer
+		ingestionSharedQueue.SyncFunc = gslbutils.InstrumentSyncFunc(utils.ObjectIngestionLayer, nodes.SyncFromIngestionLayer)
 		ingestionSharedQueue.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGIngestion))
 	})
 }
@@ -793,17 +895,26 @@ func Initialize() {
 
 	// Set workers for layer 3 (REST layer)
 	graphSharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
-	graphSharedQueue.SyncFunc = avirest.SyncFromNodesLayer
+	graphSharedQueue.SyncFunc = gslbutils.InstrumentSyncFunc(utils.GraphLayer, avirest.SyncFromNodesLayer)
 	graphSharedQueue.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGGraph))
 
 	// Set up retry Queue
 	slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
-	slowRetryQueue.SyncFunc = aviretry.SyncFromRetryLayer
+	slowRetryQueue.SyncFunc = gslbutils.InstrumentSyncFunc(gslbutils.SlowRetryQueue, aviretry.SyncFromRetryLayer)
 	slowRetryQueue.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGSlowRetry))
 	fastRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.FastRetryQueue)
-	fastRetryQueue.SyncFunc = aviretry.SyncFromRetryLayer
+	fastRetryQueue.SyncFunc = gslbutils.InstrumentSyncFunc(gslbutils.FastRetryQueue, aviretry.SyncFromRetryLayer)
 	fastRetryQueue.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGFastRetry))
 
+	gslbutils.RegisterWorkQueueMetrics(utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer),
+		graphSharedQueue, slowRetryQueue, fastRetryQueue)
+
+	// the replicas have to elect one of them before the GSLBConfig object is processed, as only the
+	// elected one sets the AMKO UUID
+	if gslbutils.IsLeaderElectionEnabled() {
+		StartLeaderElection(kubeClient, stopCh)
+	}
+
 	gslbInformerFactory := gslbinformers.NewSharedInformerFactory(gslbClient, time.Second*30)
 
 	gslbController := GetNewController(kubeClient, gslbClient, gslbInformerFactory,
@@ -827,6 +938,10 @@ func Initialize() {
 	gcChan := gslbutils.GetGSLBConfigObjectChan()
 	<-*gcChan
 
+	if gslbutils.IsWebhookEnabled() {
+		StartWebhookServer(stopCh)
+	}
+
 	gdpInformerFactory := gdpinformers.NewSharedInformerFactory(gdpClient, time.Second*30)
 	gdpCtrl := InitializeGDPController(kubeClient, gdpClient, gdpInformerFactory, AddGDPObj,
 		UpdateGDPObj, DeleteGDPObj)
@@ -842,6 +957,12 @@ func Initialize() {
 	gslbutils.SetAMKOCrdInformers(&gslbutils.AMKOCrdInformers{GslbHostruleInformer: gslbhrInformer})
 	go gslbhrInformer.Informer().Run(stopCh)
 
+	gtsCtrl := InitializeGSLBTrafficShiftController(kubeClient, gslbClient, gslbInformerFactory,
+		AddGSLBTrafficShiftObj, UpdateGSLBTrafficShiftObj, DeleteGSLBTrafficShiftObj)
+	gtsInformer := gslbInformerFactory.Amko().V1alpha1().GSLBTrafficShifts()
+	go gtsInformer.Informer().Run(stopCh)
+	go gtsCtrl.Run(stopCh)
+
 	go RunControllers(gslbController, gdpCtrl, gslbhrCtrl, stopCh)
 	<-stopCh
 	gslbutils.WaitForWorkersToExit()
@@ -960,19 +1081,118 @@ func InitializeMemberCluster(cfg *restclient.Config, cluster KubeClusterDetails,
 		return nil, fmt.Errorf("HostRule API not available for cluster: %v", err)
 	}
 	gslbutils.SetInformersPerCluster(cluster.clusterName, informerInstance)
+	setClusterRegionFromNodes(kubeClient, cluster.clusterName)
 	aviCtrl.hrClientSet = betacrdClient
 	aviCtrl.hrAlphaClientSet = aplhaCrdClient
+	if gslbutils.IsGatewayAPIEnabled() {
+		dynamicClient, err := dynamic.NewForConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("error in creating dynamic clientset: %v", err)
+		}
+		// a cluster without the gateway API CRDs can still sync the other objects
+		aviCtrl.gatewayAPIInformers, err = NewGatewayAPIInformers(dynamicClient, cluster.clusterName)
+		if err != nil {
+			gslbutils.Warnf("cluster: %s, msg: gateway API objects won't be synced: %v", cluster.clusterName, err)
+		}
+	}
 	// NOTE: Event handlers are NOT set up here - they will be set up after boot-up sync
 	// This follows AKO's pattern of separating informer startup from event handler registration
 	return &aviCtrl, nil
 }
 
+// setClusterRegionFromNodes derives the region of a member cluster from the region label of its nodes,
+// if opted in for the cluster. The region is used as the location of the GS members synced as VIPs only
+// from this cluster, if none is set in the GDP objects. The region isn't set if the nodes can't be listed
+// or are spread across regions.
+func setClusterRegionFromNodes(kubeClient kubernetes.Interface, cname string) {
+	if !gslbutils.IsNodeRegionEnabled(cname) {
+		gslbutils.SetClusterRegion(cname, "")
+		return
+	}
+	region, err := getClusterRegionFromNodes(kubeClient, cname)
+	if err != nil {
+		gslbutils.Warnf("cluster: %s, msg: couldn't list the nodes to derive the region of the cluster: %v", cname, err)
+	}
+	if region != "" {
+		gslbutils.Logf("cluster: %s, region: %s, msg: derived the region of the cluster from its nodes", cname, region)
+	}
+	gslbutils.SetClusterRegion(cname, region)
+}
+
+// getClusterRegionFromNodes returns the region of the nodes of a member cluster, empty if none of the
+// nodes has the region label or the nodes are spread across regions.
+func getClusterRegionFromNodes(kubeClient kubernetes.Interface, cname string) (string, error) {
+	nodeList, err := kubeClient.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	region := ""
+	for _, node := range nodeList.Items {
+		nodeRegion := node.Labels[gslbutils.NodeRegionLabel]
+		if nodeRegion == "" {
+			continue
+		}
+		if region != "" && nodeRegion != region {
+			gslbutils.Warnf("cluster: %s, regions: %s, %s, msg: nodes are in multiple regions, region of the cluster won't be set",
+				cname, region, nodeRegion)
+			return "", nil
+		}
+		region = nodeRegion
+	}
+	return region, nil
+}
+
+// RefreshClusterRegions derives the regions of the connected member clusters from their nodes again, as
+// the nodes can be relabelled or replaced after AMKO connects to a cluster, and the region can be opted
+// in or out for a cluster. The objects of the clusters whose region changed are synced again, to update
+// the location of their GS members. The region of a cluster is kept if its nodes can't be listed.
+func RefreshClusterRegions() {
+	pending := make(map[string]struct{})
+	for _, cluster := range getPendingClusters() {
+		pending[cluster.clusterName] = struct{}{}
+	}
+	clustersToBeSynced := []string{}
+	for _, cname := range gslbutils.GetClusterContexts() {
+		if _, ok := pending[cname]; ok {
+			// the region is derived once the cluster is initialized
+			continue
+		}
+		informers := gslbutils.GetInformersPerCluster(cname)
+		if informers == nil {
+			continue
+		}
+		region := ""
+		if gslbutils.IsNodeRegionEnabled(cname) {
+			var err error
+			region, err = getClusterRegionFromNodes(informers.ClientSet, cname)
+			if err != nil {
+				gslbutils.Warnf("cluster: %s, msg: couldn't list the nodes to refresh the region of the cluster: %v", cname, err)
+				continue
+			}
+		}
+		if region == gslbutils.GetClusterRegion(cname) {
+			continue
+		}
+		gslbutils.Logf("cluster: %s, oldRegion: %s, region: %s, msg: region of the cluster changed",
+			cname, gslbutils.GetClusterRegion(cname), region)
+		gslbutils.SetClusterRegion(cname, region)
+		clustersToBeSynced = append(clustersToBeSynced, cname)
+	}
+	if len(clustersToBeSynced) == 0 {
+		return
+	}
+	ingestionQ := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	WriteChangedObjsToQueue(ingestionQ.Workqueue, ingestionQ.NumWorkers, false, clustersToBeSynced)
+}
+
 // InitializeGSLBClusters initializes the GSLB member clusters
 func InitializeGSLBMemberClusters(membersKubeConfig string, memberClusters []gslbalphav1.MemberCluster) ([]*GSLBMemberController, error) {
 	clusterDetails := loadClusterAccess(membersKubeConfig, memberClusters)
 	clients := make(map[string]*kubernetes.Clientset)
 
 	aviCtrlList := make([]*GSLBMemberController, 0)
+	pendingClustersLock.Lock()
+	defer pendingClustersLock.Unlock()
 	pendingClusters = make(map[KubeClusterDetails]struct{})
 	for _, cluster := range clusterDetails {
 		gslbutils.Logf("cluster: %s, msg: %s", cluster.clusterName, "initializing")
@@ -1022,7 +1242,9 @@ func isHealthMonitorTemplatePresentInCache(hmName, tenant string) bool {
 // Checks whether the template is present in the controller. If present, it adds the contents of the template to the cache which will
 // used for the creation of health monitors. If the template is not present in the controller, the GDP/GSLBHostRule
 // will be rejected.
-func validateAndAddHmTemplateToCache(hmTemplate string, gdp bool, fullSync bool, namespace string) error {
+// validateHmTemplate checks that a health monitor template is present on the controller and can be
+// used by AMKO, and adds it to the hm cache if addToCache is true.
+func validateHmTemplate(hmTemplate string, gdp, fullSync, addToCache bool, namespace string) error {
 	tenant := gslbutils.GetTenantInNamespace(namespace, gslbutils.LeaderClusterContext)
 	if fullSync && isHealthMonitorTemplatePresentInCache(hmTemplate, tenant) {
 		gslbutils.Debugf("health monitor template %s present in hm cache", hmTemplate)
@@ -1055,6 +1277,9 @@ func validateAndAddHmTemplateToCache(hmTemplate string, gdp bool, fullSync bool,
 		return fmt.Errorf("client request header in health monitor template %s is invalid", hmTemplate)
 	}
 
+	if !addToCache {
+		return nil
+	}
 	key := avictrl.TenantName{Tenant: tenant, Name: hmTemplate}
 	hmCacheObj := avictrl.AviHmObj{
 		Name:   hmTemplate,
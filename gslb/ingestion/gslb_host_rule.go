@@ -19,11 +19,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/vmware/alb-sdk/go/models"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
 
 	avictrl "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
 	gslbhralphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
@@ -34,6 +36,7 @@ import (
 
 	"github.com/openshift/client-go/route/clientset/versioned/scheme"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -67,6 +70,9 @@ func (gslbHostRuleController *GSLBHostRuleController) Run(stopCh <-chan struct{}
 }
 
 func updateGSLBHR(gslbhr *gslbhralphav1.GSLBHostRule, msg string, status string) {
+	if !gslbutils.IsReplicaElected() {
+		return
+	}
 	gslbhr.Status.Error = msg
 	gslbhr.Status.Status = status
 	obj, updateErr := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBHostRules(gslbhr.ObjectMeta.Namespace).Update(context.TODO(), gslbhr, metav1.UpdateOptions{})
@@ -88,7 +94,7 @@ func isPKIRefPresentInCache(spName, tenant string) bool {
 	return present
 }
 
-func isSitePersistenceProfilePresent(profileName string, gdp bool, fullSync bool, namespace string) bool {
+func isSitePersistenceProfilePresent(profileName string, gdp, fullSync, addToCache bool, namespace string) bool {
 	tenant := gslbutils.GetTenantInNamespace(namespace, gslbutils.LeaderClusterContext)
 	if fullSync && isSitePersistenceRefPresentInCache(profileName, tenant) {
 		gslbutils.Debugf("site persistence ref %s present in site persistence cache", profileName)
@@ -125,6 +131,9 @@ func isSitePersistenceProfilePresent(profileName string, gdp bool, fullSync bool
 		gslbutils.Errf("incomplete site persistence ref unmarshalled %s", utils.Stringify(sp))
 		return false
 	}
+	if !addToCache {
+		return true
+	}
 	k := avictrl.TenantName{Tenant: tenant, Name: *sp.Name}
 	spCache := avictrl.GetAviSpCache()
 	spCache.AviSpCacheAdd(k, &sp)
@@ -133,7 +142,7 @@ func isSitePersistenceProfilePresent(profileName string, gdp bool, fullSync bool
 	return true
 }
 
-func isPKIProfilePresent(profileName string, gdp bool, fullSync bool, namespace string) bool {
+func isPKIProfilePresent(profileName string, gdp, fullSync, addToCache bool, namespace string) bool {
 	tenant := gslbutils.GetTenantInNamespace(namespace, gslbutils.LeaderClusterContext)
 	if fullSync && isPKIRefPresentInCache(profileName, tenant) {
 		gslbutils.Debugf("pki %s present in pkiProfile cache", profileName)
@@ -171,6 +180,9 @@ func isPKIProfilePresent(profileName string, gdp bool, fullSync bool, namespace
 		gslbutils.Errf("incomplete pki profile ref unmarshalled %s", utils.Stringify(sp))
 		return false
 	}
+	if !addToCache {
+		return true
+	}
 	k := avictrl.TenantName{Tenant: tenant, Name: *sp.Name}
 	spCache := avictrl.GetAviPkiCache()
 	spCache.AviPkiCacheAdd(k, &sp)
@@ -318,7 +330,133 @@ func isGSLBDownResponseValid(responseType string, fallbackIP string) error {
 	return nil
 }
 
+func isIPFamilyValid(ipFamily *string) error {
+	if ipFamily == nil {
+		return nil
+	}
+	switch *ipFamily {
+	case gslbhralphav1.IPFamilyV4, gslbhralphav1.IPFamilyV6, gslbhralphav1.IPFamilyDualStack:
+		return nil
+	}
+	return fmt.Errorf("ip family %s is invalid, must be one of %s, %s or %s", *ipFamily,
+		gslbhralphav1.IPFamilyV4, gslbhralphav1.IPFamilyV6, gslbhralphav1.IPFamilyDualStack)
+}
+
+// isGSSettingsValid validates the GslbService properties which are common to the GDP and the
+// GSLBHostRule objects.
+func isGSSettingsValid(hmScope *string, minMembers, minHealthMonitorsUp *int) error {
+	if hmScope != nil && *hmScope != gslbhralphav1.HealthMonitorScopeAllMembers &&
+		*hmScope != gslbhralphav1.HealthMonitorScopeOnlyNonControllerMembers {
+		return fmt.Errorf("health monitor scope %s is invalid, must be one of %s or %s", *hmScope,
+			gslbhralphav1.HealthMonitorScopeAllMembers, gslbhralphav1.HealthMonitorScopeOnlyNonControllerMembers)
+	}
+	if minMembers != nil && *minMembers < 0 {
+		return fmt.Errorf("min members %d is invalid, must be 0 or more", *minMembers)
+	}
+	if minHealthMonitorsUp != nil && *minHealthMonitorsUp < 1 {
+		return fmt.Errorf("min health monitors up %d is invalid, must be 1 or more", *minHealthMonitorsUp)
+	}
+	return nil
+}
+
+// isGeoLocationValid checks that the latitude and the longitude of a location are set together and are
+// within their ranges.
+func isGeoLocationValid(loc *gslbhralphav1.GeoLocation) error {
+	if loc == nil {
+		return nil
+	}
+	if (loc.Latitude == nil) != (loc.Longitude == nil) {
+		return fmt.Errorf("latitude and longitude must be set together")
+	}
+	if loc.Latitude != nil && (*loc.Latitude < -90 || *loc.Latitude > 90) {
+		return fmt.Errorf("latitude %v is invalid, must be between -90 and 90", *loc.Latitude)
+	}
+	if loc.Longitude != nil && (*loc.Longitude < -180 || *loc.Longitude > 180) {
+		return fmt.Errorf("longitude %v is invalid, must be between -180 and 180", *loc.Longitude)
+	}
+	if loc.Name == "" && loc.Region == "" && loc.Latitude == nil {
+		return fmt.Errorf("one of name, region or latitude and longitude must be set")
+	}
+	return nil
+}
+
+// isMemberObjPresent checks whether a member object is present in the accepted or the rejected store
+// for its type.
+func isMemberObjPresent(cname, ns, objType, name string) bool {
+	_, acceptedStore, rejectedStore, err := GetObjTypeStores(objType)
+	if err != nil {
+		return false
+	}
+	// the ingress, multi-cluster ingress and HTTPRoute objects are stored as "name/hostname"
+	objKey := cname + "/" + ns + "/" + name
+	for _, objStore := range []*store.ClusterStore{acceptedStore, rejectedStore} {
+		for _, objName := range objStore.GetAllClusterNSObjects() {
+			if objName == objKey || strings.HasPrefix(objName, objKey+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isMemberTrafficSplitValid(memberSplit gslbhralphav1.MemberTrafficSplitElem, checkMembers bool) error {
+	if !gslbutils.IsClusterContextPresent(memberSplit.Cluster) {
+		return fmt.Errorf("cluster %s in member traffic split not present in GSLBConfig", memberSplit.Cluster)
+	}
+	objType, err := gslbutils.GetObjTypeForMemberKind(memberSplit.Kind)
+	if err != nil {
+		return err
+	}
+	if memberSplit.Weight < 1 || memberSplit.Weight > 20 {
+		return fmt.Errorf("traffic weight %d for member %s/%s/%s must be between 1 and 20", memberSplit.Weight,
+			memberSplit.Cluster, memberSplit.Namespace, memberSplit.Name)
+	}
+	if memberSplit.Priority < 1 || memberSplit.Priority > 100 {
+		return fmt.Errorf("priority %d for member %s/%s/%s must be between 1 and 100", memberSplit.Priority,
+			memberSplit.Cluster, memberSplit.Namespace, memberSplit.Name)
+	}
+	if checkMembers && !isMemberObjPresent(memberSplit.Cluster, memberSplit.Namespace, objType, memberSplit.Name) {
+		return fmt.Errorf("%s %s/%s in member traffic split not present in cluster %s", memberSplit.Kind,
+			memberSplit.Namespace, memberSplit.Name, memberSplit.Cluster)
+	}
+	return nil
+}
+
+// RevalidateGSLBHostRulesForMember re-validates the rejected GSLBHostRules which refer to a member
+// object in their memberTrafficSplit. A GSLBHostRule created before its member objects is rejected,
+// so, it has to be accepted again once these objects are added.
+func RevalidateGSLBHostRulesForMember(cname, ns, objType, name string, k8swq []workqueue.RateLimitingInterface,
+	numWorkers uint32) {
+	amkoInformers := gslbutils.GetAMKOCRDInformer()
+	if amkoInformers == nil || amkoInformers.GslbHostruleInformer == nil {
+		return
+	}
+	gslbhrList, err := amkoInformers.GslbHostruleInformer.Lister().List(labels.Everything())
+	if err != nil {
+		gslbutils.Errf("cluster: %s, ns: %s, objType: %s, name: %s, msg: can't list the GSLBHostRules: %v",
+			cname, ns, objType, name, err)
+		return
+	}
+	for _, gslbhr := range gslbhrList {
+		if gslbhr.Status.Status != GslbHostRuleRejected ||
+			gslbutils.GetMemberTrafficSplit(gslbhr.Spec.MemberTrafficSplit, cname, ns, objType, name) == nil {
+			continue
+		}
+		gslbutils.Logf("ns: %s, gslbhostrule: %s, cluster: %s, objType: %s, name: %s, msg: member object added, re-validating the rejected GSLBHostRule",
+			gslbhr.Namespace, gslbhr.Name, cname, objType, name)
+		AddGSLBHostRuleObj(gslbhr.DeepCopy(), k8swq, numWorkers)
+	}
+}
+
 func ValidateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync bool) error {
+	// the member objects are synced only after the GSLBHostRules during a full sync, so their presence
+	// can't be verified yet
+	return validateGSLBHostRule(gslbhr, fullSync, !fullSync, true)
+}
+
+// validateGSLBHostRule validates a GSLBHostRule object, the refs fetched from the controller are added
+// to the caches only if addToCache is true.
+func validateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync, checkMembers, addToCache bool) error {
 	gslbhrName := gslbhr.ObjectMeta.Name
 	gslbhrSpec := gslbhr.Spec
 	var errmsg string
@@ -337,12 +475,12 @@ func ValidateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync bool) err
 	sitePersistence := gslbhrSpec.SitePersistence
 	if sitePersistence != nil {
 		sitePersistenceProfileName := sitePersistence.ProfileRef
-		if sitePersistence.Enabled && !isSitePersistenceProfilePresent(sitePersistenceProfileName, false, fullSync, gslbhr.Namespace) {
+		if sitePersistence.Enabled && !isSitePersistenceProfilePresent(sitePersistenceProfileName, false, fullSync, addToCache, gslbhr.Namespace) {
 			errmsg = "SitePersistence Profile " + sitePersistenceProfileName + " error for " + gslbhrName + " GSLBHostRule"
 			return fmt.Errorf("%s", errmsg)
 		}
 		if sitePersistence.PKIProfileRef != nil {
-			if !isPKIProfilePresent(*sitePersistence.PKIProfileRef, false, fullSync, gslbhr.Namespace) {
+			if !isPKIProfilePresent(*sitePersistence.PKIProfileRef, false, fullSync, addToCache, gslbhr.Namespace) {
 				errmsg = "PKI Profile " + *sitePersistence.PKIProfileRef + " error for " + gslbhrName + " GSLBHostRule"
 				return fmt.Errorf("%s", errmsg)
 			}
@@ -351,7 +489,6 @@ func ValidateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync bool) err
 
 	if ok, err := isGslbPoolAlgorithmValid(gslbhrSpec.PoolAlgorithmSettings); !ok {
 		errmsg := "Invalid Pool Algorithm: " + err.Error()
-		updateGSLBHR(gslbhr, errmsg, GslbHostRuleRejected)
 		return fmt.Errorf("%s", errmsg)
 	}
 
@@ -365,6 +502,15 @@ func ValidateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync bool) err
 			errmsg = "ThirdPartyMember site " + tpmember.Site + " does not exist for " + gslbhrName + " GSLBHostRule"
 			return fmt.Errorf("%s", errmsg)
 		}
+		if err := isGeoLocationValid(tpmember.Location); err != nil {
+			return fmt.Errorf("invalid location of thirdPartyMember site %s for %s GSLBHostRule: %v", tpmember.Site, gslbhrName, err)
+		}
+	}
+	// MemberTrafficSplit checks
+	for _, memberSplit := range gslbhrSpec.MemberTrafficSplit {
+		if err := isMemberTrafficSplitValid(memberSplit, checkMembers); err != nil {
+			return fmt.Errorf("%s for %s GSLBHostRule", err.Error(), gslbhrName)
+		}
 	}
 	// PublicIP checks
 	for _, ip := range gslbhrSpec.PublicIP {
@@ -384,7 +530,7 @@ func ValidateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync bool) err
 	}
 
 	if gslbhrSpec.HealthMonitorTemplate != nil {
-		if err := validateAndAddHmTemplateToCache(*gslbhrSpec.HealthMonitorTemplate, false, fullSync, gslbhr.Namespace); err != nil {
+		if err := validateHmTemplate(*gslbhrSpec.HealthMonitorTemplate, false, fullSync, addToCache, gslbhr.Namespace); err != nil {
 			return err
 		}
 	} else {
@@ -405,6 +551,20 @@ func ValidateGSLBHostRule(gslbhr *gslbhralphav1.GSLBHostRule, fullSync bool) err
 		}
 	}
 
+	if err := isIPFamilyValid(gslbhrSpec.IPFamily); err != nil {
+		return fmt.Errorf("%s for %s GSLBHostRule", err.Error(), gslbhrName)
+	}
+
+	if err := isGSSettingsValid(gslbhrSpec.HealthMonitorScope, gslbhrSpec.MinMembers,
+		gslbhrSpec.MinHealthMonitorsUp); err != nil {
+		return fmt.Errorf("%s for %s GSLBHostRule", err.Error(), gslbhrName)
+	}
+
+	if gslbutils.IsWildcardFqdn(gslbhrSpec.Fqdn) && gslbhrSpec.WildcardMatch != nil && !*gslbhrSpec.WildcardMatch {
+		return fmt.Errorf("wildcard match can't be disabled for the wildcard fqdn %s in %s GSLBHostRule",
+			gslbhrSpec.Fqdn, gslbhrName)
+	}
+
 	return nil
 }
 
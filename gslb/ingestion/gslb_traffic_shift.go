@@ -0,0 +1,572 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	gslbcs "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned"
+	gslbinformers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/informers/externalversions"
+	gslbListers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/listers/amko/v1alpha1"
+)
+
+const (
+	// TrafficShiftSyncInterval is the interval at which the GSLBTrafficShift objects in progress are
+	// checked for their next step
+	TrafficShiftSyncInterval = 10 * time.Second
+	// MinTrafficShiftStepInterval is the minimum time in seconds between two steps
+	MinTrafficShiftStepInterval = 30
+
+	maxTrafficShiftWeight = 20
+	memberOperUp          = "OPER_UP"
+)
+
+type AddDelGSLBTrafficShiftfn func(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32)
+
+type UpdateGSLBTrafficShiftfn func(old, new interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32)
+
+type GSLBTrafficShiftController struct {
+	kubeclientset kubernetes.Interface
+	gslbclientset gslbcs.Interface
+	gtsLister     gslbListers.GSLBTrafficShiftLister
+	gtsSynced     cache.InformerSynced
+}
+
+func (gtsController *GSLBTrafficShiftController) Run(stopCh <-chan struct{}) error {
+	defer runtime.HandleCrash()
+	gslbutils.Logf("object: GSLBTrafficShiftController, msg: %s", "starting the workers")
+	ticker := time.NewTicker(TrafficShiftSyncInterval)
+	defer ticker.Stop()
+	k8sQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	for {
+		select {
+		case <-ticker.C:
+			SyncTrafficShifts(gtsController.gtsLister, k8sQueue.Workqueue, k8sQueue.NumWorkers)
+		case <-stopCh:
+			gslbutils.Logf("object: GSLBTrafficShiftController, msg: %s", "shutting down the workers")
+			return nil
+		}
+	}
+}
+
+// trafficShiftStore keeps track of the fqdns and the GDP objects whose traffic is shifted by each
+// GSLBTrafficShift object, an fqdn or a GDP object can only be shifted by one of them.
+type trafficShiftStore struct {
+	lock sync.Mutex
+	// scopes has the GSLBTrafficShift object for each fqdn or GDP object in the spec
+	scopes map[string]string
+	// fqdnOwners has the GSLBTrafficShift object which sets the traffic split of each fqdn
+	fqdnOwners map[string]string
+	// fqdns has the fqdns for which each GSLBTrafficShift object sets the traffic split
+	fqdns map[string][]string
+}
+
+var trafficShifts = &trafficShiftStore{
+	scopes:     make(map[string]string),
+	fqdnOwners: make(map[string]string),
+	fqdns:      make(map[string][]string),
+}
+
+func getTrafficShiftKey(ts *gslbalphav1.GSLBTrafficShift) string {
+	return ts.Namespace + "/" + ts.Name
+}
+
+func getTrafficShiftScope(ts *gslbalphav1.GSLBTrafficShift) string {
+	if ts.Spec.Fqdn != "" {
+		return "fqdn/" + ts.Spec.Fqdn
+	}
+	return "gdp/" + gslbutils.GetGDPFilterKey(ts.Namespace, ts.Spec.GDP)
+}
+
+func (s *trafficShiftStore) checkScope(key, scope string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if owner, ok := s.scopes[scope]; ok && owner != key {
+		return fmt.Errorf("the traffic of %s is already shifted by GSLBTrafficShift %s", scope, owner)
+	}
+	return nil
+}
+
+func (s *trafficShiftStore) getFqdns(key string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fqdns := make([]string, len(s.fqdns[key]))
+	copy(fqdns, s.fqdns[key])
+	return fqdns
+}
+
+// set sets the traffic split built by getSplit for the fqdns of a GSLBTrafficShift object, and removes
+// it for the fqdns which it doesn't shift anymore. Returns the fqdns whose traffic split changed.
+func (s *trafficShiftStore) set(key, scope string, fqdns []string,
+	getSplit func(fqdn string) []gslbalphav1.TrafficSplitElem) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	gsHostRulesList := gslbutils.GetGSHostRulesList()
+	changed := []string{}
+	shifted := []string{}
+	s.scopes[scope] = key
+	for _, fqdn := range fqdns {
+		if owner, ok := s.fqdnOwners[fqdn]; ok && owner != key {
+			gslbutils.Warnf("gslbTrafficShift: %s, gsFqdn: %s, msg: traffic is already shifted by GSLBTrafficShift %s, ignoring",
+				key, fqdn, owner)
+			continue
+		}
+		s.fqdnOwners[fqdn] = key
+		shifted = append(shifted, fqdn)
+		if gsHostRulesList.SetTrafficShiftForFQDN(fqdn, getSplit(fqdn)) {
+			changed = append(changed, fqdn)
+		}
+	}
+	for _, fqdn := range s.fqdns[key] {
+		if gslbutils.PresentInList(fqdn, shifted) {
+			continue
+		}
+		delete(s.fqdnOwners, fqdn)
+		if gsHostRulesList.DeleteTrafficShiftForFQDN(fqdn) {
+			changed = append(changed, fqdn)
+		}
+	}
+	s.fqdns[key] = shifted
+	return changed
+}
+
+// remove removes the traffic split of a GSLBTrafficShift object for all its fqdns, returns the
+// fqdns whose traffic split changed.
+func (s *trafficShiftStore) remove(key string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for scope, owner := range s.scopes {
+		if owner == key {
+			delete(s.scopes, scope)
+		}
+	}
+	gsHostRulesList := gslbutils.GetGSHostRulesList()
+	changed := []string{}
+	for _, fqdn := range s.fqdns[key] {
+		delete(s.fqdnOwners, fqdn)
+		if gsHostRulesList.DeleteTrafficShiftForFQDN(fqdn) {
+			changed = append(changed, fqdn)
+		}
+	}
+	delete(s.fqdns, key)
+	return changed
+}
+
+// getTrafficShiftFqdns returns the fqdns of the GslbServices whose traffic is shifted, for a GDP
+// object these are the GslbServices owned by it.
+func getTrafficShiftFqdns(ts *gslbalphav1.GSLBTrafficShift) []string {
+	if ts.Spec.Fqdn != "" {
+		return []string{ts.Spec.Fqdn}
+	}
+	owned, _ := gslbutils.GetGSGDPMap().GetGDPGslbServices(gslbutils.GetGDPFilterKey(ts.Namespace, ts.Spec.GDP))
+	return owned
+}
+
+func getTrafficShiftStepInterval(ts *gslbalphav1.GSLBTrafficShift) time.Duration {
+	if ts.Spec.StepInterval != nil {
+		return time.Duration(*ts.Spec.StepInterval) * time.Second
+	}
+	return gslbutils.DefaultTrafficShiftStepInterval * time.Second
+}
+
+// getClusterPriority returns the priority of a cluster for a GslbService, the GSLBHostRule's priority
+// takes precedence over the GDP objects' priority.
+func getClusterPriority(fqdn, cname string) uint32 {
+	if ghr := gslbutils.GetGSHostRulesList().GetGSHostRulesForFQDN(fqdn); ghr != nil {
+		var gsRule gslbutils.GSHostRules
+		ghr.DeepCopyInto(&gsRule)
+		for _, ts := range gsRule.TrafficSplit {
+			if ts.Cluster == cname {
+				return ts.Priority
+			}
+		}
+	}
+	return nodes.GetObjTrafficPriority("", cname, gslbutils.GetGSGDPMap().GetGDPsForGS(fqdn)...)
+}
+
+// getTrafficShiftWeights returns the weights of the source and the target clusters, for which the
+// share of the target clusters is the closest to percentage.
+func getTrafficShiftWeights(numSources, numTargets int, percentage uint32) (uint32, uint32) {
+	sourceWeight, targetWeight := uint32(1), uint32(1)
+	if percentage >= 100 {
+		return sourceWeight, targetWeight
+	}
+	minDiff := math.MaxFloat64
+	for sw := uint32(1); sw <= maxTrafficShiftWeight; sw++ {
+		for tw := uint32(1); tw <= maxTrafficShiftWeight; tw++ {
+			targetShare := float64(tw) * float64(numTargets)
+			share := 100 * targetShare / (targetShare + float64(sw)*float64(numSources))
+			if diff := math.Abs(share - float64(percentage)); diff < minDiff {
+				minDiff = diff
+				sourceWeight, targetWeight = sw, tw
+			}
+		}
+	}
+	return sourceWeight, targetWeight
+}
+
+// getTrafficShiftSplit returns the traffic split of the source and the target clusters of a GslbService
+// for the percentage of the traffic routed to the target clusters. All of them get the highest of their
+// priorities, so that the weights apply across them. At 100, the source clusters get a lower priority
+// and only serve as a backup for the target clusters.
+func getTrafficShiftSplit(fqdn string, spec *gslbalphav1.GSLBTrafficShiftSpec, percentage uint32) []gslbalphav1.TrafficSplitElem {
+	var priority uint32
+	for _, cname := range append(append([]string{}, spec.SourceClusters...), spec.TargetClusters...) {
+		if p := getClusterPriority(fqdn, cname); p > priority {
+			priority = p
+		}
+	}
+	sourcePriority, targetPriority := priority, priority
+	if percentage >= 100 {
+		if priority == 0 {
+			targetPriority = 1
+		} else {
+			sourcePriority = priority - 1
+		}
+	}
+	sourceWeight, targetWeight := getTrafficShiftWeights(len(spec.SourceClusters), len(spec.TargetClusters), percentage)
+
+	trafficSplit := []gslbalphav1.TrafficSplitElem{}
+	for _, cname := range spec.SourceClusters {
+		trafficSplit = append(trafficSplit, gslbalphav1.TrafficSplitElem{Cluster: cname, Weight: sourceWeight,
+			Priority: sourcePriority})
+	}
+	for _, cname := range spec.TargetClusters {
+		trafficSplit = append(trafficSplit, gslbalphav1.TrafficSplitElem{Cluster: cname, Weight: targetWeight,
+			Priority: targetPriority})
+	}
+	return trafficSplit
+}
+
+// applyTrafficShift sets the traffic split of the current step of a GSLBTrafficShift object for its
+// fqdns, returns the fqdns whose traffic split changed.
+func applyTrafficShift(ts *gslbalphav1.GSLBTrafficShift) []string {
+	step := ts.Status.CurrentStep
+	if step < 1 {
+		step = 1
+	} else if step > len(ts.Spec.Steps) {
+		step = len(ts.Spec.Steps)
+	}
+	percentage := ts.Spec.Steps[step-1]
+	return trafficShifts.set(getTrafficShiftKey(ts), getTrafficShiftScope(ts), getTrafficShiftFqdns(ts),
+		func(fqdn string) []gslbalphav1.TrafficSplitElem {
+			return getTrafficShiftSplit(fqdn, &ts.Spec, percentage)
+		})
+}
+
+func pushTrafficShiftKeys(ts *gslbalphav1.GSLBTrafficShift, fqdns []string, k8swq []workqueue.RateLimitingInterface,
+	numWorkers uint32) {
+	tenant := gslbutils.GetTenantInNamespace(ts.Namespace, gslbutils.LeaderClusterContext)
+	for _, fqdn := range fqdns {
+		bkt := utils.Bkt(fqdn, numWorkers)
+		key := gslbutils.GSFQDNKey(gslbutils.ObjectUpdate, gslbutils.GSFQDNType, fqdn, ts.Namespace, tenant)
+		k8swq[bkt].AddRateLimited(key)
+		gslbutils.Logf("ns: %s, gslbTrafficShift: %s, gsFqdn: %s, key: %s, msg: pushed UPDATE key",
+			ts.Namespace, ts.Name, fqdn, key)
+	}
+}
+
+func updateGSLBTrafficShiftStatus(ts *gslbalphav1.GSLBTrafficShift) {
+	if !gslbutils.IsReplicaElected() {
+		return
+	}
+	_, err := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBTrafficShifts(ts.Namespace).Update(context.TODO(),
+		ts, metav1.UpdateOptions{})
+	if err != nil {
+		gslbutils.Errf("ns: %s, gslbTrafficShift: %s, msg: error in updating the status: %v", ts.Namespace, ts.Name, err)
+	}
+}
+
+// ValidateGSLBTrafficShift validates the spec of a GSLBTrafficShift object.
+func ValidateGSLBTrafficShift(ts *gslbalphav1.GSLBTrafficShift) error {
+	spec := ts.Spec
+	if (spec.Fqdn == "") == (spec.GDP == "") {
+		return errors.New("exactly one of fqdn and gdp must be set")
+	}
+	if len(spec.SourceClusters) == 0 || len(spec.TargetClusters) == 0 {
+		return errors.New("sourceClusters and targetClusters must be set")
+	}
+	for _, cname := range append(append([]string{}, spec.SourceClusters...), spec.TargetClusters...) {
+		if !gslbutils.IsClusterContextPresent(cname) {
+			return fmt.Errorf("cluster %s not present in GSLBConfig", cname)
+		}
+	}
+	for _, cname := range spec.SourceClusters {
+		if gslbutils.PresentInList(cname, spec.TargetClusters) {
+			return fmt.Errorf("cluster %s can't be both a source and a target cluster", cname)
+		}
+	}
+	if len(spec.Steps) == 0 {
+		return errors.New("steps must be set")
+	}
+	for idx, step := range spec.Steps {
+		if step < 1 || step > 100 {
+			return fmt.Errorf("step %d must be between 1 and 100", step)
+		}
+		if idx > 0 && step <= spec.Steps[idx-1] {
+			return errors.New("steps must be in increasing order")
+		}
+	}
+	if spec.StepInterval != nil && *spec.StepInterval < MinTrafficShiftStepInterval {
+		return fmt.Errorf("stepInterval %d must be at least %d seconds", *spec.StepInterval, MinTrafficShiftStepInterval)
+	}
+	return trafficShifts.checkScope(getTrafficShiftKey(ts), getTrafficShiftScope(ts))
+}
+
+// syncTrafficShift sets the traffic split of a GSLBTrafficShift object as per its status. A new object
+// is started from the first step by the elected replica, the other replicas follow the status set by it.
+func syncTrafficShift(ts *gslbalphav1.GSLBTrafficShift, k8swq []workqueue.RateLimitingInterface, numWorkers uint32) {
+	key := getTrafficShiftKey(ts)
+	if err := ValidateGSLBTrafficShift(ts); err != nil {
+		gslbutils.Errf("gslbTrafficShift: %s, msg: error in accepting the GSLBTrafficShift: %v", key, err)
+		pushTrafficShiftKeys(ts, trafficShifts.remove(key), k8swq, numWorkers)
+		if ts.Status.Phase != gslbalphav1.TrafficShiftRejected || ts.Status.Message != err.Error() {
+			ts.Status = gslbalphav1.GSLBTrafficShiftStatus{Phase: gslbalphav1.TrafficShiftRejected, Message: err.Error()}
+			updateGSLBTrafficShiftStatus(ts)
+		}
+		return
+	}
+
+	switch ts.Status.Phase {
+	case "":
+		if !gslbutils.IsReplicaElected() {
+			return
+		}
+		now := metav1.Now()
+		ts.Status = gslbalphav1.GSLBTrafficShiftStatus{Phase: gslbalphav1.TrafficShiftProgressing, CurrentStep: 1,
+			LastStepTime: &now}
+		pushTrafficShiftKeys(ts, applyTrafficShift(ts), k8swq, numWorkers)
+		gslbutils.Logf("gslbTrafficShift: %s, msg: started the traffic shift, %d%% of the traffic is routed to the target clusters",
+			key, ts.Spec.Steps[0])
+		updateGSLBTrafficShiftStatus(ts)
+	case gslbalphav1.TrafficShiftProgressing, gslbalphav1.TrafficShiftCompleted:
+		pushTrafficShiftKeys(ts, applyTrafficShift(ts), k8swq, numWorkers)
+	default:
+		pushTrafficShiftKeys(ts, trafficShifts.remove(key), k8swq, numWorkers)
+	}
+}
+
+func AddGSLBTrafficShiftObj(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32) {
+	ts, ok := obj.(*gslbalphav1.GSLBTrafficShift)
+	if !ok {
+		gslbutils.Errf("object added is not of type GSLBTrafficShift")
+		return
+	}
+	gslbutils.Logf("ns: %s, gslbTrafficShift: %s, msg: GSLBTrafficShift object added", ts.Namespace, ts.Name)
+	syncTrafficShift(ts.DeepCopy(), k8swq, numWorkers)
+}
+
+func UpdateGSLBTrafficShiftObj(old, new interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32) {
+	oldTs := old.(*gslbalphav1.GSLBTrafficShift)
+	newTs := new.(*gslbalphav1.GSLBTrafficShift).DeepCopy()
+
+	// Return if there's no change in the object
+	if oldTs.ObjectMeta.ResourceVersion == newTs.ObjectMeta.ResourceVersion {
+		return
+	}
+	if !reflect.DeepEqual(oldTs.Spec, newTs.Spec) && newTs.Status.Phase != "" {
+		// the traffic shift starts again from the first step if the spec changes
+		gslbutils.Logf("ns: %s, gslbTrafficShift: %s, msg: spec changed, will restart the traffic shift",
+			newTs.Namespace, newTs.Name)
+		newTs.Status = gslbalphav1.GSLBTrafficShiftStatus{}
+	}
+	syncTrafficShift(newTs, k8swq, numWorkers)
+}
+
+func DeleteGSLBTrafficShiftObj(obj interface{}, k8swq []workqueue.RateLimitingInterface, numWorkers uint32) {
+	ts := obj.(*gslbalphav1.GSLBTrafficShift)
+	gslbutils.Logf("ns: %s, gslbTrafficShift: %s, msg: GSLBTrafficShift object deleted, will restore the traffic split",
+		ts.Namespace, ts.Name)
+	pushTrafficShiftKeys(ts, trafficShifts.remove(getTrafficShiftKey(ts)), k8swq, numWorkers)
+}
+
+// checkGSTargetMembers checks the health of the members of the target clusters in a GslbService, as
+// per the runtime of the GslbService on all the GSLB sites. It returns an error if the health can't be
+// determined yet, and the reason if a member isn't up.
+func checkGSTargetMembers(tenant, fqdn string, targetClusters []string) (string, error) {
+	found, gsIntf := nodes.SharedAviGSGraphLister().Get(tenant + "/" + fqdn)
+	if !found {
+		return "", fmt.Errorf("GslbService %s not found", fqdn)
+	}
+	gsGraph := gsIntf.(*nodes.AviGSObjectGraph).GetCopy()
+	targetIPs := []string{}
+	for _, member := range gsGraph.MemberObjs {
+		if gslbutils.PresentInList(member.Cluster, targetClusters) {
+			targetIPs = append(targetIPs, member.GetIPAddrsForFamily(gsGraph.IPFamily)...)
+		}
+	}
+	if len(targetIPs) == 0 {
+		return fmt.Sprintf("GslbService %s has no members in the target clusters", fqdn), nil
+	}
+
+	gsCache, found := avicache.GetAviCache().AviCacheGet(avicache.TenantName{Tenant: gsGraph.Tenant, Name: fqdn})
+	if !found {
+		return "", fmt.Errorf("GslbService %s not found in the cache", fqdn)
+	}
+	gsRuntime, err := avicache.GetGSRuntime(gsCache.(*avicache.AviGSCache).Uuid, gsGraph.Tenant)
+	if err != nil {
+		return "", err
+	}
+	memberFound := make(map[string]bool)
+	for _, siteRuntime := range gsRuntime {
+		for _, group := range siteRuntime.Groups {
+			for _, member := range group.Members {
+				if member.IP == nil || member.IP.Addr == nil || !gslbutils.PresentInList(*member.IP.Addr, targetIPs) {
+					continue
+				}
+				memberFound[*member.IP.Addr] = true
+				state := ""
+				if member.OperStatus != nil && member.OperStatus.State != nil {
+					state = *member.OperStatus.State
+				}
+				if state != memberOperUp {
+					siteName := ""
+					if member.SiteName != nil {
+						siteName = *member.SiteName
+					}
+					return fmt.Sprintf("member %s of GslbService %s is %s on site %s", *member.IP.Addr, fqdn, state,
+						siteName), nil
+				}
+			}
+		}
+	}
+	for _, ip := range targetIPs {
+		if !memberFound[ip] {
+			return "", fmt.Errorf("member %s of GslbService %s not found in its runtime", ip, fqdn)
+		}
+	}
+	return "", nil
+}
+
+// SyncTrafficShifts moves the GSLBTrafficShift objects in progress to their next step once their step
+// interval has passed, if the members of the target clusters are up. Otherwise, the traffic shift is
+// rolled back to the traffic split of the GDP objects and the GSLBHostRule. Only the elected replica
+// moves the steps.
+func SyncTrafficShifts(gtsLister gslbListers.GSLBTrafficShiftLister, k8swq []workqueue.RateLimitingInterface,
+	numWorkers uint32) {
+	tsList, err := gtsLister.List(labels.Everything())
+	if err != nil {
+		gslbutils.Errf("msg: error in listing the GSLBTrafficShift objects: %v", err)
+		return
+	}
+	for _, obj := range tsList {
+		if obj.Status.Phase != gslbalphav1.TrafficShiftProgressing && obj.Status.Phase != gslbalphav1.TrafficShiftCompleted {
+			continue
+		}
+		ts := obj.DeepCopy()
+		key := getTrafficShiftKey(ts)
+		// the GslbServices owned by a GDP object can change after the traffic shift has started
+		pushTrafficShiftKeys(ts, applyTrafficShift(ts), k8swq, numWorkers)
+		if ts.Status.Phase != gslbalphav1.TrafficShiftProgressing || !gslbutils.IsReplicaElected() {
+			continue
+		}
+		if ts.Status.LastStepTime != nil && time.Since(ts.Status.LastStepTime.Time) < getTrafficShiftStepInterval(ts) {
+			continue
+		}
+
+		fqdns := trafficShifts.getFqdns(key)
+		if len(fqdns) == 0 {
+			gslbutils.Warnf("gslbTrafficShift: %s, msg: no GslbServices to shift the traffic of, will check again", key)
+			continue
+		}
+		tenant := gslbutils.GetTenantInNamespace(ts.Namespace, gslbutils.LeaderClusterContext)
+		var reason string
+		for _, fqdn := range fqdns {
+			reason, err = checkGSTargetMembers(tenant, fqdn, ts.Spec.TargetClusters)
+			if err != nil || reason != "" {
+				break
+			}
+		}
+		if err != nil {
+			gslbutils.Warnf("gslbTrafficShift: %s, msg: can't check the health of the target members, will check again: %v",
+				key, err)
+			continue
+		}
+
+		now := metav1.Now()
+		switch {
+		case reason != "":
+			ts.Status = gslbalphav1.GSLBTrafficShiftStatus{Phase: gslbalphav1.TrafficShiftRolledBack,
+				CurrentStep: ts.Status.CurrentStep, LastStepTime: &now, Message: reason}
+			pushTrafficShiftKeys(ts, trafficShifts.remove(key), k8swq, numWorkers)
+			msg := fmt.Sprintf("GSLBTrafficShift %s rolled back at step %d: %s", key, ts.Status.CurrentStep, reason)
+			gslbutils.Warnf("gslbTrafficShift: %s, msg: %s", key, msg)
+			gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeWarning, gslbutils.GSLBTrafficShift, msg)
+		case ts.Status.CurrentStep >= len(ts.Spec.Steps):
+			ts.Status.Phase = gslbalphav1.TrafficShiftCompleted
+			ts.Status.LastStepTime = &now
+			gslbutils.Logf("gslbTrafficShift: %s, msg: traffic shift completed", key)
+		default:
+			ts.Status.CurrentStep++
+			ts.Status.LastStepTime = &now
+			pushTrafficShiftKeys(ts, applyTrafficShift(ts), k8swq, numWorkers)
+			gslbutils.Logf("gslbTrafficShift: %s, msg: moved to step %d, %d%% of the traffic is routed to the target clusters",
+				key, ts.Status.CurrentStep, ts.Spec.Steps[ts.Status.CurrentStep-1])
+		}
+		updateGSLBTrafficShiftStatus(ts)
+	}
+}
+
+func InitializeGSLBTrafficShiftController(kubeclientset kubernetes.Interface,
+	gslbclientset gslbcs.Interface,
+	gslbInformerFactory gslbinformers.SharedInformerFactory,
+	AddGSLBTrafficShiftObj AddDelGSLBTrafficShiftfn,
+	UpdateGSLBTrafficShiftObj UpdateGSLBTrafficShiftfn, DeleteGSLBTrafficShiftObj AddDelGSLBTrafficShiftfn) *GSLBTrafficShiftController {
+
+	gtsInformer := gslbInformerFactory.Amko().V1alpha1().GSLBTrafficShifts()
+	gtsController := &GSLBTrafficShiftController{
+		kubeclientset: kubeclientset,
+		gslbclientset: gslbclientset,
+		gtsLister:     gtsInformer.Lister(),
+		gtsSynced:     gtsInformer.Informer().HasSynced,
+	}
+	gslbutils.Logf("object: GSLBTrafficShiftController, msg: %s", "setting up event handlers")
+	k8sQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+
+	gtsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			AddGSLBTrafficShiftObj(obj, k8sQueue.Workqueue, k8sQueue.NumWorkers)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			UpdateGSLBTrafficShiftObj(old, new, k8sQueue.Workqueue, k8sQueue.NumWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			DeleteGSLBTrafficShiftObj(obj, k8sQueue.Workqueue, k8sQueue.NumWorkers)
+		},
+	})
+
+	return gtsController
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+)
+
+const hmDeleted = "deleted"
+
+// CheckHmDrift compares the health monitors created by AMKO in the HM cache with the ones in
+// newHmCache, freshly fetched from the controller. The HM cache is updated with the health monitors
+// edited or deleted outside of AMKO, and the keys of their GslbServices are published to the rest
+// layer, which corrects them.
+func CheckHmDrift(newHmCache *avicache.AviHmCache) {
+	var err error
+	hmCache := avicache.GetAviHmCache()
+	sharedQ := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, k := range hmCache.AviHmGetAllKeys() {
+		hmKey, ok := k.(avicache.TenantName)
+		if !ok || !gslbutils.HMCreatedByAMKO(hmKey.Name) {
+			continue
+		}
+		hmIntf, _ := hmCache.AviHmCacheGet(hmKey)
+		existingHm, ok := hmIntf.(*avicache.AviHmObj)
+		if !ok {
+			continue
+		}
+		gsName := nodes.GetGSNameFromHmDescription(existingHm.Description)
+		if gsName == "" {
+			continue
+		}
+
+		var fields []string
+		newHmIntf, found := newHmCache.AviHmCacheGet(hmKey)
+		if !found {
+			// the health monitor might have been created after the health monitors were fetched
+			newHmIntf, found, err = fetchHm(hmKey)
+			if err != nil {
+				gslbutils.Errf("hmKey: %v, msg: error in fetching the health monitor, skipping the drift check: %v", hmKey, err)
+				continue
+			}
+		}
+		newHm, _ := newHmIntf.(*avicache.AviHmObj)
+		if !found || newHm == nil {
+			fields = []string{hmDeleted}
+			hmCache.AviHmCacheDelete(hmKey)
+			invalidateGSChecksum(hmKey.Tenant, gsName)
+		} else if newHm.CloudConfigCksum != existingHm.CloudConfigCksum || newHm.SettingsCksum != existingHm.SettingsCksum {
+			fields = getHmDriftFields(existingHm, newHm)
+			if newHm.CloudConfigCksum == existingHm.CloudConfigCksum {
+				// the rest layer only compares the checksum to update a health monitor, reset it as just
+				// the settings changed
+				hmCopy := *newHm
+				hmCopy.CloudConfigCksum = 0
+				newHm = &hmCopy
+			}
+			hmCache.AviHmCacheAdd(hmKey, newHm)
+		} else {
+			continue
+		}
+
+		key := hmKey.Tenant + "/" + gsName
+		_, gsGraphIntf := nodes.SharedAviGSGraphLister().Get(key)
+		gsGraph, ok := gsGraphIntf.(*nodes.AviGSObjectGraph)
+		if !ok || gsGraph == nil || !utils.HasElem(gsGraph.GetHmNames(), hmKey.Name) {
+			gslbutils.Debugf("key: %s, hmName: %s, fields: %v, msg: health monitor not in use changed, updated the cache",
+				key, hmKey.Name, fields)
+			continue
+		}
+		gslbutils.Warnf("key: %s, hmName: %s, fields: %v, msg: health monitor changed outside of AMKO, will correct it",
+			key, hmKey.Name, fields)
+		gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeWarning, gslbutils.HealthMonitorDrift,
+			fmt.Sprintf("Health monitor %s of GslbService %s changed outside of AMKO (%s), correcting it",
+				hmKey.Name, gsName, strings.Join(fields, ", ")))
+		nodes.PublishKeyToRestLayer(hmKey.Tenant, gsName, key, sharedQ)
+	}
+}
+
+// fetchHm fetches the health monitor hmKey from the controller, found is false if it doesn't exist.
+func fetchHm(hmKey avicache.TenantName) (interface{}, bool, error) {
+	hmCache, err := avicache.FetchHMCache(hmKey.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	hmIntf, found := hmCache.AviHmCacheGet(hmKey)
+	return hmIntf, found, nil
+}
+
+// getHmDriftFields returns the fields of the health monitor which make up its checksums, and differ
+// between existingHm, as last written by AMKO, and newHm, as fetched from the controller.
+func getHmDriftFields(existingHm, newHm *avicache.AviHmObj) []string {
+	var fields []string
+	if existingHm.Type != newHm.Type {
+		fields = append(fields, "type")
+	}
+	if existingHm.Port != newHm.Port {
+		fields = append(fields, "monitor_port")
+	}
+	if existingHm.Description != newHm.Description {
+		fields = append(fields, "description")
+	}
+	if existingHm.SettingsCksum != newHm.SettingsCksum {
+		fields = append(fields, "send_interval, receive_timeout, successful_checks, failed_checks or monitor request")
+	}
+	// the HMs created at runtime don't have the created by field in the cache, the only other field of
+	// the checksum
+	if newHm.CreatedBy != gslbutils.AMKOControlConfig().CreatedByField() || len(fields) == 0 {
+		fields = append(fields, "markers")
+	}
+	return fields
+}
+
+// invalidateGSChecksum resets the checksum of the GslbService in the GS cache, so that it's updated
+// on the controller to refer to the health monitors created again.
+func invalidateGSChecksum(tenant, gsName string) {
+	gsCache := avicache.GetAviCache()
+	gsKey := avicache.TenantName{Tenant: tenant, Name: gsName}
+	gsIntf, found := gsCache.AviCacheGet(gsKey)
+	if !found {
+		return
+	}
+	gsObj, ok := gsIntf.(*avicache.AviGSCache)
+	if !ok || gsObj == nil {
+		return
+	}
+	gsCopy := *gsObj
+	gsCopy.CloudConfigCksum = 0
+	gsCache.AviCacheAdd(gsKey, &gsCopy)
+}
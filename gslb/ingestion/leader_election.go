@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+)
+
+// With leader election, all the AMKO replicas run the informers, the graph layer and keep the Avi
+// caches, but only the elected replica runs the rest layer and the periodic operations on the Avi
+// controller. A standby replica which gets elected takes over once it has synced its caches.
+var replicaState struct {
+	lock       sync.Mutex
+	bootupDone bool
+}
+
+// StartLeaderElection runs the leader election for this replica till stopCh is closed. Losing the lease
+// restarts AMKO, and it comes back up as a standby.
+func StartLeaderElection(kubeClient kubernetes.Interface, stopCh <-chan struct{}) {
+	identity := os.Getenv("POD_NAME")
+	if identity == "" {
+		identity, _ = os.Hostname()
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      gslbutils.LeaderElectionLeaseName,
+			Namespace: gslbutils.AVISystem,
+		},
+		Client:     kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	gslbutils.Logf("identity: %s, lease: %s, msg: starting leader election, AMKO is a standby till elected",
+		identity, gslbutils.LeaderElectionLeaseName)
+	go leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   gslbutils.LeaderElectionLeaseDuration,
+		RenewDeadline:   gslbutils.LeaderElectionRenewDeadline,
+		RetryPeriod:     gslbutils.LeaderElectionRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				onReplicaElected(identity)
+			},
+			OnStoppedLeading: func() {
+				gslbutils.SetReplicaElected(false)
+				if ctx.Err() != nil {
+					gslbutils.Logf("identity: %s, msg: released the leader election lease", identity)
+					return
+				}
+				gslbutils.LogAndPanic("lost the leader election lease, restarting AMKO as a standby")
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					gslbutils.Logf("identity: %s, leader: %s, msg: AMKO replica %s is elected, this replica is a standby",
+						identity, leader, leader)
+				}
+			},
+		},
+	})
+}
+
+func onReplicaElected(identity string) {
+	replicaState.lock.Lock()
+	gslbutils.SetReplicaElected(true)
+	bootupDone := replicaState.bootupDone
+	replicaState.lock.Unlock()
+
+	gslbutils.Logf("identity: %s, msg: this AMKO replica is elected", identity)
+	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.LeaderElection,
+		"AMKO replica "+identity+" is elected and will sync the GslbServices")
+	if bootupDone {
+		TakeOver()
+	}
+}
+
+// setBootupDone marks the boot up sync as done. electedAtBootup tells whether this replica was elected
+// when the boot up sync started, if it got elected during the sync, the keys dropped by the rest layer
+// before that are synced now.
+func setBootupDone(electedAtBootup bool) {
+	replicaState.lock.Lock()
+	replicaState.bootupDone = true
+	elected := gslbutils.IsReplicaElected()
+	replicaState.lock.Unlock()
+
+	if elected && !electedAtBootup {
+		TakeOver()
+	}
+}
+
+// TakeOver syncs the Avi caches with the controller, as the previously elected replica might have
+// changed the objects since they were populated, and publishes all the GslbService keys to the rest
+// layer. The cache entries of the objects deleted in the meantime are removed by the rest layer when
+// the controller returns a 404 for them.
+func TakeOver() {
+	gslbutils.Logf("msg: taking over, syncing the Avi caches and all the GslbServices")
+	gsCache := avicache.GetAviCache()
+	newGSCache := avicache.PopulateGSCache(false)
+	for _, k := range newGSCache.AviCacheGetAllKeys() {
+		if obj, ok := newGSCache.AviCacheGet(k); ok {
+			gsCache.AviCacheAdd(k, obj)
+		}
+	}
+
+	newHmCache, err := avicache.FetchHMCache()
+	if err != nil {
+		gslbutils.Errf("msg: error in fetching the health monitors, will continue with the existing HM cache: %v", err)
+	} else {
+		hmCache := avicache.GetAviHmCache()
+		for _, k := range newHmCache.AviHmGetAllKeys() {
+			if obj, ok := newHmCache.AviHmCacheGet(k); ok {
+				hmCache.AviHmCacheAdd(k, obj.(*avicache.AviHmObj))
+			}
+		}
+	}
+
+	nodes.PublishAllGraphKeys()
+	// the GslbServices deleted while this replica was a standby
+	sharedQ := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, key := range nodes.SharedDeleteGSGraphLister().GetAll() {
+		tenant, gsName := utils.ExtractNamespaceObjectName(key)
+		nodes.PublishKeyToRestLayer(tenant, gsName, key, sharedQ)
+	}
+}
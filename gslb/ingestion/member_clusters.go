@@ -0,0 +1,322 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"os"
+	"sync"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/k8sobjects"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// memberClusterStopChs holds the stop channel for the informers of each member cluster, so that the
+// informers of a single member cluster can be stopped without touching the other clusters.
+var memberClusterStopChs = make(map[string]chan struct{})
+var memberClusterStopChsLock sync.Mutex
+
+// memberKubeConfigChecksums holds the checksum of each context in the members' kubeconfig in use.
+var memberKubeConfigChecksums map[string]uint32
+
+// getMemberClusterStopCh returns the stop channel for the informers of member cluster cname. The
+// channel is closed either when AMKO shuts down or when the cluster is removed or reconnected.
+func getMemberClusterStopCh(cname string) <-chan struct{} {
+	memberClusterStopChsLock.Lock()
+	defer memberClusterStopChsLock.Unlock()
+
+	if ch, ok := memberClusterStopChs[cname]; ok {
+		return ch
+	}
+	ch := make(chan struct{})
+	memberClusterStopChs[cname] = ch
+	go func() {
+		select {
+		case <-stopCh:
+			stopMemberClusterInformers(cname)
+		case <-ch:
+		}
+	}()
+	return ch
+}
+
+// stopMemberClusterInformers stops the informers of member cluster cname, returns false if the
+// informers weren't running.
+func stopMemberClusterInformers(cname string) bool {
+	memberClusterStopChsLock.Lock()
+	defer memberClusterStopChsLock.Unlock()
+
+	ch, ok := memberClusterStopChs[cname]
+	if !ok {
+		return false
+	}
+	delete(memberClusterStopChs, cname)
+	close(ch)
+	gslbutils.Logf("cluster: %s, msg: stopped the informers", cname)
+	return true
+}
+
+func diffMemberClusters(oldClusters, newClusters []gslbalphav1.MemberCluster) ([]string, []string) {
+	oldContexts := make(map[string]bool)
+	for _, c := range oldClusters {
+		oldContexts[c.ClusterContext] = true
+	}
+	newContexts := make(map[string]bool)
+	var added, removed []string
+	for _, c := range newClusters {
+		newContexts[c.ClusterContext] = true
+		if !oldContexts[c.ClusterContext] {
+			added = append(added, c.ClusterContext)
+		}
+	}
+	for _, c := range oldClusters {
+		if !newContexts[c.ClusterContext] {
+			removed = append(removed, c.ClusterContext)
+		}
+	}
+	return added, removed
+}
+
+// getChangedClusters returns the member clusters present in both the lists, for which the setting
+// returned by getSetting changed.
+func getChangedClusters(oldClusters, newClusters []gslbalphav1.MemberCluster,
+	getSetting func(gslbalphav1.MemberCluster) bool) []string {
+	oldSettings := make(map[string]bool)
+	for _, c := range oldClusters {
+		oldSettings[c.ClusterContext] = getSetting(c)
+	}
+	var changed []string
+	for _, c := range newClusters {
+		if oldSetting, ok := oldSettings[c.ClusterContext]; ok && oldSetting != getSetting(c) {
+			changed = append(changed, c.ClusterContext)
+		}
+	}
+	return changed
+}
+
+// UpdateMemberClusters applies the changes in the member cluster list of the GSLBConfig object. The
+// informers for the new clusters are started and their objects synced, the removed clusters are
+// stopped and their objects are removed from the GS graphs. For the other clusters, a change in
+// useNodeRegion refreshes their regions, and the objects of the clusters whose drain changed are
+// synced again.
+func UpdateMemberClusters(oldClusters, newClusters []gslbalphav1.MemberCluster) {
+	gslbutils.SetMemberClusterSettings(newClusters)
+	if changed := getChangedClusters(oldClusters, newClusters,
+		func(c gslbalphav1.MemberCluster) bool { return c.UseNodeRegion }); len(changed) != 0 {
+		gslbutils.Logf("clusters: %v, msg: useNodeRegion changed in the GSLBConfig object", changed)
+		go RefreshClusterRegions()
+	}
+	if changed := getChangedClusters(oldClusters, newClusters,
+		func(c gslbalphav1.MemberCluster) bool { return c.Drain }); len(changed) != 0 {
+		gslbutils.Logf("clusters: %v, msg: drain changed in the GSLBConfig object", changed)
+		ingestionQ := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+		WriteChangedObjsToQueue(ingestionQ.Workqueue, ingestionQ.NumWorkers, false, changed)
+	}
+	added, removed := diffMemberClusters(oldClusters, newClusters)
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	gslbutils.Logf("addedClusters: %v, removedClusters: %v, msg: member clusters changed in the GSLBConfig object",
+		added, removed)
+	for _, cname := range removed {
+		RemoveMemberCluster(cname)
+	}
+	if len(added) == 0 {
+		return
+	}
+	for _, cname := range added {
+		gslbutils.AddClusterContext(cname)
+	}
+	addToPendingClusters(added)
+	// the initialization and sync of a new cluster can take long, the resync worker picks up the
+	// clusters which can't be initialized now
+	go resyncMemberCluster()
+}
+
+func addToPendingClusters(clusters []string) {
+	pendingClustersLock.Lock()
+	defer pendingClustersLock.Unlock()
+
+	if pendingClusters == nil {
+		pendingClusters = make(map[KubeClusterDetails]struct{})
+	}
+	for _, cname := range clusters {
+		gslbutils.Logf("cluster: %s, msg: added to the pending clusters", cname)
+		pendingClusters[GetNewKubeClusterDetails(cname, gslbutils.GSLBKubePath, "", nil)] = struct{}{}
+	}
+}
+
+// getPendingClusters returns a copy of the pending clusters.
+func getPendingClusters() []KubeClusterDetails {
+	pendingClustersLock.Lock()
+	defer pendingClustersLock.Unlock()
+	clusters := make([]KubeClusterDetails, 0, len(pendingClusters))
+	for cluster := range pendingClusters {
+		clusters = append(clusters, cluster)
+	}
+	return clusters
+}
+
+// removeFromPendingClusters removes a cluster from the pending clusters, returns false if the cluster
+// isn't pending anymore, i.e., it was removed from the GSLBConfig object.
+func removeFromPendingClusters(cluster KubeClusterDetails) bool {
+	pendingClustersLock.Lock()
+	defer pendingClustersLock.Unlock()
+	if _, ok := pendingClusters[cluster]; !ok {
+		return false
+	}
+	delete(pendingClusters, cluster)
+	return true
+}
+
+// RemoveMemberCluster stops the informers of member cluster cname, deletes all its objects from the
+// stores and publishes the keys for the deleted objects to the graph layer.
+func RemoveMemberCluster(cname string) {
+	gslbutils.Logf("cluster: %s, msg: removing the member cluster", cname)
+	gslbutils.RemoveClusterContext(cname)
+	stopMemberClusterInformers(cname)
+	unregisterClusterHealth(cname)
+
+	pendingClustersLock.Lock()
+	for cluster := range pendingClusters {
+		if cluster.clusterName == cname {
+			delete(pendingClusters, cluster)
+		}
+	}
+	pendingClustersLock.Unlock()
+
+	gslbutils.DeleteInformersPerCluster(cname)
+	gslbutils.DeleteMemberClusterInformerSynced(cname)
+
+	k8sQueue := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	DeleteClusterObjsFromAllStores(k8sQueue.Workqueue, k8sQueue.NumWorkers, cname)
+	gslbutils.Logf("cluster: %s, msg: removed the member cluster", cname)
+}
+
+// DeleteClusterObjsFromAllStores deletes all the objects of cluster cname from all the stores. A DELETE
+// key is published for each accepted object, so that the cluster's members are removed from the GS graphs.
+func DeleteClusterObjsFromAllStores(k8swq []workqueue.RateLimitingInterface, numWorkers uint32, cname string) {
+	objTypes := []string{gdpalphav2.RouteObj, gdpalphav2.LBSvcObj, gdpalphav2.IngressObj, gslbutils.MCIType,
+		gslbutils.HTTPRouteType}
+	for _, objType := range objTypes {
+		_, acceptedObjStore, rejectedObjStore, err := GetObjTypeStores(objType)
+		if err != nil {
+			gslbutils.Errf("objtype error: %s", err.Error())
+			continue
+		}
+		namespaces := make(map[string]struct{})
+		for _, objStore := range []*store.ClusterStore{acceptedObjStore, rejectedObjStore} {
+			for _, ns := range objStore.GetClusterStore(cname).GetAllNamespaces() {
+				namespaces[ns] = struct{}{}
+			}
+		}
+		for ns := range namespaces {
+			deleteNamespacedObjsAndWriteToQueue(objType, k8swq, numWorkers, cname, ns)
+		}
+		acceptedObjStore.DeleteClusterStore(cname)
+		rejectedObjStore.DeleteClusterStore(cname)
+	}
+
+	acceptedNSStore := store.GetAcceptedNSStore()
+	for _, ns := range acceptedNSStore.GetNSStore(cname).GetAllObjectNames() {
+		nsObj, ok := acceptedNSStore.GetNSObjectByName(cname, ns)
+		if !ok {
+			continue
+		}
+		nsObj.(k8sobjects.NSMeta).DeleteFromFilter()
+	}
+	acceptedNSStore.DeleteNSStore(cname)
+	store.GetRejectedNSStore().DeleteNSStore(cname)
+	store.GetNamespaceToTenantStore().DeleteNSStore(cname)
+	store.GetHostRuleStore().DeleteClusterStore(cname)
+}
+
+// getKubeConfigChecksums returns a checksum for each context in a kubeconfig, built from the context
+// and its cluster and user details.
+func getKubeConfigChecksums(kubeConfigData []byte) (map[string]uint32, error) {
+	kubeConfig, err := clientcmd.Load(kubeConfigData)
+	if err != nil {
+		return nil, err
+	}
+	checksums := make(map[string]uint32)
+	for name, kcContext := range kubeConfig.Contexts {
+		checksums[name] = utils.Hash(utils.Stringify(kcContext)) +
+			utils.Hash(utils.Stringify(kubeConfig.Clusters[kcContext.Cluster])) +
+			utils.Hash(utils.Stringify(kubeConfig.AuthInfos[kcContext.AuthInfo]))
+	}
+	return checksums, nil
+}
+
+// syncMemberKubeConfig reloads the members' kubeconfig from the mounted secret. The member clusters
+// whose context has changed are reconnected with the new kubeconfig.
+func syncMemberKubeConfig() {
+	kubeConfigFile := gslbutils.GetMemberKubeConfigFile()
+	if kubeConfigFile == "" {
+		return
+	}
+	kubeConfigData, err := os.ReadFile(kubeConfigFile)
+	if err != nil {
+		gslbutils.Warnf("file: %s, msg: can't read the members' kubeconfig: %v", kubeConfigFile, err)
+		return
+	}
+	if string(kubeConfigData) == membersKubeConfig {
+		return
+	}
+	checksums, err := getKubeConfigChecksums(kubeConfigData)
+	if err != nil {
+		gslbutils.Errf("file: %s, msg: invalid members' kubeconfig, will keep the existing one: %v", kubeConfigFile, err)
+		return
+	}
+	if memberKubeConfigChecksums == nil {
+		// the checksums for the kubeconfig from the environment weren't calculated yet
+		memberKubeConfigChecksums, _ = getKubeConfigChecksums([]byte(membersKubeConfig))
+	}
+	if err := writeKubeConfig(string(kubeConfigData)); err != nil {
+		gslbutils.Errf("msg: can't write the members' kubeconfig: %v", err)
+		return
+	}
+	gslbutils.Logf("file: %s, msg: members' kubeconfig changed", kubeConfigFile)
+	membersKubeConfig = string(kubeConfigData)
+
+	var changedClusters []string
+	for cname, cksum := range checksums {
+		if oldCksum, ok := memberKubeConfigChecksums[cname]; ok && oldCksum == cksum {
+			continue
+		}
+		if !gslbutils.IsClusterContextPresent(cname) {
+			continue
+		}
+		// the objects of a reconnected cluster are kept in the stores and updated by the sync
+		gslbutils.Logf("cluster: %s, msg: context changed in the members' kubeconfig, will reconnect", cname)
+		stopMemberClusterInformers(cname)
+		changedClusters = append(changedClusters, cname)
+	}
+	memberKubeConfigChecksums = checksums
+	addToPendingClusters(changedClusters)
+}
+
+// syncMemberClusters picks up the changes in the members' kubeconfig, initializes the member
+// clusters which are pending and refreshes the regions of the connected member clusters.
+func syncMemberClusters() {
+	syncMemberKubeConfig()
+	resyncMemberCluster()
+	RefreshClusterRegions()
+}
@@ -45,11 +45,13 @@ type GSLBMemberController struct {
 	worker_id        uint32
 	informers        *containerutils.Informers
 	hrInformer       *hrinformer.HostRuleInformer
-	hrClientSet      *hrcs.Clientset
-	hrAlphaClientSet *ahrcs.Clientset
+	hrClientSet      hrcs.Interface
+	hrAlphaClientSet ahrcs.Interface
 	workqueue        []workqueue.RateLimitingInterface
 	recorder         *gslbutils.EventRecorder
 	cacheSyncParam   []cache.InformerSynced
+	// gatewayAPIInformers is set only if the gateway API is enabled and available in the cluster
+	gatewayAPIInformers *GatewayAPIInformers
 }
 
 // GetAviController sets config for an AviController
@@ -135,6 +137,27 @@ func DeleteFromMultiClusterIngressStore(clusterIngStore *store.ClusterStore,
 	return present
 }
 
+// AddOrUpdateHTTPRouteStore traverses through the cluster store for cluster name cname,
+// and then to ns store for the httproute host's namespace and then adds/updates the httproute
+// host obj in the object map store.
+func AddOrUpdateHTTPRouteStore(clusterRouteStore *store.ClusterStore,
+	routeHost k8sobjects.HTTPRouteHostMeta, cname string) {
+	clusterRouteStore.AddOrUpdate(routeHost, cname, routeHost.Namespace, routeHost.ObjName)
+}
+
+// DeleteFromHTTPRouteStore traverses through the cluster store for cluster name cname,
+// and then ns store for the httproute host's namespace and then deletes the httproute host
+// key from the object map store.
+func DeleteFromHTTPRouteStore(clusterRouteStore *store.ClusterStore,
+	routeHost k8sobjects.HTTPRouteHostMeta, cname string) bool {
+	if clusterRouteStore == nil {
+		// Store is empty, so, noop
+		return false
+	}
+	_, present := clusterRouteStore.DeleteClusterNSObj(routeHost.Cluster, routeHost.Namespace, routeHost.ObjName)
+	return present
+}
+
 // SetupEventHandlers sets up event handlers for the controllers of the member clusters.
 // This is called AFTER informers have started and initial boot-up sync is complete.
 // Following AKO pattern: informers start first, then full sync, then event handlers.
@@ -186,6 +209,13 @@ func (c *GSLBMemberController) SetupEventHandlers(k8sinfo K8SInformers) {
 		c.informers.MultiClusterIngressInformer.Informer().AddEventHandler(mciEventHandler)
 	}
 
+	if c.gatewayAPIInformers != nil {
+		gslbutils.Logf("cluster: %s, msg: adding Gateway and HTTPRoute event handlers", c.name)
+		c.gatewayAPIInformers.HTTPRouteInformer.AddEventHandler(AddHTTPRouteEventHandler(numWorkers, c))
+		c.gatewayAPIInformers.GatewayInformer.AddEventHandler(AddGatewayEventHandler(numWorkers, c))
+	}
+
+	registerClusterHealth(c)
 	gslbutils.Logf("cluster: %s, msg: all event handlers configured successfully", c.name)
 }
 
@@ -219,6 +249,13 @@ func isHostRuleAcceptable(hr *akov1beta1.HostRule) bool {
 	if hr.Status.Status != gslbutils.HostRuleAccepted || hr.Spec.VirtualHost.Fqdn == "" {
 		return false
 	}
+	// a wildcard GslbService can only be backed by the virtual service of a wildcard host
+	if gslbutils.GetCustomFqdnMode() && gslbutils.IsWildcardFqdn(hr.Spec.VirtualHost.Gslb.Fqdn) &&
+		!gslbutils.IsWildcardFqdn(hr.Spec.VirtualHost.Fqdn) {
+		gslbutils.Warnf("namespace: %s, hostRule: %s, fqdn: %s, gsFqdn: %s, msg: wildcard gslb fqdn is only supported for a wildcard fqdn",
+			hr.Namespace, hr.Name, hr.Spec.VirtualHost.Fqdn, hr.Spec.VirtualHost.Gslb.Fqdn)
+		return false
+	}
 	return true
 }
 
@@ -279,18 +316,21 @@ func (c *GSLBMemberController) Start(stopCh <-chan struct{}) {
 
 	if c.informers.IngressInformer != nil {
 		gslbutils.Logf("cluster: %s, msg: starting Ingress informer", c.name)
+		c.setWatchErrorHandler(c.informers.IngressInformer.Informer())
 		go c.informers.IngressInformer.Informer().Run(stopCh)
 		c.cacheSyncParam = append(c.cacheSyncParam, c.informers.IngressInformer.Informer().HasSynced)
 	}
 
 	if c.informers.RouteInformer != nil {
 		gslbutils.Logf("cluster: %s, msg: starting Route informer", c.name)
+		c.setWatchErrorHandler(c.informers.RouteInformer.Informer())
 		go c.informers.RouteInformer.Informer().Run(stopCh)
 		c.cacheSyncParam = append(c.cacheSyncParam, c.informers.RouteInformer.Informer().HasSynced)
 	}
 
 	if c.informers.ServiceInformer != nil {
 		gslbutils.Logf("cluster: %s, msg: starting Service informer", c.name)
+		c.setWatchErrorHandler(c.informers.ServiceInformer.Informer())
 		go c.informers.ServiceInformer.Informer().Run(stopCh)
 		c.cacheSyncParam = append(c.cacheSyncParam, c.informers.ServiceInformer.Informer().HasSynced)
 	}
@@ -306,21 +346,43 @@ func (c *GSLBMemberController) Start(stopCh <-chan struct{}) {
 
 	if c.informers.MultiClusterIngressInformer != nil {
 		gslbutils.Logf("cluster: %s, msg: starting MultiClusterIngress informer", c.name)
+		c.setWatchErrorHandler(c.informers.MultiClusterIngressInformer.Informer())
 		go c.informers.MultiClusterIngressInformer.Informer().Run(stopCh)
 		c.cacheSyncParam = append(c.cacheSyncParam, c.informers.MultiClusterIngressInformer.Informer().HasSynced)
 	}
 
+	if c.gatewayAPIInformers != nil {
+		gslbutils.Logf("cluster: %s, msg: starting Gateway and HTTPRoute informers", c.name)
+		c.setWatchErrorHandler(c.gatewayAPIInformers.GatewayInformer)
+		c.setWatchErrorHandler(c.gatewayAPIInformers.HTTPRouteInformer)
+		go c.gatewayAPIInformers.GatewayInformer.Run(stopCh)
+		go c.gatewayAPIInformers.HTTPRouteInformer.Run(stopCh)
+		c.cacheSyncParam = append(c.cacheSyncParam, c.gatewayAPIInformers.GatewayInformer.HasSynced,
+			c.gatewayAPIInformers.HTTPRouteInformer.HasSynced)
+	}
+
 	gslbutils.Logf("cluster: %s, msg: waiting for all informer caches to sync", c.name)
+	gslbutils.SetMemberClusterInformerSynced(c.name, false)
 	if !cache.WaitForCacheSync(stopCh, c.cacheSyncParam...) {
 		runtime.HandleError(fmt.Errorf("cluster: %s, timed out waiting for informer caches to sync", c.name))
 	} else {
 		gslbutils.Logf("cluster: %s, msg: all informer caches synced successfully", c.name)
+		gslbutils.SetMemberClusterInformerSynced(c.name, true)
+	}
+}
+
+// setWatchErrorHandler sets the handler to track the cluster connectivity on an informer which
+// isn't running yet, the informers shared with another controller keep their handler.
+func (c *GSLBMemberController) setWatchErrorHandler(informer cache.SharedInformer) {
+	if err := informer.SetWatchErrorHandler(c.watchErrorHandler); err != nil {
+		gslbutils.Debugf("cluster: %s, msg: watch error handler not set: %v", c.name, err)
 	}
 }
 
 func (c *GSLBMemberController) StartNamespaceInformer(stopCh <-chan struct{}) {
 	if c.informers.NSInformer != nil {
 		gslbutils.Logf("cluster: %s, msg: %s", c.name, "starting namespace informer")
+		c.setWatchErrorHandler(c.informers.NSInformer.Informer())
 		go c.informers.NSInformer.Informer().Run(stopCh)
 		c.cacheSyncParam = append(c.cacheSyncParam, c.informers.NSInformer.Informer().HasSynced)
 	}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+)
+
+// namespacedGDPStore holds the GDP objects created outside the avi-system namespace, accepted or
+// not. A namespaced GDP object has to be re-evaluated whenever the guardrails set by the GDP objects
+// in avi-system change.
+type namespacedGDPStore struct {
+	gdps map[string]*gdpalphav2.GlobalDeploymentPolicy
+	lock sync.RWMutex
+}
+
+var nsGDPStore *namespacedGDPStore
+var nsGDPStoreOnce sync.Once
+
+func getNamespacedGDPStore() *namespacedGDPStore {
+	nsGDPStoreOnce.Do(func() {
+		nsGDPStore = &namespacedGDPStore{
+			gdps: make(map[string]*gdpalphav2.GlobalDeploymentPolicy),
+		}
+	})
+	return nsGDPStore
+}
+
+func (s *namespacedGDPStore) addOrUpdate(gdp *gdpalphav2.GlobalDeploymentPolicy) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.gdps[gslbutils.GetGDPFilterKey(gdp.Namespace, gdp.Name)] = gdp.DeepCopy()
+}
+
+func (s *namespacedGDPStore) delete(gdp *gdpalphav2.GlobalDeploymentPolicy) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.gdps, gslbutils.GetGDPFilterKey(gdp.Namespace, gdp.Name))
+}
+
+// getAll returns the copies of the namespaced GDP objects, sorted by their keys.
+func (s *namespacedGDPStore) getAll() []*gdpalphav2.GlobalDeploymentPolicy {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	keys := make([]string, 0, len(s.gdps))
+	for k := range s.gdps {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	gdps := make([]*gdpalphav2.GlobalDeploymentPolicy, 0, len(keys))
+	for _, k := range keys {
+		gdps = append(gdps, s.gdps[k].DeepCopy())
+	}
+	return gdps
+}
+
+// namespacedGDPSanityChecks verifies that a GDP object outside avi-system only selects the objects in
+// its own namespace and stays within the guardrails set by the GDP objects in avi-system.
+func namespacedGDPSanityChecks(gdp *gdpalphav2.GlobalDeploymentPolicy) error {
+	guardrails := gslbutils.GetGlobalFilter().GetGuardrails()
+	if guardrails == nil {
+		return errors.New("namespaced GDP objects are not allowed, no GDP object in " + gslbutils.AVISystem +
+			" specifies namespacedPolicyGuardrails")
+	}
+	if nsSelector := gdp.Spec.MatchRules.NamespaceSelector; len(nsSelector.Label) != 0 ||
+		len(nsSelector.MatchExpressions) != 0 {
+		return errors.New("namespaceSelector is not allowed for a GDP object outside " + gslbutils.AVISystem)
+	}
+	if gdp.Spec.HealthMonitorTemplate != nil {
+		return errors.New("healthMonitorTemplate is not allowed for a GDP object outside " + gslbutils.AVISystem)
+	}
+	if gdp.Spec.DefaultDomain != nil {
+		return errors.New("defaultDomain is not allowed for a GDP object outside " + gslbutils.AVISystem)
+	}
+	if gdp.Spec.NamespacedPolicyGuardrails != nil {
+		return errors.New("namespacedPolicyGuardrails is not allowed for a GDP object outside " + gslbutils.AVISystem)
+	}
+	for _, cluster := range gdp.Spec.MatchClusters {
+		if !gslbutils.IsClusterAllowedByGuardrails(guardrails, cluster.Cluster) {
+			return fmt.Errorf("cluster %s is not allowed by the guardrails", cluster.Cluster)
+		}
+	}
+	for _, tp := range gdp.Spec.TrafficSplit {
+		if !gslbutils.IsClusterAllowedByGuardrails(guardrails, tp.Cluster) {
+			return fmt.Errorf("cluster %s in traffic split is not allowed by the guardrails", tp.Cluster)
+		}
+	}
+	if gdp.Spec.TTL != nil && !gslbutils.IsTTLAllowedByGuardrails(guardrails, *gdp.Spec.TTL) {
+		return fmt.Errorf("ttl %d is not within the range [%d, %d] allowed by the guardrails", *gdp.Spec.TTL,
+			guardrails.TTLRange.Min, guardrails.TTLRange.Max)
+	}
+	for _, hmRef := range gdp.Spec.HealthMonitorRefs {
+		if !gslbutils.IsHmRefAllowedByGuardrails(guardrails, hmRef) {
+			return fmt.Errorf("health monitor ref %s is not allowed by the guardrails", hmRef)
+		}
+	}
+	return nil
+}
+
+// reevaluateNamespacedGDPs runs the sanity checks for all the namespaced GDP objects again, as the
+// guardrails might have changed. The namespaced GDP objects which are no longer within the guardrails
+// are removed from the GlobalFilter, and the ones which are now within the guardrails are added.
+// Returns true if the GlobalFilter was changed.
+func reevaluateNamespacedGDPs(fullSync bool) bool {
+	gf := gslbutils.GetGlobalFilter()
+	changed := false
+	for _, gdp := range getNamespacedGDPStore().getAll() {
+		key := gslbutils.GetGDPFilterKey(gdp.Namespace, gdp.Name)
+		err := GDPSanityChecks(gdp, fullSync)
+		accepted := gf.GetFilter(key) != nil
+		if err != nil {
+			if accepted {
+				gslbutils.Logf("gdp: %s, msg: namespaced GDP object no longer accepted: %s", key, err.Error())
+				gf.DeleteFilter(key)
+				changed = true
+			}
+			if gdp.Status.ErrorStatus != err.Error() {
+				updateGDPStatus(gdp, err.Error())
+			}
+			continue
+		}
+		if accepted {
+			continue
+		}
+		gslbutils.Logf("gdp: %s, msg: namespaced GDP object is now accepted", key)
+		gdpFilter := gslbutils.NewGDPFilter(key)
+		gdpFilter.AddToFilter(gdp)
+		gf.AddFilter(gdpFilter)
+		updateGDPStatus(gdp, GDPSuccess)
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"context"
+	"fmt"
+
+	oshiftclient "github.com/openshift/client-go/route/clientset/versioned"
+	ahrcs "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned"
+	hrcs "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
+)
+
+// NewOfflineMemberController returns the controller for member cluster cname, which fetches the
+// objects from the given clientsets instead of a running cluster. The informers of such a
+// controller are never started, the objects are only synced once by BuildGSGraphsOffline.
+func NewOfflineMemberController(cname string, kubeClient kubernetes.Interface, oshiftClient oshiftclient.Interface,
+	hrClient hrcs.Interface, hrAlphaClient ahrcs.Interface) (*GSLBMemberController, error) {
+
+	// the namespace to tenant store is usually filled by the namespace event handlers
+	nsList, err := kubeClient.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error in fetching namespaces for cluster %s: %v", cname, err)
+	}
+	nt := store.GetNamespaceToTenantStore()
+	for _, ns := range nsList.Items {
+		nt.AddOrUpdate(cname, ns.Name, ns.Annotations[gslbutils.TenantAnnotation])
+	}
+
+	informersArg := make(map[string]interface{})
+	informersArg[utils.INFORMERS_OPENSHIFT_CLIENT] = oshiftClient
+	informersArg[utils.INFORMERS_INSTANTIATE_ONCE] = false
+	informersArg[utils.INFORMERS_AKO_CLIENT] = hrAlphaClient
+
+	// both ingresses and routes are synced, as there's no API to check which of these the
+	// cluster supports
+	registeredInformers := []string{utils.IngressInformer, utils.RouteInformer, utils.ServiceInformer, utils.NSInformer}
+	if utils.IsMultiClusterIngressEnabled() {
+		registeredInformers = append(registeredInformers, utils.MultiClusterIngressInformer)
+	}
+	informerInstance := utils.NewInformers(utils.KubeClientIntf{ClientSet: kubeClient}, registeredInformers,
+		informersArg)
+	gslbutils.SetInformersPerCluster(cname, informerInstance)
+
+	aviCtrl := GetGSLBMemberController(cname, informerInstance, nil)
+	aviCtrl.hrClientSet = hrClient
+	aviCtrl.hrAlphaClientSet = hrAlphaClient
+	return &aviCtrl, nil
+}
+
+// BuildGSGraphsOffline runs the boot up sync for the offline member controllers, which builds the
+// GS graphs for the objects in these clusters. The GDP and GSLBHostRule objects are fetched via
+// the clientsets set in AMKOControlConfig. The keys of the GS graphs are only published to the
+// graph layer queue, it's the caller's job to process them.
+func BuildGSGraphsOffline(ctrlList []*GSLBMemberController) {
+	bootupSync(ctrlList, avicache.GetAviCache())
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	gslbhralphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// WebhookGDPPath is the path on which the GDP objects are validated
+	WebhookGDPPath = "/validate-gdp"
+	// WebhookGSLBHostRulePath is the path on which the GSLBHostRule objects are validated
+	WebhookGSLBHostRulePath = "/validate-gslbhostrule"
+
+	webhookCertFile = "tls.crt"
+	webhookKeyFile  = "tls.key"
+)
+
+// admissionValidator validates the object in an admission request, a non-nil error rejects the object.
+type admissionValidator func(req *admissionv1.AdmissionRequest) error
+
+// certLoader serves the webhook server certificate, and reloads it whenever the certificate file
+// changes, so that the rotated certificates are picked up without a restart.
+type certLoader struct {
+	certDir string
+	modTime time.Time
+	cert    *tls.Certificate
+	lock    sync.Mutex
+}
+
+func (c *certLoader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	certFile := filepath.Join(c.certDir, webhookCertFile)
+	info, err := os.Stat(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("can't stat the webhook certificate %s: %v", certFile, err)
+	}
+	if c.cert != nil && info.ModTime().Equal(c.modTime) {
+		return c.cert, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, filepath.Join(c.certDir, webhookKeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("can't load the webhook certificate: %v", err)
+	}
+	c.cert = &cert
+	c.modTime = info.ModTime()
+	return c.cert, nil
+}
+
+// StartWebhookServer starts the validating admission webhook server for the GDP and GSLBHostRule
+// objects. The validators need the Avi controller details, so this has to be called only after a
+// GSLBConfig object is accepted.
+func StartWebhookServer(stopCh <-chan struct{}) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(WebhookGDPPath, func(w http.ResponseWriter, r *http.Request) {
+		ServeAdmission(w, r, ValidateGDPAdmission)
+	})
+	mux.HandleFunc(WebhookGSLBHostRulePath, func(w http.ResponseWriter, r *http.Request) {
+		ServeAdmission(w, r, ValidateGSLBHostRuleAdmission)
+	})
+
+	loader := &certLoader{certDir: gslbutils.WebhookCertDir}
+	server := &http.Server{
+		Addr:    ":" + gslbutils.GetWebhookPort(),
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: loader.getCertificate,
+		},
+	}
+
+	go func() {
+		gslbutils.Logf("object: WebhookServer, port: %s, msg: starting the validating webhook server",
+			gslbutils.GetWebhookPort())
+		if err := server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			gslbutils.Errf("object: WebhookServer, msg: webhook server stopped: %v", err)
+		}
+	}()
+	go func() {
+		<-stopCh
+		gslbutils.Logf("object: WebhookServer, msg: shutting down the validating webhook server")
+		server.Shutdown(context.TODO())
+	}()
+}
+
+// ServeAdmission decodes an AdmissionReview request, validates the object in it and writes back the
+// AdmissionReview response.
+func ServeAdmission(w http.ResponseWriter, r *http.Request, validate admissionValidator) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "can't read the request body", http.StatusBadRequest)
+		return
+	}
+	review := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(w, "can't decode the admission review", http.StatusBadRequest)
+		return
+	}
+
+	response := &admissionv1.AdmissionResponse{
+		UID:     review.Request.UID,
+		Allowed: true,
+	}
+	if err := validate(review.Request); err != nil {
+		gslbutils.Logf("objType: %s, ns: %s, name: %s, operation: %s, msg: rejected by the webhook: %v",
+			review.Request.Kind.Kind, review.Request.Namespace, review.Request.Name, review.Request.Operation, err)
+		response.Allowed = false
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		}
+	}
+	review.Response = response
+	review.Request = nil
+
+	resp, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, "can't encode the admission review", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(resp)
+}
+
+// ValidateGDPAdmission validates a GDP object being created or updated with the same checks used
+// while accepting a GDP object.
+func ValidateGDPAdmission(req *admissionv1.AdmissionRequest) error {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return nil
+	}
+	gdp := gdpalphav2.GlobalDeploymentPolicy{}
+	if err := json.Unmarshal(req.Object.Raw, &gdp); err != nil {
+		return fmt.Errorf("can't decode the GDP object: %v", err)
+	}
+	if req.Operation == admissionv1.Update {
+		oldGdp := gdpalphav2.GlobalDeploymentPolicy{}
+		if err := json.Unmarshal(req.OldObject.Raw, &oldGdp); err == nil &&
+			reflect.DeepEqual(oldGdp.Spec, gdp.Spec) {
+			// the status updates must go through, even for a GDP object which was rejected
+			return nil
+		}
+	}
+	if gdp.Namespace == "" {
+		gdp.Namespace = req.Namespace
+	}
+	// the refs found in the caches need not be fetched again, and the webhook can't retry forever. The
+	// object may still be rejected, so the refs fetched from the controller aren't added to the caches.
+	return gdpSanityChecks(&gdp, true, false, false)
+}
+
+// ValidateGSLBHostRuleAdmission validates a GSLBHostRule object being created or updated with the same
+// checks used while accepting a GSLBHostRule object.
+func ValidateGSLBHostRuleAdmission(req *admissionv1.AdmissionRequest) error {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return nil
+	}
+	gslbhr := gslbhralphav1.GSLBHostRule{}
+	if err := json.Unmarshal(req.Object.Raw, &gslbhr); err != nil {
+		return fmt.Errorf("can't decode the GSLBHostRule object: %v", err)
+	}
+	if req.Operation == admissionv1.Update {
+		oldGslbhr := gslbhralphav1.GSLBHostRule{}
+		if err := json.Unmarshal(req.OldObject.Raw, &oldGslbhr); err == nil &&
+			reflect.DeepEqual(oldGslbhr.Spec, gslbhr.Spec) {
+			// the status updates must go through, even for a GSLBHostRule which was rejected
+			return nil
+		}
+	}
+	if gslbhr.Namespace == "" {
+		gslbhr.Namespace = req.Namespace
+	}
+	// the refs found in the caches need not be fetched again, but the member objects must be present.
+	// The refs fetched from the controller aren't added to the caches.
+	return validateGSLBHostRule(&gslbhr, true, true, false)
+}
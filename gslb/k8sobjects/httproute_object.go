@@ -0,0 +1,496 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8sobjects
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+)
+
+var httpRouteHostMapInit sync.Once
+var httpRouteHostMap ObjHostMap
+
+func getHTTPRouteHostMap() *ObjHostMap {
+	httpRouteHostMapInit.Do(func() {
+		httpRouteHostMap.HostMap = make(map[string]IPHostname)
+	})
+	return &httpRouteHostMap
+}
+
+// GatewayGetter fetches a gateway object by its namespace and name.
+type GatewayGetter func(ns, name string) (*gatewayv1.Gateway, bool)
+
+// GetHostMetaForHTTPRoute returns a gateway API HTTPRoute split into its hostnames. The addresses
+// are picked up from the status of the parent gateways and the TLS setting from the listeners the
+// route is attached to.
+func GetHostMetaForHTTPRoute(route *gatewayv1.HTTPRoute, cname string, getGateway GatewayGetter) []HTTPRouteHostMeta {
+	metaObjects := []HTTPRouteHostMeta{}
+
+	gf := gslbutils.GetGlobalFilter()
+	// we don't return because of errors here, as we need these objects in the our internal cache,
+	// so that, when the GDP object gets changed, we can re-apply these objects back again.
+	// The errors for syncVIPsOnly are taken care of in the graph layer.
+	syncVIPsOnly, err := gf.IsClusterSyncVIPOnly(cname)
+	if err != nil {
+		gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, msg: skipping httproute because of error: %v",
+			cname, route.Namespace, route.Name, err)
+	}
+
+	paths := getPathListForHTTPRoute(route)
+	for _, parentRef := range route.Spec.ParentRefs {
+		if !IsGatewayParentRef(parentRef) {
+			continue
+		}
+		gwNamespace := GetParentRefNamespace(parentRef, route.Namespace)
+		if !isHTTPRouteAcceptedByParent(route, parentRef, gwNamespace) {
+			gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, gateway: %s/%s, msg: httproute not accepted by gateway",
+				cname, route.Namespace, route.Name, gwNamespace, parentRef.Name)
+			continue
+		}
+		gw, ok := getGateway(gwNamespace, string(parentRef.Name))
+		if !ok {
+			gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, gateway: %s/%s, msg: parent gateway not found",
+				cname, route.Namespace, route.Name, gwNamespace, parentRef.Name)
+			continue
+		}
+		ipAddrs := getGatewayIPAddrs(gw)
+		if len(ipAddrs) == 0 {
+			gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, gateway: %s/%s, msg: no ip address in gateway status",
+				cname, route.Namespace, route.Name, gwNamespace, parentRef.Name)
+			continue
+		}
+
+		vsUUIDs, controllerUUID, tenant, err := parseVSAndControllerAnnotations(gw.Annotations)
+		if err != nil && !syncVIPsOnly {
+			// Note that the key will still be published to graph layer, but the key won't be
+			// processed, this is just to maintain the httproute information as part of in-memory map.
+			gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, gateway: %s/%s, msg: error in parsing gateway annotations: %v",
+				cname, route.Namespace, route.Name, gwNamespace, parentRef.Name, err)
+		}
+
+		for _, listener := range gw.Spec.Listeners {
+			if !listenerSelectedByParentRef(listener, parentRef) {
+				continue
+			}
+			if listener.Protocol != gatewayv1.HTTPProtocolType && listener.Protocol != gatewayv1.HTTPSProtocolType {
+				continue
+			}
+			for _, hostname := range getListenerHostnamesForHTTPRoute(listener, route.Spec.Hostnames) {
+				if _, found := HTTPRouteHostMeta.HostInList(HTTPRouteHostMeta{Hostname: hostname}, metaObjects); found {
+					// hostname already picked up via another gateway or listener
+					continue
+				}
+				vsUUID, ok := vsUUIDs[hostname]
+				if !ok && !syncVIPsOnly {
+					gslbutils.Logf("cluster: %s, ns: %s, httproute: %s, msg: hostname %s missing from VS UUID annotations",
+						cname, route.Namespace, route.Name, hostname)
+				}
+				metaObj := HTTPRouteHostMeta{
+					Cluster:            cname,
+					RouteName:          route.Name,
+					ObjName:            route.Name + "/" + hostname,
+					Namespace:          route.Namespace,
+					Gateway:            gwNamespace + "/" + gw.Name,
+					Hostname:           hostname,
+					IPAddr:             ipAddrs[0],
+					IPAddrs:            ipAddrs,
+					VirtualServiceUUID: vsUUID,
+					ControllerUUID:     controllerUUID,
+					Paths:              paths,
+					TLS:                listener.Protocol == gatewayv1.HTTPSProtocolType,
+					Tenant:             tenant,
+					Drained:            gslbutils.IsDrainAnnotationSet(route.Annotations),
+				}
+				metaObj.Labels = make(map[string]string)
+				for key, value := range route.GetLabels() {
+					metaObj.Labels[key] = value
+				}
+				metaObjects = append(metaObjects, metaObj)
+			}
+		}
+	}
+	return metaObjects
+}
+
+// IsGatewayParentRef returns true if the parent reference of a route points to a Gateway.
+func IsGatewayParentRef(parentRef gatewayv1.ParentReference) bool {
+	if parentRef.Group != nil && string(*parentRef.Group) != gatewayv1.GroupName {
+		return false
+	}
+	if parentRef.Kind != nil && string(*parentRef.Kind) != "Gateway" {
+		return false
+	}
+	return true
+}
+
+// GetParentRefNamespace returns the namespace of the parent, which defaults to the route's namespace.
+func GetParentRefNamespace(parentRef gatewayv1.ParentReference, routeNamespace string) string {
+	if parentRef.Namespace != nil && *parentRef.Namespace != "" {
+		return string(*parentRef.Namespace)
+	}
+	return routeNamespace
+}
+
+// isHTTPRouteAcceptedByParent returns false only if the gateway controller has explicitly marked the
+// route as not accepted for this parent.
+func isHTTPRouteAcceptedByParent(route *gatewayv1.HTTPRoute, parentRef gatewayv1.ParentReference,
+	gwNamespace string) bool {
+	for _, parentStatus := range route.Status.Parents {
+		if !IsGatewayParentRef(parentStatus.ParentRef) ||
+			parentStatus.ParentRef.Name != parentRef.Name ||
+			GetParentRefNamespace(parentStatus.ParentRef, route.Namespace) != gwNamespace {
+			continue
+		}
+		for _, condition := range parentStatus.Conditions {
+			if condition.Type == string(gatewayv1.RouteConditionAccepted) && condition.Status == metav1.ConditionFalse {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func getGatewayIPAddrs(gw *gatewayv1.Gateway) []string {
+	ipAddrs := []string{}
+	for _, addr := range gw.Status.Addresses {
+		if addr.Type != nil && *addr.Type != gatewayv1.IPAddressType {
+			continue
+		}
+		if net.ParseIP(addr.Value) == nil || gslbutils.PresentInList(addr.Value, ipAddrs) {
+			continue
+		}
+		ipAddrs = append(ipAddrs, addr.Value)
+	}
+	return ipAddrs
+}
+
+func listenerSelectedByParentRef(listener gatewayv1.Listener, parentRef gatewayv1.ParentReference) bool {
+	if parentRef.SectionName != nil && *parentRef.SectionName != listener.Name {
+		return false
+	}
+	if parentRef.Port != nil && *parentRef.Port != listener.Port {
+		return false
+	}
+	return true
+}
+
+// getListenerHostnamesForHTTPRoute returns the list of hostnames served by an HTTPRoute via a
+// listener, i.e., the intersection of the route's hostnames and the listener's hostname. As for the
+// hosts of an ingress, a wildcard hostname is returned as is and gets a wildcard GslbService. When
+// one of a route hostname and the listener hostname is a wildcard covering the other, the more
+// specific one is returned.
+func getListenerHostnamesForHTTPRoute(listener gatewayv1.Listener, routeHostnames []gatewayv1.Hostname) []string {
+	var listenerHostname string
+	if listener.Hostname != nil {
+		listenerHostname = string(*listener.Hostname)
+	}
+	hostnames := []string{}
+	if len(routeHostnames) == 0 {
+		if listenerHostname != "" {
+			hostnames = append(hostnames, listenerHostname)
+		}
+		return hostnames
+	}
+	for _, h := range routeHostnames {
+		hostname := string(h)
+		if !hostnameMatches(hostname, listenerHostname) {
+			// a wildcard route hostname can be narrowed down by a more specific listener hostname
+			if listenerHostname == "" || !hostnameMatches(listenerHostname, hostname) {
+				continue
+			}
+			hostname = listenerHostname
+		}
+		if !gslbutils.PresentInList(hostname, hostnames) {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+func isWildcardHostname(hostname string) bool {
+	return strings.HasPrefix(hostname, "*.")
+}
+
+// hostnameMatches checks whether a hostname matches a listener hostname, which can be empty
+// (matches all) or a wildcard. A wildcard hostname matches a wildcard covering its domain.
+func hostnameMatches(hostname, pattern string) bool {
+	if pattern == "" || hostname == pattern {
+		return true
+	}
+	if isWildcardHostname(pattern) {
+		return strings.HasSuffix(hostname, pattern[1:]) && hostname != pattern[2:]
+	}
+	return false
+}
+
+func getPathListForHTTPRoute(route *gatewayv1.HTTPRoute) []string {
+	pathList := []string{}
+	for _, rule := range route.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Path == nil || match.Path.Value == nil {
+				continue
+			}
+			if match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression {
+				continue
+			}
+			if gslbutils.PresentInList(*match.Path.Value, pathList) {
+				continue
+			}
+			pathList = append(pathList, *match.Path.Value)
+		}
+	}
+
+	// if nothing in the pathList, always add "/"
+	if len(pathList) == 0 {
+		pathList = append(pathList, "/")
+	}
+	return pathList
+}
+
+// HTTPRouteHostMeta is the metadata for a hostname of a gateway API HTTPRoute. It is the minimal
+// information that we maintain for each HTTPRoute hostname, accepted or rejected.
+type HTTPRouteHostMeta struct {
+	Cluster            string
+	RouteName          string
+	ObjName            string
+	Namespace          string
+	Gateway            string
+	Hostname           string
+	IPAddr             string
+	IPAddrs            []string
+	VirtualServiceUUID string
+	ControllerUUID     string
+	Labels             map[string]string
+	Paths              []string
+	TLS                bool
+	Tenant             string
+	Drained            bool
+}
+
+func (hrhm HTTPRouteHostMeta) GetType() string {
+	return gslbutils.HTTPRouteType
+}
+
+func (hrhm HTTPRouteHostMeta) GetName() string {
+	return hrhm.ObjName
+}
+
+func (hrhm HTTPRouteHostMeta) GetNamespace() string {
+	return hrhm.Namespace
+}
+
+func (hrhm HTTPRouteHostMeta) GetCluster() string {
+	return hrhm.Cluster
+}
+
+func (hrhm HTTPRouteHostMeta) GetHostname() string {
+	return hrhm.Hostname
+}
+
+func (hrhm HTTPRouteHostMeta) GetIPAddr() string {
+	return hrhm.IPAddr
+}
+
+func (hrhm HTTPRouteHostMeta) GetIPAddrs() []string {
+	return getIPAddrsCopy(hrhm.IPAddr, hrhm.IPAddrs)
+}
+
+func (hrhm HTTPRouteHostMeta) GetPort() (int32, error) {
+	return 0, errors.New("httproute object doesn't support GetPort function")
+}
+
+func (hrhm HTTPRouteHostMeta) GetProtocol() (string, error) {
+	return "", errors.New("httproute object doesn't support GetProtocol function")
+}
+
+func (hrhm HTTPRouteHostMeta) GetPaths() ([]string, error) {
+	if len(hrhm.Paths) == 0 {
+		return hrhm.Paths, errors.New("no paths for this httproute " + hrhm.ObjName)
+	}
+	return hrhm.Paths, nil
+}
+
+func (hrhm HTTPRouteHostMeta) GetTLS() (bool, error) {
+	return hrhm.TLS, nil
+}
+
+func (hrhm HTTPRouteHostMeta) IsPassthrough() bool {
+	return false
+}
+
+func (hrhm HTTPRouteHostMeta) GetVirtualServiceUUID() string {
+	return hrhm.VirtualServiceUUID
+}
+
+func (hrhm HTTPRouteHostMeta) GetControllerUUID() string {
+	return hrhm.ControllerUUID
+}
+
+func (hrhm HTTPRouteHostMeta) GetTenant() string {
+	return hrhm.Tenant
+}
+
+func (hrhm HTTPRouteHostMeta) IsDrained() bool {
+	return hrhm.Drained
+}
+
+func (hrhm HTTPRouteHostMeta) GetHTTPRouteHostCksum() uint32 {
+	var cksum uint32
+	for lblKey, lblValue := range hrhm.Labels {
+		cksum += utils.Hash(lblKey) + utils.Hash(lblValue)
+	}
+	paths := make([]string, len(hrhm.Paths))
+	copy(paths, hrhm.Paths)
+	sort.Strings(paths)
+	cksum += utils.Hash(hrhm.Cluster) + utils.Hash(hrhm.Namespace) +
+		utils.Hash(hrhm.RouteName) + utils.Hash(hrhm.Gateway) + utils.Hash(hrhm.Hostname) +
+		utils.Hash(hrhm.IPAddr) + utils.Hash(utils.Stringify(hrhm.IPAddrs)) + utils.Hash(utils.Stringify(paths)) +
+		utils.Hash(hrhm.VirtualServiceUUID) + utils.Hash(hrhm.ControllerUUID) +
+		utils.Hash(hrhm.Tenant) + utils.Hash(utils.Stringify(hrhm.Drained))
+	if hrhm.TLS {
+		cksum += utils.Hash("tls")
+	}
+	return cksum
+}
+
+func (hrhm HTTPRouteHostMeta) UpdateHostMap(key string) {
+	hm := getHTTPRouteHostMap()
+	hm.Lock.Lock()
+	defer hm.Lock.Unlock()
+	hm.HostMap[key] = IPHostname{
+		IP:       hrhm.IPAddr,
+		Hostname: hrhm.Hostname,
+	}
+}
+
+func (hrhm HTTPRouteHostMeta) GetHostnameFromHostMap(key string) string {
+	hm := getHTTPRouteHostMap()
+	hm.Lock.Lock()
+	defer hm.Lock.Unlock()
+	ipHostname, ok := hm.HostMap[key]
+	if !ok {
+		return ""
+	}
+	return ipHostname.Hostname
+}
+
+func (hrhm HTTPRouteHostMeta) DeleteMapByKey(key string) {
+	hm := getHTTPRouteHostMap()
+	hm.Lock.Lock()
+	defer hm.Lock.Unlock()
+	delete(hm.HostMap, key)
+}
+
+func (hrhm HTTPRouteHostMeta) ApplyFilter() bool {
+	fqdnMap := gslbutils.GetFqdnMap()
+
+	selectedByGDP := hrhm.ApplyGDPSelector()
+	if selectedByGDP {
+		if gslbutils.GetCustomFqdnMode() {
+			_, err := fqdnMap.GetGlobalFqdnForLocalFqdn(hrhm.Cluster, hrhm.Hostname)
+			if err != nil {
+				gslbutils.Debugf("cluster: %s, ns: %s, httproute host: %s, msg: error in fetching global fqdn: %v",
+					hrhm.Cluster, hrhm.Namespace, hrhm.Hostname, err)
+				return false
+			}
+			return true
+		}
+	}
+
+	return selectedByGDP
+}
+
+func (hrhm HTTPRouteHostMeta) ApplyGDPSelector() bool {
+	return len(GetSelectingGDPs(hrhm)) > 0
+}
+
+// ApplyGDPFilter checks whether the object is selected by the filter of a GDP object.
+func (hrhm HTTPRouteHostMeta) ApplyGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
+
+	if !gf.IsObjInScope(hrhm.Cluster, hrhm.Namespace) {
+		gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster or namespace is not in scope",
+			hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+		return false
+	}
+	nsFilter := gf.NSFilter
+	// will check the namespaces first, whether the namespace for the httproute is selected
+	if nsFilter != nil {
+		nsFilter.Lock.RLock()
+		defer nsFilter.Lock.RUnlock()
+		nsList, ok := gf.NSFilter.SelectedNS[hrhm.Cluster]
+		if !ok {
+			gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: rejected because of namespaceSelector",
+				hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+			return false
+		}
+		if gslbutils.PresentInList(hrhm.Namespace, nsList) {
+			appFilter := gf.AppFilter
+			if appFilter == nil {
+				gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: accepted because of namespaceSelector",
+					hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+				return true
+			}
+			// Check the appFilter now for this object
+			if applyAppFilter(hrhm.Labels, appFilter) {
+				gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: accepted because of namespaceSelector and appSelector",
+					hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+				return true
+			}
+			gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: rejected because of appSelector",
+				hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+			return false
+		}
+		// this means that the namespace is not selected in the filter
+		gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: rejected because namespace is not selected",
+			hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+		return false
+	}
+	// check for app filter
+	if gf.AppFilter == nil {
+		gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: rejected because no appSelector",
+			hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+		return false
+	}
+	if !applyAppFilter(hrhm.Labels, gf.AppFilter) {
+		gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: rejected because of appSelector",
+			hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+		return false
+	}
+	gslbutils.Logf("objType: HTTPRoute, cluster: %s, namespace: %s, name: %s, msg: accepted because of appSelector",
+		hrhm.Cluster, hrhm.Namespace, hrhm.ObjName)
+
+	return true
+}
+
+// HostInList returns the object from hrhmList which has the same hostname as this object.
+func (hrhm HTTPRouteHostMeta) HostInList(hrhmList []HTTPRouteHostMeta) (HTTPRouteHostMeta, bool) {
+	for _, obj := range hrhmList {
+		if hrhm.Hostname == obj.Hostname {
+			return obj, true
+		}
+	}
+	return HTTPRouteHostMeta{}, false
+}
@@ -170,6 +170,7 @@ func GetIngressHostMeta(ingress *networkingv1.Ingress, cname string) []IngressHo
 			Namespace:          ingress.ObjectMeta.Namespace,
 			Hostname:           hip.Hostname,
 			IPAddr:             hip.IPAddr,
+			IPAddrs:            hip.IPAddrs,
 			Cluster:            cname,
 			ObjName:            ingress.Name + "/" + hip.Hostname,
 			TLS:                false,
@@ -177,6 +178,7 @@ func GetIngressHostMeta(ingress *networkingv1.Ingress, cname string) []IngressHo
 			ControllerUUID:     controllerUUID,
 			Tenant:             tenant,
 			Passthrough:        passThroughEnabled,
+			Drained:            gslbutils.IsDrainAnnotationSet(ingress.Annotations),
 		}
 		metaObj.Paths = make([]string, 0)
 		metaObj.Labels = make(map[string]string)
@@ -203,6 +205,7 @@ type IngressHostMeta struct {
 	Namespace          string
 	Hostname           string
 	IPAddr             string
+	IPAddrs            []string
 	VirtualServiceUUID string
 	ControllerUUID     string
 	Labels             map[string]string
@@ -210,6 +213,7 @@ type IngressHostMeta struct {
 	TLS                bool
 	Tenant             string
 	Passthrough        bool
+	Drained            bool
 }
 
 func (ing IngressHostMeta) GetType() string {
@@ -244,6 +248,10 @@ func (ing IngressHostMeta) GetIPAddr() string {
 	return ing.IPAddr
 }
 
+func (ing IngressHostMeta) GetIPAddrs() []string {
+	return getIPAddrsCopy(ing.IPAddr, ing.IPAddrs)
+}
+
 func (ing IngressHostMeta) GetPort() (int32, error) {
 	return 0, errors.New("ingress object doesn't support GetPort function")
 }
@@ -281,6 +289,10 @@ func (ing IngressHostMeta) GetTenant() string {
 	return ing.Tenant
 }
 
+func (ing IngressHostMeta) IsDrained() bool {
+	return ing.Drained
+}
+
 func (ing IngressHostMeta) IngressHostInList(ihmList []IngressHostMeta) (IngressHostMeta, bool) {
 	var ihm IngressHostMeta
 	for _, ihm = range ihmList {
@@ -301,8 +313,9 @@ func (ing IngressHostMeta) GetIngressHostCksum() uint32 {
 	// TODO: annotations will be checked in later
 	cksum += utils.Hash(ing.Cluster) + utils.Hash(ing.Namespace) +
 		utils.Hash(ing.IngName) + utils.Hash(ing.Hostname) +
-		utils.Hash(ing.IPAddr) + utils.Hash(utils.Stringify(paths)) +
-		utils.Hash(ing.VirtualServiceUUID) + utils.Hash(ing.ControllerUUID) + utils.Hash(ing.Tenant) + utils.Hash(utils.Stringify(ing.Passthrough))
+		utils.Hash(ing.IPAddr) + utils.Hash(utils.Stringify(ing.IPAddrs)) + utils.Hash(utils.Stringify(paths)) +
+		utils.Hash(ing.VirtualServiceUUID) + utils.Hash(ing.ControllerUUID) + utils.Hash(ing.Tenant) + utils.Hash(utils.Stringify(ing.Passthrough)) +
+		utils.Hash(utils.Stringify(ing.Drained))
 	return cksum
 }
 
@@ -359,12 +372,16 @@ func (ihm IngressHostMeta) ApplyFilter() bool {
 }
 
 func (ihm IngressHostMeta) ApplyGDPSelector() bool {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	return len(GetSelectingGDPs(ihm)) > 0
+}
+
+// ApplyGDPFilter checks whether the object is selected by the filter of a GDP object.
+func (ihm IngressHostMeta) ApplyGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
-	if !gslbutils.ClusterContextPresentInList(ihm.Cluster, gf.ApplicableClusters) {
-		gslbutils.Logf("objType: Ingress, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster is not selected",
+	if !gf.IsObjInScope(ihm.Cluster, ihm.Namespace) {
+		gslbutils.Logf("objType: Ingress, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster or namespace is not in scope",
 			ihm.Cluster, ihm.Namespace, ihm.ObjName)
 		return false
 	}
@@ -419,10 +436,5 @@ func (ihm IngressHostMeta) ApplyGDPSelector() bool {
 }
 
 func applyAppFilter(ihmLabels map[string]string, appFilter *gslbutils.AppFilter) bool {
-	for k, v := range ihmLabels {
-		if k == appFilter.Key && v == appFilter.Value {
-			return true
-		}
-	}
-	return false
+	return appFilter.Matches(ihmLabels)
 }
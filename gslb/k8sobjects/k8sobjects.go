@@ -16,6 +16,8 @@ package k8sobjects
 
 import (
 	"sync"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
 )
 
 // Interface for k8s/openshift objects(e.g. route, service, ingress) with minimal information
@@ -25,6 +27,7 @@ type MetaObject interface {
 	GetNamespace() string
 	GetHostname() string
 	GetIPAddr() string
+	GetIPAddrs() []string
 	GetCluster() string
 	UpdateHostMap(string)
 	GetHostnameFromHostMap(string) string
@@ -37,12 +40,49 @@ type MetaObject interface {
 	GetVirtualServiceUUID() string
 	GetControllerUUID() string
 	GetTenant() string
+	// IsDrained returns true if the object's GS members have to be disabled
+	IsDrained() bool
 }
 
 type FilterableObject interface {
 	ApplyFilter() bool
 }
 
+// GDPSelectableObject is implemented by the objects which can be selected by a GDP object.
+type GDPSelectableObject interface {
+	ApplyGDPFilter(gf *gslbutils.GDPFilter) bool
+}
+
+// GetSelectingGDPs returns the names of all the GDP objects selecting obj, in the order of precedence.
+func GetSelectingGDPs(obj GDPSelectableObject) []string {
+	gdps := []string{}
+	for _, gf := range gslbutils.GetGlobalFilter().GetFilters() {
+		if obj.ApplyGDPFilter(gf) {
+			gdps = append(gdps, gf.Name)
+		}
+	}
+	return gdps
+}
+
+// WildcardObject is implemented by the objects which can serve all the hosts in the domain of their hostname.
+type WildcardObject interface {
+	// GetWildcardFqdn returns the wildcard FQDN served by the object, empty if it only serves its hostname.
+	GetWildcardFqdn() string
+}
+
+// GetGSHostname returns the hostname from which the GslbService of obj is derived, i.e., the wildcard
+// FQDN served by obj, if any, and the hostname of obj otherwise. In the custom FQDN mode, the GslbService
+// is derived from the global FQDN mapped to the hostname of obj, so, the hostname is returned as is.
+func GetGSHostname(obj MetaObject) string {
+	if gslbutils.GetCustomFqdnMode() {
+		return obj.GetHostname()
+	}
+	if wObj, ok := obj.(WildcardObject); ok && wObj.GetWildcardFqdn() != "" {
+		return wObj.GetWildcardFqdn()
+	}
+	return obj.GetHostname()
+}
+
 type IPHostname struct {
 	IP       string
 	Hostname string
@@ -53,3 +93,17 @@ type ObjHostMap struct {
 	HostMap map[string]IPHostname
 	Lock    sync.Mutex
 }
+
+// getIPAddrsCopy returns a copy of the list of IP addresses of an object. Objects which were
+// built without the list of addresses, return only the primary IP address.
+func getIPAddrsCopy(ipAddr string, ipAddrs []string) []string {
+	if len(ipAddrs) == 0 {
+		if ipAddr == "" {
+			return []string{}
+		}
+		return []string{ipAddr}
+	}
+	addrs := make([]string, len(ipAddrs))
+	copy(addrs, ipAddrs)
+	return addrs
+}
@@ -84,6 +84,7 @@ func GetHostMetaForMultiClusterIngress(mci *akov1alpha1.MultiClusterIngress, cna
 		Namespace:          mci.ObjectMeta.Namespace,
 		Hostname:           hip.Hostname,
 		IPAddr:             hip.IPAddr,
+		IPAddrs:            hip.IPAddrs,
 		Cluster:            cname,
 		ObjName:            mci.Name + "/" + hip.Hostname,
 		TLS:                false,
@@ -108,10 +109,15 @@ func GetHostMetaForMultiClusterIngress(mci *akov1alpha1.MultiClusterIngress, cna
 
 func getHostAndIP(mci *akov1alpha1.MultiClusterIngress) *gslbutils.IngressHostIP {
 	var ingHostIP gslbutils.IngressHostIP
-	for _, ingStatus := range mci.Status.LoadBalancer.Ingress {
-		ingHostIP.Hostname = ingStatus.Hostname
-		ingHostIP.IPAddr = ingStatus.IP
-		break
+	for idx, ingStatus := range mci.Status.LoadBalancer.Ingress {
+		if idx == 0 {
+			ingHostIP.Hostname = ingStatus.Hostname
+			ingHostIP.IPAddr = ingStatus.IP
+		}
+		if ingStatus.Hostname == ingHostIP.Hostname && ingStatus.IP != "" &&
+			!gslbutils.PresentInList(ingStatus.IP, ingHostIP.IPAddrs) {
+			ingHostIP.IPAddrs = append(ingHostIP.IPAddrs, ingStatus.IP)
+		}
 	}
 	return &ingHostIP
 }
@@ -154,6 +160,7 @@ type MultiClusterIngressHostMeta struct {
 	Namespace          string
 	Hostname           string
 	IPAddr             string
+	IPAddrs            []string
 	VirtualServiceUUID string
 	ControllerUUID     string
 	Labels             map[string]string
@@ -194,6 +201,10 @@ func (mciHostMeta MultiClusterIngressHostMeta) GetIPAddr() string {
 	return mciHostMeta.IPAddr
 }
 
+func (mciHostMeta MultiClusterIngressHostMeta) GetIPAddrs() []string {
+	return getIPAddrsCopy(mciHostMeta.IPAddr, mciHostMeta.IPAddrs)
+}
+
 func (mciHostMeta MultiClusterIngressHostMeta) GetPort() (int32, error) {
 	return 0, errors.New("ingress object doesn't support GetPort function")
 }
@@ -231,6 +242,10 @@ func (mciHostMeta MultiClusterIngressHostMeta) GetTenant() string {
 	return mciHostMeta.Tenant
 }
 
+func (mciHostMeta MultiClusterIngressHostMeta) IsDrained() bool {
+	return false
+}
+
 func (mciHostMeta MultiClusterIngressHostMeta) GetIngressHostCksum() uint32 {
 	var cksum uint32
 	for lblKey, lblValue := range mciHostMeta.Labels {
@@ -241,7 +256,7 @@ func (mciHostMeta MultiClusterIngressHostMeta) GetIngressHostCksum() uint32 {
 	// TODO: annotations will be checked in later
 	cksum += utils.Hash(mciHostMeta.Cluster) + utils.Hash(mciHostMeta.Namespace) +
 		utils.Hash(mciHostMeta.IngName) + utils.Hash(mciHostMeta.Hostname) +
-		utils.Hash(mciHostMeta.IPAddr) + utils.Hash(utils.Stringify(paths)) +
+		utils.Hash(mciHostMeta.IPAddr) + utils.Hash(utils.Stringify(mciHostMeta.IPAddrs)) + utils.Hash(utils.Stringify(paths)) +
 		utils.Hash(mciHostMeta.VirtualServiceUUID) + utils.Hash(mciHostMeta.ControllerUUID) +
 		utils.Hash(mciHostMeta.Tenant)
 	return cksum
@@ -295,12 +310,16 @@ func (mciHostMeta MultiClusterIngressHostMeta) ApplyFilter() bool {
 }
 
 func (mciHostMeta MultiClusterIngressHostMeta) ApplyGDPSelector() bool {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	return len(GetSelectingGDPs(mciHostMeta)) > 0
+}
+
+// ApplyGDPFilter checks whether the object is selected by the filter of a GDP object.
+func (mciHostMeta MultiClusterIngressHostMeta) ApplyGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
-	if !gslbutils.ClusterContextPresentInList(mciHostMeta.Cluster, gf.ApplicableClusters) {
-		gslbutils.Logf("objType: Multi-cluster Ingress, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster is not selected",
+	if !gf.IsObjInScope(mciHostMeta.Cluster, mciHostMeta.Namespace) {
+		gslbutils.Logf("objType: Multi-cluster Ingress, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster or namespace is not in scope",
 			mciHostMeta.Cluster, mciHostMeta.Namespace, mciHostMeta.ObjName)
 		return false
 	}
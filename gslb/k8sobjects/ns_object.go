@@ -54,10 +54,21 @@ func (nsObj NSMeta) GetCluster() string {
 	return nsObj.Cluster
 }
 
+// ApplyFilter adds the namespace to the namespace filters of all the GDP objects selecting it,
+// returns true if any of the GDP objects selects the namespace.
 func (ns NSMeta) ApplyFilter() bool {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	selected := false
+	for _, gf := range gslbutils.GetGlobalFilter().GetFilters() {
+		if ns.applyGDPFilter(gf) {
+			selected = true
+		}
+	}
+	return selected
+}
+
+func (ns NSMeta) applyGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	if !gslbutils.ClusterContextPresentInList(ns.Cluster, gf.ApplicableClusters) {
 		gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, msg: namespace rejected because cluster was not selected",
@@ -68,13 +79,7 @@ func (ns NSMeta) ApplyFilter() bool {
 	if nsFilter != nil {
 		nsFilter.Lock.Lock()
 		defer nsFilter.Lock.Unlock()
-		lblMatch := false
-		for k, v := range ns.Labels {
-			if k == nsFilter.Key && v == nsFilter.Value {
-				lblMatch = true
-			}
-		}
-		if !lblMatch {
+		if !nsFilter.Matches(ns.Labels) {
 			gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, msg: namespace rejected because it was not selected via label",
 				ns.Cluster, ns.Name)
 			return false
@@ -106,10 +111,20 @@ func (ns NSMeta) ApplyFilter() bool {
 	return false
 }
 
+// DeleteFromFilter deletes the namespace from the namespace filters of all the GDP objects.
 func (ns NSMeta) DeleteFromFilter() bool {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	deleted := false
+	for _, gf := range gslbutils.GetGlobalFilter().GetFilters() {
+		if ns.deleteFromGDPFilter(gf) {
+			deleted = true
+		}
+	}
+	return deleted
+}
+
+func (ns NSMeta) deleteFromGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	nsFilter := gf.NSFilter
 	// nsFilter nil indicates GDP object doesn't contain the namespaceSelector field, don't do anything
@@ -150,31 +165,43 @@ func (ns NSMeta) DeleteFromFilter() bool {
 	return false
 }
 
-// UpdateFilter returns true if there was a change in the filter
+// UpdateFilter returns true if there was a change in any of the filters
 func (ns NSMeta) UpdateFilter(old NSMeta) bool {
-	oldApplied := old.ApplyFilter()
-	newApplied := ns.ApplyFilter()
+	changed := false
+	for _, gf := range gslbutils.GetGlobalFilter().GetFilters() {
+		oldApplied := old.applyGDPFilter(gf)
+		newApplied := ns.applyGDPFilter(gf)
 
-	if oldApplied == newApplied {
-		gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, msg: no changes", ns.Cluster, ns.Name)
-		return false
+		if oldApplied == newApplied {
+			continue
+		}
+		changed = true
+		if oldApplied && !newApplied {
+			gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, gdp: %s, msg: namespace changed, deleting the new namespace from filter",
+				ns.Cluster, ns.Name, gf.Name)
+			// delete the ns from the filter
+			ns.deleteFromGDPFilter(gf)
+			continue
+		}
+		// oldApplied == false, newApplied == true, namespace already added as part of applyGDPFilter
+		gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, gdp: %s, msg: namespace changed, added the namespace to filter",
+			ns.Cluster, ns.Name, gf.Name)
 	}
-	if oldApplied == true && newApplied == false {
-		gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, msg: namespace changed, deleting the new namespace from filter",
-			ns.Cluster, ns.Name)
-		// delete the ns from the filter
-		return ns.DeleteFromFilter()
+	if !changed {
+		gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, msg: no changes", ns.Cluster, ns.Name)
 	}
-
-	// oldApplied == false, newApplied == true, namespace already added as part of ApplyFilter
-	gslbutils.Logf("objType: Namespace, cluster: %s, name: %s, msg: namespace changed, added the namespace to filter")
-	return true
+	return changed
 }
 
 func RemoveAllSelectedNamespaces() {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	for _, gf := range gslbutils.GetGlobalFilter().GetFilters() {
+		removeAllSelectedNamespaces(gf)
+	}
+}
+
+func removeAllSelectedNamespaces(gf *gslbutils.GDPFilter) {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
 	nsFilter := gf.NSFilter
 	// nsFilter nil indicates GDP object doesn't contain the namespaceSelector field, don't do anything
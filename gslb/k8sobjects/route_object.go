@@ -79,17 +79,29 @@ func GetRouteMeta(route *routev1.Route, cname string) RouteMeta {
 		gslbutils.Logf("cluster: %s, ns: %s, route: %s, msg: hostname %s is missing from VS UUID annotations",
 			cname, route.Namespace, route.Name, hostname)
 	}
-	ipAddr, _ := gslbutils.RouteGetIPAddr(route)
+	var ipAddr string
+	ipAddrs, ok := gslbutils.RouteGetIPAddrs(route)
+	if ok {
+		ipAddr = ipAddrs[0]
+	}
+	// a route with the Subdomain wildcard policy serves all the hosts in the domain of its host
+	var wildcardFqdn string
+	if route.Spec.WildcardPolicy == routev1.WildcardPolicySubdomain {
+		wildcardFqdn = gslbutils.GetWildcardFqdn(hostname)
+	}
 	metaObj := RouteMeta{
 		Name:               route.Name,
 		Namespace:          route.ObjectMeta.Namespace,
 		Hostname:           hostname,
+		WildcardFqdn:       wildcardFqdn,
 		IPAddr:             ipAddr,
+		IPAddrs:            ipAddrs,
 		Cluster:            cname,
 		TLS:                false,
 		VirtualServiceUUID: vsUUID,
 		ControllerUUID:     controllerUUID,
 		Tenant:             tenant,
+		Drained:            gslbutils.IsDrainAnnotationSet(route.Annotations),
 	}
 	metaObj.Labels = make(map[string]string)
 	routeLabels := route.GetLabels()
@@ -172,6 +184,7 @@ type RouteMeta struct {
 	Namespace          string
 	Hostname           string
 	IPAddr             string
+	IPAddrs            []string
 	Labels             map[string]string
 	Paths              []string
 	TLS                bool
@@ -181,6 +194,10 @@ type RouteMeta struct {
 	VirtualServiceUUID string
 	ControllerUUID     string
 	Tenant             string
+	Drained            bool
+	// WildcardFqdn is the wildcard FQDN served by a route with the Subdomain wildcard policy, the
+	// GslbService is derived from it instead of the Hostname
+	WildcardFqdn string
 }
 
 func (route RouteMeta) GetType() string {
@@ -199,10 +216,18 @@ func (route RouteMeta) GetHostname() string {
 	return route.Hostname
 }
 
+func (route RouteMeta) GetWildcardFqdn() string {
+	return route.WildcardFqdn
+}
+
 func (route RouteMeta) GetIPAddr() string {
 	return route.IPAddr
 }
 
+func (route RouteMeta) GetIPAddrs() []string {
+	return getIPAddrsCopy(route.IPAddr, route.IPAddrs)
+}
+
 func (route RouteMeta) GetCluster() string {
 	return route.Cluster
 }
@@ -250,6 +275,10 @@ func (route RouteMeta) GetTenant() string {
 	return route.Tenant
 }
 
+func (route RouteMeta) IsDrained() bool {
+	return route.Drained
+}
+
 func (route RouteMeta) UpdateHostMap(key string) {
 	rhm := getRouteHostMap()
 	rhm.Lock.Lock()
@@ -303,12 +332,16 @@ func (route RouteMeta) ApplyFilter() bool {
 }
 
 func (route RouteMeta) ApplyGDPSelector() bool {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	return len(GetSelectingGDPs(route)) > 0
+}
+
+// ApplyGDPFilter checks whether the object is selected by the filter of a GDP object.
+func (route RouteMeta) ApplyGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
-	if !gslbutils.ClusterContextPresentInList(route.Cluster, gf.ApplicableClusters) {
-		gslbutils.Logf("objType: Route, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster is not selected",
+	if !gf.IsObjInScope(route.Cluster, route.Namespace) {
+		gslbutils.Logf("objType: Route, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster or namespace is not in scope",
 			route.Cluster, route.Namespace, route.Name)
 		return false
 	}
@@ -16,6 +16,9 @@ package k8sobjects
 
 import (
 	"errors"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
 	gdpv1alpha2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
@@ -53,6 +56,90 @@ func getSvcPortProtocol(svc *corev1.Service) (int32, string, error) {
 	return minPort, minProto, nil
 }
 
+// SvcPort is a port/protocol pair of a service which has to be health monitored.
+type SvcPort struct {
+	Port     int32
+	Protocol string
+}
+
+// getSvcPorts returns all the ports of a service which have to be health monitored, sorted by the
+// port number. Ports with protocols other than TCP and UDP are monitored via TCP health monitors.
+// If the service has the health monitor ports annotation, only the selected ports are returned.
+func getSvcPorts(svc *corev1.Service) ([]SvcPort, error) {
+	selectedPorts, err := parseHealthMonitorPortsAnnotation(svc.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	svcPorts := []SvcPort{}
+	for _, port := range svc.Spec.Ports {
+		protocol := string(port.Protocol)
+		if protocol != gslbutils.ProtocolUDP {
+			protocol = gslbutils.ProtocolTCP
+		}
+		svcPort := SvcPort{Port: port.Port, Protocol: protocol}
+		if selectedPorts != nil && !PresentInSvcPortList(svcPort, selectedPorts) {
+			continue
+		}
+		if PresentInSvcPortList(svcPort, svcPorts) {
+			continue
+		}
+		svcPorts = append(svcPorts, svcPort)
+	}
+	if len(svcPorts) == 0 {
+		return nil, errors.New("no service port matches the health monitor ports annotation")
+	}
+	sort.Slice(svcPorts, func(i, j int) bool {
+		if svcPorts[i].Port == svcPorts[j].Port {
+			return svcPorts[i].Protocol < svcPorts[j].Protocol
+		}
+		return svcPorts[i].Port < svcPorts[j].Port
+	})
+	return svcPorts, nil
+}
+
+// parseHealthMonitorPortsAnnotation parses a value of the format "443/TCP,53/UDP,8080". Returns nil
+// if the annotation is absent.
+func parseHealthMonitorPortsAnnotation(annotations map[string]string) ([]SvcPort, error) {
+	value, ok := annotations[gslbutils.HealthMonitorPortsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	ports := []SvcPort{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		portProto := strings.Split(entry, "/")
+		if len(portProto) > 2 {
+			return nil, errors.New("malformed entry " + entry + " in health monitor ports annotation")
+		}
+		port, err := strconv.ParseInt(strings.TrimSpace(portProto[0]), 10, 32)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, errors.New("invalid port " + portProto[0] + " in health monitor ports annotation")
+		}
+		protocol := gslbutils.ProtocolTCP
+		if len(portProto) == 2 {
+			protocol = strings.ToUpper(strings.TrimSpace(portProto[1]))
+			if protocol != gslbutils.ProtocolTCP && protocol != gslbutils.ProtocolUDP {
+				return nil, errors.New("unsupported protocol " + portProto[1] + " in health monitor ports annotation")
+			}
+		}
+		ports = append(ports, SvcPort{Port: int32(port), Protocol: protocol})
+	}
+	return ports, nil
+}
+
+// PresentInSvcPortList returns true if the port/protocol pair is present in svcPorts.
+func PresentInSvcPortList(svcPort SvcPort, svcPorts []SvcPort) bool {
+	for _, p := range svcPorts {
+		if p.Port == svcPort.Port && p.Protocol == svcPort.Protocol {
+			return true
+		}
+	}
+	return false
+}
+
 func getSvcHostMap() *ObjHostMap {
 	rhMapInit.Do(func() {
 		rhMap.HostMap = make(map[string]IPHostname)
@@ -61,17 +148,22 @@ func getSvcHostMap() *ObjHostMap {
 }
 
 type SvcMeta struct {
-	Cluster            string
-	Name               string
-	Namespace          string
-	Hostname           string
-	IPAddr             string
-	Labels             map[string]string
-	Port               int32
-	Protocol           string
+	Cluster   string
+	Name      string
+	Namespace string
+	Hostname  string
+	IPAddr    string
+	IPAddrs   []string
+	Labels    map[string]string
+	Port      int32
+	Protocol  string
+	// Ports contains all the port/protocol pairs which have to be health monitored,
+	// Port and Protocol point to the lowest of these.
+	Ports              []SvcPort
 	VirtualServiceUUID string
 	ControllerUUID     string
 	Tenant             string
+	Drained            bool
 }
 
 // GetSvcMeta returns a trimmed down version of a svc
@@ -107,10 +199,12 @@ func GetSvcMeta(svc *corev1.Service, cname string) (SvcMeta, bool) {
 		Namespace:          svc.ObjectMeta.Namespace,
 		Hostname:           hostname,
 		IPAddr:             ip,
+		IPAddrs:            GetSvcStatusIPs(svc, hostname),
 		Cluster:            cname,
 		VirtualServiceUUID: vsUUID,
 		ControllerUUID:     controllerUUID,
 		Tenant:             tenant,
+		Drained:            gslbutils.IsDrainAnnotationSet(svc.Annotations),
 	}
 	metaObj.Labels = make(map[string]string)
 	for key, value := range svc.GetLabels() {
@@ -133,6 +227,19 @@ func GetSvcMeta(svc *corev1.Service, cname string) (SvcMeta, bool) {
 	metaObj.Port = port
 	metaObj.Protocol = protocol
 
+	svcPorts, err := getSvcPorts(svc)
+	if err != nil {
+		gslbutils.Errf("cluster: %s, ns: %s, service: %s, msg: service rejected because of error: %v",
+			cname, svc.Namespace, svc.Name, err)
+		return metaObj, false
+	}
+	metaObj.Ports = svcPorts
+	if _, ok := svc.Annotations[gslbutils.HealthMonitorPortsAnnotation]; ok {
+		// the primary port must be one of the selected ports
+		metaObj.Port = svcPorts[0].Port
+		metaObj.Protocol = svcPorts[0].Protocol
+	}
+
 	return metaObj, true
 }
 
@@ -147,6 +254,21 @@ func GetSvcStatusIPHostname(svc *corev1.Service) (string, string) {
 	return ip, hostname
 }
 
+// GetSvcStatusIPs returns all the status IPs of a service for a hostname, for a dual-stack
+// service this includes both the IPv4 and IPv6 addresses.
+func GetSvcStatusIPs(svc *corev1.Service, hostname string) []string {
+	ipAddrs := []string{}
+	for _, ingr := range svc.Status.LoadBalancer.Ingress {
+		if ingr.IP == "" || ingr.Hostname != hostname {
+			continue
+		}
+		if !gslbutils.PresentInList(ingr.IP, ipAddrs) {
+			ipAddrs = append(ipAddrs, ingr.IP)
+		}
+	}
+	return ipAddrs
+}
+
 func (svc SvcMeta) GetType() string {
 	return gdpv1alpha2.LBSvcObj
 }
@@ -171,6 +293,10 @@ func (svc SvcMeta) GetIPAddr() string {
 	return svc.IPAddr
 }
 
+func (svc SvcMeta) GetIPAddrs() []string {
+	return getIPAddrsCopy(svc.IPAddr, svc.IPAddrs)
+}
+
 func (svc SvcMeta) GetPort() (int32, error) {
 	return svc.Port, nil
 }
@@ -179,6 +305,11 @@ func (svc SvcMeta) GetProtocol() (string, error) {
 	return svc.Protocol, nil
 }
 
+// GetPorts returns all the port/protocol pairs of the service which have to be health monitored.
+func (svc SvcMeta) GetPorts() []SvcPort {
+	return svc.Ports
+}
+
 func (svc SvcMeta) GetPaths() ([]string, error) {
 	return []string{}, errors.New("service object has no paths configured")
 }
@@ -199,6 +330,10 @@ func (svc SvcMeta) GetTenant() string {
 	return svc.Tenant
 }
 
+func (svc SvcMeta) IsDrained() bool {
+	return svc.Drained
+}
+
 func (svc SvcMeta) GetControllerUUID() string {
 	return svc.ControllerUUID
 }
@@ -251,12 +386,16 @@ func (svc SvcMeta) ApplyFilter() bool {
 }
 
 func (svc SvcMeta) ApplyGDPSelector() bool {
-	gf := gslbutils.GetGlobalFilter()
-	gf.GlobalLock.RLock()
-	defer gf.GlobalLock.RUnlock()
+	return len(GetSelectingGDPs(svc)) > 0
+}
+
+// ApplyGDPFilter checks whether the object is selected by the filter of a GDP object.
+func (svc SvcMeta) ApplyGDPFilter(gf *gslbutils.GDPFilter) bool {
+	gf.Lock.RLock()
+	defer gf.Lock.RUnlock()
 
-	if !gslbutils.ClusterContextPresentInList(svc.Cluster, gf.ApplicableClusters) {
-		gslbutils.Logf("objType: LBSvc, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster is not selected",
+	if !gf.IsObjInScope(svc.Cluster, svc.Namespace) {
+		gslbutils.Logf("objType: LBSvc, cluster: %s, namespace: %s, name: %s, msg: rejected because cluster or namespace is not in scope",
 			svc.Cluster, svc.Namespace, svc.Name)
 		return false
 	}
@@ -15,6 +15,7 @@
 package nodes
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -92,34 +93,60 @@ type AviGSK8sObj struct {
 	Name          string
 	Namespace     string
 	IPAddr        string
+	IPAddrs       []string
 	Weight        uint32
 	Priority      uint32
 	IsPassthrough bool
 	PublicIP      string
 	// Port and protocol will be only used by LB service
-	Port               int32
-	Proto              string
+	Port  int32
+	Proto string
+	// Ports contains all the port/protocol pairs of an LB service which have to be health monitored
+	Ports              []k8sobjects.SvcPort
 	TLS                bool
 	Paths              []string
 	VirtualServiceUUID string
 	ControllerUUID     string
 	SyncVIPOnly        bool
 	Tenant             string
+	// Location is the geo location of the member, from its cluster or the third party member
+	Location *gslbalphav1.GeoLocation
+	// Drained members are disabled in the GS, either their cluster or the object itself is drained
+	Drained bool
+	// GDPs contains the names of the GDP objects selecting this member, in the order of precedence
+	GDPs []string
 }
 
 func (gsk8sObj AviGSK8sObj) getCopy() AviGSK8sObj {
 	paths := make([]string, len(gsk8sObj.Paths))
 	copy(paths, gsk8sObj.Paths)
+	var ipAddrs []string
+	if gsk8sObj.IPAddrs != nil {
+		ipAddrs = make([]string, len(gsk8sObj.IPAddrs))
+		copy(ipAddrs, gsk8sObj.IPAddrs)
+	}
+	var ports []k8sobjects.SvcPort
+	if gsk8sObj.Ports != nil {
+		ports = make([]k8sobjects.SvcPort, len(gsk8sObj.Ports))
+		copy(ports, gsk8sObj.Ports)
+	}
+	var gdps []string
+	if gsk8sObj.GDPs != nil {
+		gdps = make([]string, len(gsk8sObj.GDPs))
+		copy(gdps, gsk8sObj.GDPs)
+	}
 	obj := AviGSK8sObj{
 		Cluster:            gsk8sObj.Cluster,
 		ObjType:            gsk8sObj.ObjType,
 		Name:               gsk8sObj.Name,
 		Namespace:          gsk8sObj.Namespace,
 		IPAddr:             gsk8sObj.IPAddr,
+		IPAddrs:            ipAddrs,
 		Weight:             gsk8sObj.Weight,
 		Priority:           gsk8sObj.Priority,
 		Port:               gsk8sObj.Port,
 		Proto:              gsk8sObj.Proto,
+		Ports:              ports,
 		TLS:                gsk8sObj.TLS,
 		Paths:              paths,
 		VirtualServiceUUID: gsk8sObj.VirtualServiceUUID,
@@ -128,10 +155,48 @@ func (gsk8sObj AviGSK8sObj) getCopy() AviGSK8sObj {
 		IsPassthrough:      gsk8sObj.IsPassthrough,
 		PublicIP:           gsk8sObj.PublicIP,
 		Tenant:             gsk8sObj.Tenant,
+		Location:           gsk8sObj.Location.DeepCopy(),
+		Drained:            gsk8sObj.Drained,
+		GDPs:               gdps,
 	}
 	return obj
 }
 
+// GetIPAddrsForFamily returns the addresses of this member which have to be added as GS pool
+// members for the ipFamily. For V4 and V6, the first address of that family is returned, and
+// for V4_V6, the first address of each family is returned. If ipFamily is empty, only the
+// primary address of the member is returned.
+func (gsk8sObj AviGSK8sObj) GetIPAddrsForFamily(ipFamily string) []string {
+	ipAddrs := []string{}
+	if ipFamily == "" {
+		if gsk8sObj.IPAddr != "" {
+			ipAddrs = append(ipAddrs, gsk8sObj.IPAddr)
+		}
+		return ipAddrs
+	}
+	memberAddrs := gsk8sObj.IPAddrs
+	if len(memberAddrs) == 0 && gsk8sObj.IPAddr != "" {
+		memberAddrs = []string{gsk8sObj.IPAddr}
+	}
+	var v4Found, v6Found bool
+	for _, addr := range memberAddrs {
+		switch gslbutils.GetIPVersion(addr) {
+		case gslbutils.IPVersionV4:
+			if v4Found || ipFamily == gslbalphav1.IPFamilyV6 {
+				continue
+			}
+			v4Found = true
+		case gslbutils.IPVersionV6:
+			if v6Found || ipFamily == gslbalphav1.IPFamilyV4 {
+				continue
+			}
+			v6Found = true
+		}
+		ipAddrs = append(ipAddrs, addr)
+	}
+	return ipAddrs
+}
+
 type PathHealthMonitorDetails struct {
 	Name            string
 	IngressProtocol string
@@ -146,12 +211,42 @@ func (pathHm PathHealthMonitorDetails) GetPathHMDescription(gsName string, templ
 	return hmDescription
 }
 
+// PortHealthMonitorDetails represents a non path health monitor for one of the additional ports of
+// a multi-port LB service.
+type PortHealthMonitorDetails struct {
+	Name       string
+	HMProtocol string
+	Port       int32
+	Protocol   string
+}
+
+func (portHm PortHealthMonitorDetails) GetPortHMDescription(gsName string) string {
+	return CreatedByAMKO + ", gsname: " + gsName + ", port: " + strconv.Itoa(int(portHm.Port)) +
+		", protocol: " + portHm.Protocol
+}
+
+func (portHm PortHealthMonitorDetails) GetChecksum(gsName string) uint32 {
+	return gslbutils.GetGSLBHmChecksum(portHm.HMProtocol, portHm.Port, []string{portHm.GetPortHMDescription(gsName)},
+		gslbutils.AMKOControlConfig().CreatedByField())
+}
+
 type HealthMonitor struct {
 	Name       string // used for non path HMs
 	HMProtocol string
 	Port       int32
 	Type       string
 	PathHM     []PathHealthMonitorDetails // used for path based HMs
+	PortHM     []PortHealthMonitorDetails // used for the additional ports of multi-port LB services
+}
+
+// GetPortHM returns the port health monitor with the name hmName, if present.
+func (hm HealthMonitor) GetPortHM(hmName string) (PortHealthMonitorDetails, bool) {
+	for _, portHm := range hm.PortHM {
+		if portHm.Name == hmName {
+			return portHm, true
+		}
+	}
+	return PortHealthMonitorDetails{}, false
 }
 
 func (hm HealthMonitor) GetHMDescription(gsName string, template *string) []string {
@@ -193,12 +288,15 @@ func (hm HealthMonitor) getChecksum(hmDescription []string) uint32 {
 func (hm HealthMonitor) getCopy() HealthMonitor {
 	pathDetails := make([]PathHealthMonitorDetails, len(hm.PathHM))
 	copy(pathDetails, hm.PathHM)
+	portDetails := make([]PortHealthMonitorDetails, len(hm.PortHM))
+	copy(portDetails, hm.PortHM)
 	hmObj := HealthMonitor{
 		Name:       hm.Name,
 		HMProtocol: hm.HMProtocol,
 		Port:       hm.Port,
 		Type:       hm.Type,
 		PathHM:     pathDetails,
+		PortHM:     portDetails,
 	}
 	return hmObj
 }
@@ -227,7 +325,10 @@ type AviGSObjectGraph struct {
 	GslbPoolAlgorithm  *gslbalphav1.PoolAlgorithmSettings
 	GslbDownResponse   *gslbalphav1.DownResponse
 	ControlPlaneHmOnly bool
-	Lock               *sync.RWMutex
+	IPFamily           string
+	// GSSettings are the GslbService properties set via the GDP and GSLBHostRule objects
+	GSSettings gslbutils.GSSettings
+	Lock       *sync.RWMutex
 }
 
 func (v *AviGSObjectGraph) SetRetryCounter(num ...int) {
@@ -264,20 +365,125 @@ func (v *AviGSObjectGraph) GetHmChecksum(hmDescription []string) uint32 {
 	return v.Hm.getChecksum(hmDescription)
 }
 
+// GetGSSettings returns the GslbService properties, with AMKO's defaults for the ones which aren't set.
+// The minimum number of health monitors up is returned for each pool, as computed by getPools. A
+// GslbService for a wildcard FQDN always has wildcard match enabled.
+func (v *AviGSObjectGraph) GetGSSettings() gslbutils.GSSettings {
+	gsSettings := v.GSSettings.WithDefaults(v.getDefaultMinHealthMonitorsUp())
+	if gslbutils.IsWildcardFqdn(v.Name) {
+		wildcardMatch := true
+		gsSettings.WildcardMatch = &wildcardMatch
+	}
+	pools, _ := v.getPools()
+	gsSettings.PoolsMinHealthMonitorsUp = []uint32{}
+	for _, pool := range pools {
+		gsSettings.PoolsMinHealthMonitorsUp = append(gsSettings.PoolsMinHealthMonitorsUp, pool.MinHealthMonitorsUp)
+	}
+	// the minimum number of health monitors up is applied per pool, so that the settings match the ones
+	// parsed from the GslbService on the controller
+	gsSettings.MinHealthMonitorsUp = nil
+	return gsSettings
+}
+
+// getDefaultMinHealthMonitorsUp returns the minimum number of health monitors up of a pool when it
+// isn't set via the GDP or GSLBHostRule objects: all the health monitors created by AMKO.
+func (v *AviGSObjectGraph) getDefaultMinHealthMonitorsUp() uint32 {
+	if v.ControlPlaneHmOnly {
+		return 1
+	}
+	// each port of a multi-port LB service has to be up for the member to be up
+	return uint32(2 + len(v.Hm.PortHM))
+}
+
+// AviGSPool is a pool of a GslbService, with the members of one priority.
+type AviGSPool struct {
+	Priority            uint32
+	MinHealthMonitorsUp uint32
+	Members             []AviGSK8sObj
+}
+
+// GetPools returns the pools of the GslbService, the highest priority first. The members which can't be
+// added to a pool are logged.
+func (v *AviGSObjectGraph) GetPools(key string) []AviGSPool {
+	v.Lock.RLock()
+	defer v.Lock.RUnlock()
+
+	pools, skipped := v.getPools()
+	for _, m := range skipped {
+		gslbutils.Warnf("key: %s, cluster: %s, namespace: %s, member: %s, msg: no IP address for ip family %s in %v",
+			key, m.Cluster, m.Namespace, m.Name, v.IPFamily, m.IPAddrs)
+	}
+	return pools
+}
+
+// getPools groups the unique members of the GslbService by priority, the highest priority first, and
+// returns the members without an IP address for the GslbService's IP family separately. Unless set, the
+// minimum number of health monitors up of a pool is the default one, and the first pool of a GslbService
+// with custom health monitors needs one more than the number of custom health monitors. This is the only
+// place where the pools are computed, both the rest layer and the GS settings checksum use it.
+func (v *AviGSObjectGraph) getPools() ([]AviGSPool, []AviGSK8sObj) {
+	var memberVips []string
+	var skipped []AviGSK8sObj
+	membersByPriority := map[uint32][]AviGSK8sObj{}
+	for _, member := range v.MemberObjs {
+		if member.IPAddr == "" || gslbutils.PresentInList(member.IPAddr, memberVips) {
+			continue
+		}
+		memberVips = append(memberVips, member.IPAddr)
+		if len(member.GetIPAddrsForFamily(v.IPFamily)) == 0 {
+			skipped = append(skipped, member.getCopy())
+			continue
+		}
+		membersByPriority[member.Priority] = append(membersByPriority[member.Priority], member.getCopy())
+	}
+	priorities := []uint32{}
+	for priority := range membersByPriority {
+		priorities = append(priorities, priority)
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] > priorities[j] })
+
+	minHmUp := v.getDefaultMinHealthMonitorsUp()
+	if v.GSSettings.MinHealthMonitorsUp != nil {
+		minHmUp = *v.GSSettings.MinHealthMonitorsUp
+	}
+	pools := make([]AviGSPool, 0, len(priorities))
+	for idx, priority := range priorities {
+		poolMinHmUp := minHmUp
+		if idx == 0 && v.GSSettings.MinHealthMonitorsUp == nil && len(v.HmRefs) > 0 {
+			poolMinHmUp = uint32(len(v.HmRefs) + 1)
+		}
+		pools = append(pools, AviGSPool{
+			Priority:            priority,
+			MinHealthMonitorsUp: poolMinHmUp,
+			Members:             membersByPriority[priority],
+		})
+	}
+	return pools, skipped
+}
+
 func (v *AviGSObjectGraph) CalculateChecksum() {
 	// A sum of fields for this GS
 	var memberObjs []string
 	var memberAddrs []string
+	var memberIPs []string
 
 	for _, gsMember := range v.MemberObjs {
-		var server string
-		if !gsMember.SyncVIPOnly {
-			server = gsMember.VirtualServiceUUID + "-" + gsMember.ControllerUUID
-		} else {
-			server = gsMember.IPAddr
+		// a dual-stack member results in one GS pool member per address family
+		for _, ipAddr := range gsMember.GetIPAddrsForFamily(v.IPFamily) {
+			memberIPs = append(memberIPs, ipAddr)
+			var server string
+			if !gsMember.SyncVIPOnly {
+				server = gsMember.VirtualServiceUUID + "-" + gsMember.ControllerUUID
+			} else {
+				server = ipAddr
+			}
+			memberAddr := server + "-" + strconv.Itoa(int(gsMember.Weight)) +
+				"-" + strconv.Itoa(int(gsMember.Priority)) + "-" + gsMember.PublicIP + "-" + gslbutils.GetGeoLocationKey(gsMember.Location)
+			if gsMember.Drained {
+				memberAddr += "-drained"
+			}
+			memberAddrs = append(memberAddrs, memberAddr)
 		}
-		memberAddrs = append(memberAddrs, server+"-"+strconv.Itoa(int(gsMember.Weight))+
-			"-"+strconv.Itoa(int(gsMember.Priority))+"-"+gsMember.PublicIP)
 		if gsMember.ObjType == gslbutils.ThirdPartyMemberType {
 			continue
 		}
@@ -289,6 +495,9 @@ func (v *AviGSObjectGraph) CalculateChecksum() {
 		if len(v.HmRefs) == 0 {
 			if v.Hm.Name != "" {
 				hmNames = append(hmNames, v.Hm.Name)
+				for _, portHm := range v.Hm.PortHM {
+					hmNames = append(hmNames, portHm.Name)
+				}
 			} else {
 				hmNames = v.GetHmPathNamesList()
 			}
@@ -299,8 +508,10 @@ func (v *AviGSObjectGraph) CalculateChecksum() {
 	}
 
 	v.GraphChecksum = gslbutils.GetGSLBServiceChecksum(memberAddrs, v.DomainNames, memberObjs, hmNames,
-		v.SitePersistenceRef, v.TTL, v.GslbPoolAlgorithm, v.GslbDownResponse, v.PkiProfileRef, gslbutils.AMKOControlConfig().CreatedByField())
+		v.SitePersistenceRef, v.TTL, v.GslbPoolAlgorithm, v.GslbDownResponse, v.PkiProfileRef, v.GetGSSettings(),
+		gslbutils.AMKOControlConfig().CreatedByField())
 	v.GraphChecksum += utils.Hash(utils.Stringify(v.ControlPlaneHmOnly))
+	v.GraphChecksum += gslbutils.GetChecksumForIPFamily(memberIPs)
 }
 
 // GetMemberRouteList returns a list of member objects
@@ -346,8 +557,9 @@ func (v *AviGSObjectGraph) buildHmPathList() {
 			ifSec = true
 		}
 	}
-	// clear out all path based HM names first
+	// clear out all path based and port based HM names first
 	v.Hm.PathHM = make([]PathHealthMonitorDetails, 0)
+	v.Hm.PortHM = make([]PortHealthMonitorDetails, 0)
 
 	// add the member paths
 	for _, member := range v.MemberObjs {
@@ -372,6 +584,60 @@ func (v *AviGSObjectGraph) BuildNonPathHmName(gsName string) string {
 	return ""
 }
 
+func (v *AviGSObjectGraph) BuildPortHmName(gsName string, port int32, protocol string) string {
+	encodedHMName := gslbutils.EncodeHMName(gsName + "--" + strings.ToLower(protocol) + "--" + strconv.Itoa(int(port)))
+	if gslbutils.CheckNameLength(encodedHMName, HmNamePrefix) {
+		return HmNamePrefix + encodedHMName
+	}
+	gslbutils.Errf("hm: %s, port: %d, protocol: %s, msg: hm name could not be encoded", gsName, port, protocol)
+	return ""
+}
+
+// buildPortHmList builds a health monitor for each port of the LB service members, other than the
+// port which is already monitored by the non path health monitor of this GS.
+func (v *AviGSObjectGraph) buildPortHmList() {
+	v.Hm.PortHM = make([]PortHealthMonitorDetails, 0)
+	if v.Hm.Name == "" || v.Hm.Name == gslbutils.SystemGslbHealthMonitorPassthrough+gslbutils.AMKOControlConfig().GetAMKOUUID() {
+		return
+	}
+	for _, member := range v.MemberObjs {
+		if member.ObjType != gslbutils.SvcType {
+			continue
+		}
+		for _, svcPort := range member.Ports {
+			hmProtocol, err := gslbutils.GetHmTypeForProtocol(svcPort.Protocol)
+			if err != nil {
+				gslbutils.Errf("gsName: %s, port: %d, protocol: %s, msg: can't build a health monitor for port: %v",
+					v.Name, svcPort.Port, svcPort.Protocol, err)
+				continue
+			}
+			if svcPort.Port == v.Hm.Port && hmProtocol == v.Hm.HMProtocol {
+				continue
+			}
+			hmName := v.BuildPortHmName(v.Name, svcPort.Port, svcPort.Protocol)
+			if hmName == "" {
+				continue
+			}
+			if _, exists := v.Hm.GetPortHM(hmName); exists {
+				continue
+			}
+			v.Hm.PortHM = append(v.Hm.PortHM, PortHealthMonitorDetails{
+				Name:       hmName,
+				HMProtocol: hmProtocol,
+				Port:       svcPort.Port,
+				Protocol:   svcPort.Protocol,
+			})
+		}
+	}
+	sort.Slice(v.Hm.PortHM, func(i, j int) bool {
+		if v.Hm.PortHM[i].Port == v.Hm.PortHM[j].Port {
+			return v.Hm.PortHM[i].Protocol < v.Hm.PortHM[j].Protocol
+		}
+		return v.Hm.PortHM[i].Port < v.Hm.PortHM[j].Port
+	})
+	gslbutils.Debugf("gsName: %s, portHMList: %v, msg: rebuilt port list for GS", v.Name, v.Hm.PortHM)
+}
+
 func (v *AviGSObjectGraph) buildNonPathHealthMonitorFromObj(port int32, isPassthrough bool, protocol, key string) {
 	hmName := ""
 	if isPassthrough {
@@ -393,6 +659,7 @@ func (v *AviGSObjectGraph) buildNonPathHealthMonitorFromObj(port int32, isPassth
 	}
 	v.MemberObjs[0].Port = port
 	v.MemberObjs[0].Proto = protocol
+	v.buildPortHmList()
 }
 
 /* func (v *AviGSObjectGraph) buildNonPathHealthMonitor(metaObj k8sobjects.MetaObject, key string) {
@@ -594,6 +861,7 @@ func (v *AviGSObjectGraph) checkAndUpdateNonPathHealthMonitor(objType string, is
 	v.Hm.Port = newPort
 	v.Hm.HMProtocol = hmProtocol
 	v.Hm.Type = NonPathHM
+	v.buildPortHmList()
 }
 
 func (v *AviGSObjectGraph) updateGSHmPathListAndProtocol() {
@@ -632,6 +900,33 @@ func (v *AviGSObjectGraph) SetPropertiesForGS(gsFqdn string, tls bool) {
 func (v *AviGSObjectGraph) AddUpdateGSMember(newMember AviGSK8sObj) bool {
 	v.SetPropertiesForGS(v.Name, newMember.TLS)
 
+	deleteMember := v.addUpdateGSMember(newMember)
+	if v.isGDPListChanged() {
+		// the new member is selected by a different set of GDP objects, re-evaluate the GS properties
+		v.SetPropertiesForGS(v.Name, newMember.TLS)
+	}
+	return deleteMember
+}
+
+// isGDPListChanged returns true if the GDP objects selecting the GS members are different from
+// the GDP objects with which the GS properties were last evaluated.
+func (v *AviGSObjectGraph) isGDPListChanged() bool {
+	v.Lock.RLock()
+	defer v.Lock.RUnlock()
+	gdps := getGDPsForMembers(v.MemberObjs)
+	oldGDPs := gslbutils.GetGSGDPMap().GetGDPsForGS(v.Name)
+	if len(gdps) != len(oldGDPs) {
+		return true
+	}
+	for idx := range gdps {
+		if gdps[idx] != oldGDPs[idx] {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *AviGSObjectGraph) addUpdateGSMember(newMember AviGSK8sObj) bool {
 	v.Lock.Lock()
 	defer v.Lock.Unlock()
 
@@ -726,6 +1021,10 @@ func (v *AviGSObjectGraph) DeleteMember(cname, ns, name, objType string) {
 	if len(v.MemberObjs) == 0 {
 		return
 	}
+	if gslbutils.GetGSGDPMap().SetGDPsForGS(v.Name, getGDPsForMembers(v.MemberObjs)) {
+		// the deleted member was the only member selected by a GDP object, re-evaluate the GS properties
+		setGSLBPropertiesForGS(v.Name, v, false, v.MemberObjs[0].TLS)
+	}
 
 	// If HostRule object is deleted -> need to update domain names (in case the deleted hostrule had an aliases)
 	v.DomainNames = DeriveGSLBServiceDomainNames(v.Name)
@@ -762,6 +1061,24 @@ func (v *AviGSObjectGraph) GetHmPathNamesList() []string {
 	return hmNameList
 }
 
+// GetHmNames returns the names of the non path, path based and port health monitors built for the
+// GslbService.
+func (v *AviGSObjectGraph) GetHmNames() []string {
+	v.Lock.RLock()
+	defer v.Lock.RUnlock()
+	var hmNames []string
+	if v.Hm.Name != "" {
+		hmNames = append(hmNames, v.Hm.Name)
+	}
+	for _, pathHm := range v.Hm.PathHM {
+		hmNames = append(hmNames, pathHm.Name)
+	}
+	for _, portHm := range v.Hm.PortHM {
+		hmNames = append(hmNames, portHm.Name)
+	}
+	return hmNames
+}
+
 func (v *AviGSObjectGraph) MembersLen() int {
 	v.Lock.RLock()
 	defer v.Lock.RUnlock()
@@ -816,6 +1133,7 @@ func (v *AviGSObjectGraph) GetUniqueMemberObjs() []AviGSK8sObj {
 			Name:               memberObj.Name,
 			Namespace:          memberObj.Namespace,
 			IPAddr:             memberObj.IPAddr,
+			IPAddrs:            memberObj.IPAddrs,
 			Weight:             memberObj.Weight,
 			Priority:           memberObj.Priority,
 			ControllerUUID:     memberObj.ControllerUUID,
@@ -823,6 +1141,8 @@ func (v *AviGSObjectGraph) GetUniqueMemberObjs() []AviGSK8sObj {
 			SyncVIPOnly:        memberObj.SyncVIPOnly,
 			PublicIP:           memberObj.PublicIP,
 			Tenant:             memberObj.Tenant,
+			Location:           memberObj.Location.DeepCopy(),
+			Drained:            memberObj.Drained,
 		})
 		memberVips = append(memberVips, memberObj.IPAddr)
 	}
@@ -844,6 +1164,8 @@ func (v *AviGSObjectGraph) GetCopy() *AviGSObjectGraph {
 		RetryCount:         v.RetryCount,
 		Hm:                 v.Hm.getCopy(),
 		ControlPlaneHmOnly: v.ControlPlaneHmOnly,
+		IPFamily:           v.IPFamily,
+		GSSettings:         v.GSSettings.DeepCopy(),
 	}
 	var ttl uint32
 	if v.TTL != nil {
@@ -883,25 +1205,37 @@ func BuildGSMemberObjFromMeta(metaObj k8sobjects.MetaObject, gsFqdn string, gsDo
 	ns := metaObj.GetNamespace()
 	objType := metaObj.GetType()
 	gf := gslbutils.GetGlobalFilter()
+	var gdps []string
+	if selectableObj, ok := metaObj.(k8sobjects.GDPSelectableObject); ok {
+		gdps = k8sobjects.GetSelectingGDPs(selectableObj)
+	}
 
 	gsHostRuleList := gslbutils.GetGSHostRulesList()
 	if ghRulesForFqdn := gsHostRuleList.GetGSHostRulesForFQDN(gsFqdn); ghRulesForFqdn != nil {
 		ghRulesForFqdn.DeepCopyInto(&ghRules)
 	}
+	gsHostRuleList.ApplyTrafficShift(gsFqdn, &ghRules)
 
-	// determine the GS member's weight
-	for _, c := range ghRules.TrafficSplit {
-		if c.Cluster == cname {
-			weight = int32(c.Weight)
-			priority = int32(c.Priority)
+	// determine the GS member's weight, the weight for the member object takes precedence over the
+	// weight for the member cluster
+	if memberSplit := gslbutils.GetMemberTrafficSplit(ghRules.MemberTrafficSplit, cname, ns, objType,
+		metaObj.GetName()); memberSplit != nil {
+		weight = int32(memberSplit.Weight)
+		priority = int32(memberSplit.Priority)
+	} else {
+		for _, c := range ghRules.TrafficSplit {
+			if c.Cluster == cname {
+				weight = int32(c.Weight)
+				priority = int32(c.Priority)
+			}
 		}
 	}
 	if weight == -1 {
-		weight = int32(GetObjTrafficRatio(ns, cname))
+		weight = int32(GetObjTrafficRatio(ns, cname, gdps...))
 	}
 
 	if priority == -1 {
-		priority = int32(GetObjTrafficPriority(ns, cname))
+		priority = int32(GetObjTrafficPriority(ns, cname, gdps...))
 	}
 	// determine the GS member's PublicIP
 	for _, c := range ghRules.PublicIP {
@@ -919,25 +1253,41 @@ func BuildGSMemberObjFromMeta(metaObj k8sobjects.MetaObject, gsFqdn string, gsDo
 		svcPort, _ = metaObj.GetPort()
 		svcProtocol, _ = metaObj.GetProtocol()
 	}
+	var svcPorts []k8sobjects.SvcPort
+	if svcMeta, ok := metaObj.(k8sobjects.SvcMeta); ok {
+		svcPorts = svcMeta.GetPorts()
+	}
 
-	syncVIPOnly, err := gf.IsClusterSyncVIPOnly(cname)
+	syncVIPOnly, err := gf.IsClusterSyncVIPOnly(cname, gdps...)
 	if err != nil {
 		gslbutils.Errf("gsName: %s, cluster: %s, msg: couldn't find the sync type for member: %v",
 			gsFqdn, cname, err)
 	}
 
 	tls, _ := getTLSFromObj(metaObj)
+	location := gf.GetClusterLocation(cname, gdps...)
+	if location == nil && syncVIPOnly {
+		// a member synced as a VIP only doesn't inherit a location from its site, so, the region of the
+		// cluster's nodes is used, if opted in. A region only location has no coordinates, it only
+		// serves the topology based pool algorithm.
+		if region := gslbutils.GetClusterRegion(cname); region != "" {
+			location = &gslbalphav1.GeoLocation{Region: region}
+		}
+	}
+	drained := metaObj.IsDrained() || gslbutils.IsMemberClusterDrained(cname) || gf.IsClusterDrained(cname, gdps...)
 
 	return AviGSK8sObj{
 		Cluster:            cname,
 		Namespace:          ns,
 		Name:               metaObj.GetName(),
 		IPAddr:             metaObj.GetIPAddr(),
+		IPAddrs:            metaObj.GetIPAddrs(),
 		Weight:             uint32(weight),
 		Priority:           uint32(priority),
 		ObjType:            objType,
 		Port:               svcPort,
 		Proto:              svcProtocol,
+		Ports:              svcPorts,
 		Paths:              paths,
 		VirtualServiceUUID: metaObj.GetVirtualServiceUUID(),
 		ControllerUUID:     metaObj.GetControllerUUID(),
@@ -946,6 +1296,9 @@ func BuildGSMemberObjFromMeta(metaObj k8sobjects.MetaObject, gsFqdn string, gsDo
 		TLS:                tls,
 		PublicIP:           publicIP,
 		Tenant:             metaObj.GetTenant(),
+		Location:           location,
+		Drained:            drained,
+		GDPs:               gdps,
 	}, nil
 }
 
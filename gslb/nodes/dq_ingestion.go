@@ -66,14 +66,16 @@ func PublishKeyToRestLayer(tenant, gsName, key string, sharedQueue *utils.Worker
 	gslbutils.Logf("key: %s, modelName: %s, bkt: %d, msg: %s", key, modelName, bkt, "published key to rest layer")
 }
 
-func GetObjTrafficRatio(ns, cname string) uint32 {
+// GetObjTrafficRatio returns the traffic ratio of a cluster from the GDP objects in gdps (all the GDP
+// objects if gdps is empty).
+func GetObjTrafficRatio(ns, cname string, gdps ...string) uint32 {
 	globalFilter := gslbutils.GetGlobalFilter()
 	if globalFilter == nil {
 		// return default traffic ratio
 		gslbutils.Errf("ns: %s, cname: %s, msg: global filter can't be nil at this stage", ns, cname)
 		return 1
 	}
-	val, err := globalFilter.GetTrafficWeight(cname)
+	val, err := globalFilter.GetTrafficWeight(cname, gdps...)
 	if err != nil {
 		gslbutils.Warnf("ns: %s, cname: %s, msg: error occurred while fetching traffic info for this cluster, %s",
 			ns, cname, err.Error())
@@ -82,14 +84,16 @@ func GetObjTrafficRatio(ns, cname string) uint32 {
 	return val
 }
 
-func GetObjTrafficPriority(ns, cname string) uint32 {
+// GetObjTrafficPriority returns the priority of a cluster from the GDP objects in gdps (all the GDP
+// objects if gdps is empty).
+func GetObjTrafficPriority(ns, cname string, gdps ...string) uint32 {
 	globalFilter := gslbutils.GetGlobalFilter()
 	if globalFilter == nil {
 		// return default priority
 		gslbutils.Errf("ns: %s, cname: %s, msg: global filter can't be nil at this stage", ns, cname)
 		return 10
 	}
-	val, err := globalFilter.GetTrafficPriority(cname)
+	val, err := globalFilter.GetTrafficPriority(cname, gdps...)
 	if err != nil {
 		gslbutils.Warnf("ns: %s, cname: %s, msg: error occurred while fetching traffic priority info for this cluster, %s",
 			ns, cname, err.Error())
@@ -144,6 +148,16 @@ func getObjFromStore(objType, cname, ns, objName, key, storeType string) interfa
 			gslbutils.Errf("key: %s, msg: %s", key, "accepted/rejected multi-cluster ingress store is empty, can't add/delete multi-cluster ingress")
 			return nil
 		}
+	case gslbutils.HTTPRouteType:
+		if storeType == gslbutils.AcceptedStore {
+			cstore = store.GetAcceptedHTTPRouteStore()
+		} else {
+			cstore = store.GetRejectedHTTPRouteStore()
+		}
+		if cstore == nil {
+			gslbutils.Errf("key: %s, msg: %s", key, "accepted/rejected httproute store is empty, can't add/delete httproute")
+			return nil
+		}
 	}
 	obj, ok := cstore.GetClusterNSObjectByName(cname, ns, objName)
 	if !ok {
@@ -284,7 +298,7 @@ func AddUpdateObjOperation(key, cname, ns, objType, objName string, wq *utils.Wo
 			return
 		}
 	}
-	gsName, err := DeriveGSLBServiceName(metaObj.GetHostname(), metaObj.GetCluster())
+	gsName, err := DeriveGSLBServiceName(k8sobjects.GetGSHostname(metaObj), metaObj.GetCluster())
 	if err != nil {
 		gslbutils.Errf("key: %s, msg: failed to derive GSLB service name: %v", key, err)
 		return
@@ -344,6 +358,8 @@ func GetNewObj(objType string) (k8sobjects.MetaObject, error) {
 		return k8sobjects.SvcMeta{}, nil
 	case gslbutils.MCIType:
 		return k8sobjects.MultiClusterIngressHostMeta{}, nil
+	case gslbutils.HTTPRouteType:
+		return k8sobjects.HTTPRouteHostMeta{}, nil
 	default:
 		return nil, errors.New("unrecognised object: " + objType)
 	}
@@ -405,6 +421,7 @@ func deleteObjOperation(key, cname, ns, objType, objName, tenant string, wq *uti
 		// add the object to the delete cache and remove from the model cache
 		SharedDeleteGSGraphLister().Save(modelName, aviGS)
 		SharedAviGSGraphLister().Delete(modelName)
+		gslbutils.GetGSGDPMap().DeleteGS(gsName)
 	} else {
 		SharedAviGSGraphLister().Save(modelName, aviGS)
 	}
@@ -446,6 +463,7 @@ func DeleteGSOrGSMembers(aviGSGraph *AviGSObjectGraph, members []AviGSK8sObj, mo
 	if len(aviGSGraph.GetUniqueMemberObjs()) == 0 {
 		SharedDeleteGSGraphLister().Save(modelName, aviGSGraph)
 		agl.Delete(modelName)
+		gslbutils.GetGSGDPMap().DeleteGS(gsName)
 	} else {
 		agl.Save(gsName, aviGSGraph)
 	}
@@ -556,7 +574,7 @@ func DequeueIngestion(key string) {
 		return
 	}
 	switch objType {
-	case gslbutils.RouteType, gslbutils.IngressType, gslbutils.SvcType, gslbutils.MCIType:
+	case gslbutils.RouteType, gslbutils.IngressType, gslbutils.SvcType, gslbutils.MCIType, gslbutils.HTTPRouteType:
 		OperateOnK8sObject(key)
 	case gslbutils.GSFQDNType:
 		OperateOnGSLBHostRule(key)
@@ -31,7 +31,7 @@ import (
 //     We enable Site Persistence on the GS object and set the provided ref as the persistence ref.
 //  3. GSLBHostRule doesn't contain Site Persistence, we inherit the Site Persistence properties from
 //     the Global filter (GDP object).
-func getSitePersistence(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GlobalFilter) *string {
+func getSitePersistence(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GDPFilter) *string {
 	if gsRuleExists && gsRule.SitePersistence != nil {
 		if gsRule.SitePersistence.Enabled {
 			ref := gsRule.SitePersistence.ProfileRef
@@ -43,7 +43,7 @@ func getSitePersistence(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gs
 	return gf.GetSitePersistence()
 }
 
-func getPKIProfile(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GlobalFilter) *string {
+func getPKIProfile(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GDPFilter) *string {
 	if gsRuleExists && gsRule.SitePersistence != nil {
 		if gsRule.SitePersistence.Enabled {
 			if gsRule.SitePersistence.PKIProfileRef != nil {
@@ -60,15 +60,32 @@ func getPKIProfile(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbuti
 // getGslbPoolAlgorithm returns the applicable algorithn settings for a GS object. Two conditions:
 // 1. If the GSLBHostRule has the pool algorithm settings defined, we return that.
 // 2. If no settings defined in the GSLBHostRule (i.e., value is nil), we return the GDP object's settings.
-func getGslbPoolAlgorithm(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GlobalFilter) *gslbalphav1.PoolAlgorithmSettings {
+func getGslbPoolAlgorithm(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GDPFilter) *gslbalphav1.PoolAlgorithmSettings {
 	if gsRuleExists && gsRule.GslbPoolAlgorithm != nil {
 		return gsRule.GslbPoolAlgorithm
 	}
 	return gf.GetGslbPoolAlgorithm()
 }
 
+// getGDPsForMembers returns the names of all the GDP objects selecting the members of a GS, in
+// the order of precedence.
+func getGDPsForMembers(members []AviGSK8sObj) []string {
+	gdps := []string{}
+	for _, member := range members {
+		for _, gdp := range member.GDPs {
+			if !gslbutils.PresentInList(gdp, gdps) {
+				gdps = append(gdps, gdp)
+			}
+		}
+	}
+	return gslbutils.GetGlobalFilter().SortByPrecedence(gdps)
+}
+
 func setGSLBPropertiesForGS(gsFqdn string, gsGraph *AviGSObjectGraph, newObj bool, tls bool) {
-	gf := gslbutils.GetGlobalFilter()
+	// the GDP properties are merged from all the GDP objects selecting the members of this GS
+	gdps := getGDPsForMembers(gsGraph.MemberObjs)
+	gslbutils.GetGSGDPMap().SetGDPsForGS(gsFqdn, gdps)
+	gf := gslbutils.GetGlobalFilter().GetMergedFilter(gdps)
 	// check if a GSLB Host Rule has been defined for this fqdn (gsName)
 	gsHostRuleList := gslbutils.GetGSHostRulesList()
 	var gsRule gslbutils.GSHostRules
@@ -79,6 +96,10 @@ func setGSLBPropertiesForGS(gsFqdn string, gsGraph *AviGSObjectGraph, newObj boo
 		ghRulesForFqdn.DeepCopyInto(&gsRule)
 		gsRuleExists = true
 	}
+	// the traffic split of a GSLBTrafficShift object takes precedence over the GSLBHostRule's
+	if gsHostRuleList.ApplyTrafficShift(gsFqdn, &gsRule) {
+		gsRuleExists = true
+	}
 
 	if gsRuleExists && gsRule.TTL != nil {
 		gsGraph.TTL = gsRule.TTL
@@ -134,6 +155,7 @@ func setGSLBPropertiesForGS(gsFqdn string, gsGraph *AviGSObjectGraph, newObj boo
 					Name:        tpm.Site,
 					PublicIP:    tpm.PublicIP,
 					SyncVIPOnly: true,
+					Location:    tpm.Location.DeepCopy(),
 				}
 				// weight of a third party member is decided only by a GSLBHostRule, and not
 				// by the GDP object. So, if there's no weight given in the GSLBHostRule, the
@@ -161,8 +183,10 @@ func setGSLBPropertiesForGS(gsFqdn string, gsGraph *AviGSObjectGraph, newObj boo
 			gsGraph.MemberObjs[idx].Weight = getThirdPartyMemberWeight(weightMap, member.Name)
 			gsGraph.MemberObjs[idx].Priority = getThirdPartyMemberPriority(priorityMap, member.Name)
 		} else {
-			gsGraph.MemberObjs[idx].Weight = getK8sMemberWeight(weightMap, member.Cluster, member.Namespace)
-			gsGraph.MemberObjs[idx].Priority = getK8sMemberPriority(priorityMap, member.Cluster, member.Namespace)
+			memberSplit := gslbutils.GetMemberTrafficSplit(gsRule.MemberTrafficSplit, member.Cluster, member.Namespace,
+				member.ObjType, member.Name)
+			gsGraph.MemberObjs[idx].Weight = getK8sMemberWeight(weightMap, memberSplit, member)
+			gsGraph.MemberObjs[idx].Priority = getK8sMemberPriority(priorityMap, memberSplit, member)
 			gsGraph.MemberObjs[idx].PublicIP = getMemberPublicIP(publicIPMap, member.Cluster)
 		}
 	}
@@ -172,6 +196,27 @@ func setGSLBPropertiesForGS(gsFqdn string, gsGraph *AviGSObjectGraph, newObj boo
 	} else {
 		gsGraph.GslbDownResponse = gf.GetDownResponse()
 	}
+
+	gsGraph.IPFamily = getIPFamily(gsRuleExists, &gsRule, gf)
+
+	// a GSLBHostRule's settings take precedence over the GDP object's settings, field by field
+	gsGraph.GSSettings = gslbutils.GSSettings{}
+	if gsRuleExists {
+		gsGraph.GSSettings = gsRule.GSSettings.DeepCopy()
+	}
+	gsGraph.GSSettings.Merge(gf.GetGSSettings())
+}
+
+// getIPFamily returns the address family of the GS pool members, a GSLBHostRule's ipFamily
+// takes precedence over the GDP object's ipFamily.
+func getIPFamily(gsRuleExists bool, gsRule *gslbutils.GSHostRules, gf *gslbutils.GDPFilter) string {
+	if gsRuleExists && gsRule.IPFamily != nil {
+		return *gsRule.IPFamily
+	}
+	if ipFamily := gf.GetIPFamily(); ipFamily != nil {
+		return *ipFamily
+	}
+	return ""
 }
 
 func getMemberPublicIP(publicIPMap map[string]string, site string) string {
@@ -195,18 +240,30 @@ func getThirdPartyMemberPriority(priorityMap map[string]uint32, site string) uin
 	return 1
 }
 
-func getK8sMemberWeight(ghrWeightMap map[string]uint32, cname, ns string) uint32 {
-	if weight, ok := ghrWeightMap[cname]; ok {
+// getK8sMemberWeight returns the weight of a k8s member, the GSLBHostRule's weight for the member object
+// takes precedence over the GSLBHostRule's weight for the member cluster, which in turn takes precedence
+// over the GDP's weight.
+func getK8sMemberWeight(ghrWeightMap map[string]uint32, memberSplit *v1alpha1.MemberTrafficSplitElem,
+	member AviGSK8sObj) uint32 {
+	if memberSplit != nil {
+		return memberSplit.Weight
+	}
+	if weight, ok := ghrWeightMap[member.Cluster]; ok {
 		return weight
 	}
-	return GetObjTrafficRatio(ns, cname)
+	return GetObjTrafficRatio(member.Namespace, member.Cluster, member.GDPs...)
 }
 
-func getK8sMemberPriority(ghrPriorityMap map[string]uint32, cname, ns string) uint32 {
-	if priority, ok := ghrPriorityMap[cname]; ok {
+// getK8sMemberPriority returns the priority of a k8s member, with the same precedence as getK8sMemberWeight.
+func getK8sMemberPriority(ghrPriorityMap map[string]uint32, memberSplit *v1alpha1.MemberTrafficSplitElem,
+	member AviGSK8sObj) uint32 {
+	if memberSplit != nil {
+		return memberSplit.Priority
+	}
+	if priority, ok := ghrPriorityMap[member.Cluster]; ok {
 		return priority
 	}
-	return GetObjTrafficPriority(ns, cname)
+	return GetObjTrafficPriority(member.Namespace, member.Cluster, member.GDPs...)
 }
 
 func updateThirdPartyMembers(gsGraph *AviGSObjectGraph, thirdPartyMembers []v1alpha1.ThirdPartyMember) {
@@ -214,9 +271,11 @@ func updateThirdPartyMembers(gsGraph *AviGSObjectGraph, thirdPartyMembers []v1al
 	gslbutils.Logf("gs members before update: %v", gsGraph.MemberObjs)
 	existingMembers := make(map[string]struct{})
 	newMembers := make(map[string]bool)
+	locations := make(map[string]*v1alpha1.GeoLocation)
 
 	for _, tpm := range thirdPartyMembers {
 		newMembers[tpm.Site+"/"+tpm.VIP+"/"+tpm.PublicIP] = false
+		locations[tpm.Site+"/"+tpm.VIP+"/"+tpm.PublicIP] = tpm.Location
 	}
 	// find any existing member which is supposed to be deleted
 	for idx, member := range gsGraph.MemberObjs {
@@ -232,6 +291,7 @@ func updateThirdPartyMembers(gsGraph *AviGSObjectGraph, thirdPartyMembers []v1al
 		} else {
 			// true indicates that this new member is already present in the existing members list
 			newMembers[siteIP] = true
+			gsGraph.MemberObjs[idx].Location = locations[siteIP].DeepCopy()
 		}
 	}
 
@@ -248,6 +308,7 @@ func updateThirdPartyMembers(gsGraph *AviGSObjectGraph, thirdPartyMembers []v1al
 			Name:        site,
 			SyncVIPOnly: true,
 			PublicIP:    PubIP,
+			Location:    locations[k].DeepCopy(),
 		}
 		gsGraph.MemberObjs = append(gsGraph.MemberObjs, memberObj)
 	}
@@ -273,6 +334,22 @@ func GetDescriptionForPathHMName(hmName string, gsMeta *AviGSObjectGraph) string
 	return ""
 }
 
+// IsPortHmDescription returns true if the HM description belongs to an HM created for one of the
+// additional ports of a multi-port LB service.
+func IsPortHmDescription(hmDescription string) bool {
+	return strings.HasPrefix(hmDescription, CreatedByAMKO+", gsname: ") && strings.Contains(hmDescription, ", port: ")
+}
+
+// GetGSNameFromHmDescription returns the GslbService name from the description of a health monitor
+// created by AMKO, "created by: amko, gsname: <gsName>, ...". Returns an empty string if not found.
+func GetGSNameFromHmDescription(hmDescription string) string {
+	descSplit := strings.Split(hmDescription, "gsname: ")
+	if len(descSplit) != 2 {
+		return ""
+	}
+	return strings.TrimSpace(strings.Split(descSplit[1], ",")[0])
+}
+
 func GetPathFromHmDescription(hmName, hmDescription string) string {
 	hmDescriptionSplit := strings.Split(hmDescription, ": ")
 	if len(hmDescriptionSplit) != 5 &&
@@ -0,0 +1,308 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+// gsAdopter takes ownership of the GslbServices created outside of AMKO, whose domain names match
+// the ones of a GslbService to be created by AMKO. A GslbService is adopted only if its domain names
+// and members are a subset of the ones built by AMKO, so that adopting it doesn't remove any DNS
+// records. The ones which can't be adopted are reported in the GSLBConfig status.
+type gsAdopter struct {
+	lock    sync.Mutex
+	enabled bool
+	// existingGSs are the GslbServices not created by this AMKO instance, fetched from the controller
+	// when required, and dropped periodically and after a domain name conflict
+	existingGSs []avicache.AviUnmanagedGS
+	fetched     bool
+	// conflicts are the GslbServices which couldn't be adopted, keyed by the GS key
+	conflicts map[string]gslbalphav1.AdoptionConflict
+	// prevConflicts are the conflicts last published to the GSLBConfig status
+	prevConflicts []gslbalphav1.AdoptionConflict
+}
+
+var gsAdoption = &gsAdopter{
+	conflicts: make(map[string]gslbalphav1.AdoptionConflict),
+}
+
+// SetGSAdoption enables or disables the adoption of the existing GslbServices.
+func SetGSAdoption(enabled bool) {
+	a := gsAdoption
+	a.lock.Lock()
+	a.enabled = enabled
+	a.existingGSs = nil
+	a.fetched = false
+	if !enabled {
+		a.conflicts = make(map[string]gslbalphav1.AdoptionConflict)
+	}
+	a.lock.Unlock()
+	gslbutils.Logf("enabled: %v, msg: adoption of the existing GslbServices set", enabled)
+	a.publishConflicts()
+}
+
+// GetAdoptionConflicts returns the existing GslbServices which couldn't be adopted.
+func GetAdoptionConflicts() []gslbalphav1.AdoptionConflict {
+	a := gsAdoption
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.conflictList()
+}
+
+// RetryAdoptionConflicts drops the fetched GslbServices and publishes the keys of the GslbServices
+// with conflicts to the rest layer, to check them again. To be called periodically.
+func RetryAdoptionConflicts() {
+	a := gsAdoption
+	a.lock.Lock()
+	if !a.enabled {
+		a.lock.Unlock()
+		return
+	}
+	a.existingGSs = nil
+	a.fetched = false
+	keys := make([]string, 0, len(a.conflicts))
+	for key := range a.conflicts {
+		keys = append(keys, key)
+	}
+	a.lock.Unlock()
+
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, key := range keys {
+		gslbutils.Logf("key: %s, msg: will check again if the existing GslbService can be adopted", key)
+		tenant, gsName := utils.ExtractNamespaceObjectName(key)
+		nodes.PublishKeyToRestLayer(tenant, gsName, key, sharedQueue)
+	}
+}
+
+// refetch drops the fetched GslbServices, so that they are fetched again on the next adoption.
+func (a *gsAdopter) refetch() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.existingGSs = nil
+	a.fetched = false
+}
+
+// resolve drops the conflict for key, if any.
+func (a *gsAdopter) resolve(key string) {
+	a.lock.Lock()
+	hadConflict := a.dropConflict(key)
+	a.lock.Unlock()
+	if hadConflict {
+		a.publishConflicts()
+	}
+}
+
+// dropConflict must be called with the lock held, returns true if key had a conflict.
+func (a *gsAdopter) dropConflict(key string) bool {
+	_, ok := a.conflicts[key]
+	delete(a.conflicts, key)
+	return ok
+}
+
+// findAdoptable returns the existing GslbService to be adopted for the GslbService in aviGSGraph.
+// The GslbService is to be created only if proceed is true, i.e. if no existing GslbService has
+// its domain names, or if one is found and can be adopted.
+func (a *gsAdopter) findAdoptable(aviGSGraph *nodes.AviGSObjectGraph, key string) (*avicache.AviGSCache, bool) {
+	a.lock.Lock()
+	if !a.enabled {
+		a.lock.Unlock()
+		return nil, true
+	}
+	if !a.fetched {
+		if err := a.fetchExistingGSs(); err != nil {
+			a.lock.Unlock()
+			gslbutils.Errf("key: %s, msg: can't fetch the existing GslbServices for adoption, %v", key, err)
+			publishKeyToRetryQueue(gslbutils.SlowRetryQueue, key)
+			return nil, false
+		}
+	}
+
+	domains := make(map[string]bool)
+	for _, domain := range aviGSGraph.DomainNames {
+		domains[domain] = true
+	}
+	var matches []avicache.AviUnmanagedGS
+	for _, gs := range a.existingGSs {
+		for _, domain := range gs.DomainNames {
+			if domains[domain] {
+				matches = append(matches, gs)
+				break
+			}
+		}
+	}
+	if len(matches) == 0 {
+		hadConflict := a.dropConflict(key)
+		a.lock.Unlock()
+		if hadConflict {
+			a.publishConflicts()
+		}
+		return nil, true
+	}
+
+	reason := adoptionConflictReason(aviGSGraph, matches)
+	if reason != "" {
+		names := make([]string, 0, len(matches))
+		for _, gs := range matches {
+			names = append(names, gs.Name)
+		}
+		conflict := gslbalphav1.AdoptionConflict{
+			GslbService:         aviGSGraph.Name,
+			ExistingGslbService: strings.Join(names, ","),
+			Tenant:              matches[0].Tenant,
+			Reason:              reason,
+		}
+		prev, hadConflict := a.conflicts[key]
+		a.conflicts[key] = conflict
+		a.lock.Unlock()
+		gslbutils.Warnf("key: %s, existingGS: %s, msg: can't adopt the existing GslbService, %s", key,
+			conflict.ExistingGslbService, reason)
+		if !hadConflict || prev != conflict {
+			gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeWarning, gslbutils.GSAdoption,
+				fmt.Sprintf("Can't adopt the GslbService %s for %s: %s", conflict.ExistingGslbService, aviGSGraph.Name, reason))
+			a.publishConflicts()
+		}
+		return nil, false
+	}
+
+	existingGS := matches[0]
+	if !gslbutils.IsDryRunEnabled() {
+		// the GslbService is no longer unmanaged once adopted
+		for i, gs := range a.existingGSs {
+			if gs.Uuid == existingGS.Uuid {
+				a.existingGSs = append(a.existingGSs[:i], a.existingGSs[i+1:]...)
+				break
+			}
+		}
+	}
+	hadConflict := a.dropConflict(key)
+	a.lock.Unlock()
+	if hadConflict {
+		a.publishConflicts()
+	}
+
+	gslbutils.Logf("key: %s, existingGS: %s, uuid: %s, createdBy: %s, msg: adopting the existing GslbService", key,
+		existingGS.Name, existingGS.Uuid, existingGS.CreatedBy)
+	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.GSAdoption,
+		fmt.Sprintf("Adopted the GslbService %s as %s", existingGS.Name, aviGSGraph.Name))
+	return &avicache.AviGSCache{
+		Name:   existingGS.Name,
+		Tenant: existingGS.Tenant,
+		Uuid:   existingGS.Uuid,
+	}, true
+}
+
+// fetchExistingGSs must be called with the lock held.
+func (a *gsAdopter) fetchExistingGSs() error {
+	aviRestPoolClient := avicache.SharedAviClients("*")
+	if aviRestPoolClient == nil || len(aviRestPoolClient.AviClient) == 0 {
+		return fmt.Errorf("no avi clients available")
+	}
+	gsList, err := avicache.GetUnmanagedGSs(aviRestPoolClient.AviClient[0])
+	if err != nil {
+		return err
+	}
+	gslbutils.Logf("gsCount: %d, msg: fetched the existing GslbServices for adoption", len(gsList))
+	a.existingGSs = gsList
+	a.fetched = true
+	return nil
+}
+
+// adoptionConflictReason returns why the existing GslbServices in matches can't be adopted for the
+// GslbService in aviGSGraph, an empty string if they can be.
+func adoptionConflictReason(aviGSGraph *nodes.AviGSObjectGraph, matches []avicache.AviUnmanagedGS) string {
+	if len(matches) > 1 {
+		return "the domain names are spread across multiple GslbServices"
+	}
+	gs := matches[0]
+	// the created_by field of the GslbServices created by AMKO is amko-<AMKO UUID>
+	if strings.HasPrefix(gs.CreatedBy, "amko-") {
+		return fmt.Sprintf("created by another AMKO instance %s", gs.CreatedBy)
+	}
+	if gs.Tenant != aviGSGraph.Tenant {
+		return fmt.Sprintf("in tenant %s, the GslbService built by AMKO is in tenant %s", gs.Tenant, aviGSGraph.Tenant)
+	}
+
+	domains := make(map[string]bool)
+	for _, domain := range aviGSGraph.DomainNames {
+		domains[domain] = true
+	}
+	var extraDomains []string
+	for _, domain := range gs.DomainNames {
+		if !domains[domain] {
+			extraDomains = append(extraDomains, domain)
+		}
+	}
+	if len(extraDomains) != 0 {
+		return fmt.Sprintf("domain names %v aren't part of the GslbService built by AMKO", extraDomains)
+	}
+
+	members := make(map[string]bool)
+	for _, member := range aviGSGraph.GetUniqueMemberObjs() {
+		for _, ipAddr := range member.GetIPAddrsForFamily(aviGSGraph.IPFamily) {
+			members[ipAddr] = true
+		}
+	}
+	var extraMembers []string
+	for _, member := range gs.Members {
+		if !members[member] {
+			extraMembers = append(extraMembers, member)
+		}
+	}
+	if len(extraMembers) != 0 {
+		return fmt.Sprintf("members %v aren't part of the GslbService built by AMKO", extraMembers)
+	}
+	return ""
+}
+
+// conflictList must be called with the lock held.
+func (a *gsAdopter) conflictList() []gslbalphav1.AdoptionConflict {
+	conflicts := make([]gslbalphav1.AdoptionConflict, 0, len(a.conflicts))
+	for _, conflict := range a.conflicts {
+		conflicts = append(conflicts, conflict)
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		if conflicts[i].Tenant != conflicts[j].Tenant {
+			return conflicts[i].Tenant < conflicts[j].Tenant
+		}
+		return conflicts[i].GslbService < conflicts[j].GslbService
+	})
+	return conflicts
+}
+
+// publishConflicts updates the GSLBConfig status with the conflicts, if they changed.
+func (a *gsAdopter) publishConflicts() {
+	a.lock.Lock()
+	conflicts := a.conflictList()
+	if reflect.DeepEqual(conflicts, a.prevConflicts) || (len(conflicts) == 0 && len(a.prevConflicts) == 0) {
+		a.lock.Unlock()
+		return
+	}
+	a.prevConflicts = conflicts
+	a.lock.Unlock()
+	gslbutils.UpdateGSLBConfigAdoptionConflicts(conflicts)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+// minDeletionsForPercentage is the number of deletions in a window below which the percentage
+// threshold isn't checked, so that a few deletions don't trip it for a small number of GslbServices.
+const minDeletionsForPercentage = 5
+
+// deletionBreaker holds the GslbService deletions once they cross the configured thresholds in a
+// time window. The held deletions are executed only after they are released via the
+// ReleaseHeldDeletionsAnnotation on the GSLBConfig object.
+type deletionBreaker struct {
+	lock      sync.Mutex
+	config    *gslbalphav1.DeletionProtection
+	window    time.Duration
+	deletions []time.Time
+	// heldID identifies the held deletions, set when the breaker trips
+	heldID string
+	held   map[string]struct{}
+	// released are the keys released by the user, which are deleted without being counted
+	released   map[string]struct{}
+	prevStatus string
+}
+
+var gsDeletionBreaker = &deletionBreaker{
+	held:     make(map[string]struct{}),
+	released: make(map[string]struct{}),
+}
+
+// SetDeletionProtection sets the thresholds for the GslbService deletions. Disabling the protection
+// releases the deletions held so far.
+func SetDeletionProtection(dp *gslbalphav1.DeletionProtection) {
+	b := gsDeletionBreaker
+	b.lock.Lock()
+	if dp == nil || (dp.MaxDeletions <= 0 && dp.MaxDeletionPercentage <= 0) {
+		b.config = nil
+		heldID := b.heldID
+		b.lock.Unlock()
+		if heldID != "" {
+			gslbutils.Logf("heldID: %s, msg: deletion protection disabled, releasing the held GslbService deletions", heldID)
+			ReleaseHeldDeletions(heldID)
+		}
+		return
+	}
+	defer b.lock.Unlock()
+	b.config = dp.DeepCopy()
+	b.window = time.Duration(dp.Window) * time.Second
+	if dp.Window <= 0 {
+		b.window = gslbutils.DefaultDeletionProtectionWindow * time.Second
+	}
+	gslbutils.Logf("maxDeletions: %d, maxDeletionPercentage: %d, window: %s, msg: deletion protection set",
+		dp.MaxDeletions, dp.MaxDeletionPercentage, b.window)
+}
+
+// GetHeldDeletions returns the ID of the held GslbService deletions and their keys, the ID is empty
+// if no deletions are held.
+func GetHeldDeletions() (string, []string) {
+	b := gsDeletionBreaker
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	keys := make([]string, 0, len(b.held))
+	for key := range b.held {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return b.heldID, keys
+}
+
+// allowDelete returns true if the GslbService for key can be deleted, totalGS is the number of
+// GslbServices known to AMKO. A deletion which crosses the thresholds trips the breaker, and all the
+// deletions after that are held.
+func (b *deletionBreaker) allowDelete(key string, totalGS int) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.released[key]; ok {
+		delete(b.released, key)
+		return true
+	}
+	if b.config == nil {
+		return true
+	}
+	if b.heldID != "" {
+		b.held[key] = struct{}{}
+		gslbutils.Warnf("key: %s, heldID: %s, msg: GslbService deletions are on hold, holding this deletion", key, b.heldID)
+		return false
+	}
+
+	now := time.Now()
+	var recent []time.Time
+	for _, t := range b.deletions {
+		if now.Sub(t) < b.window {
+			recent = append(recent, t)
+		}
+	}
+	b.deletions = recent
+
+	count := len(b.deletions) + 1
+	// the GslbServices deleted in this window are no longer known to AMKO
+	total := totalGS + len(b.deletions)
+	var reason string
+	if b.config.MaxDeletions > 0 && count > b.config.MaxDeletions {
+		reason = fmt.Sprintf("%d GslbService deletions in %s, more than maxDeletions %d", count, b.window,
+			b.config.MaxDeletions)
+	} else if b.config.MaxDeletionPercentage > 0 && count >= minDeletionsForPercentage && total > 0 &&
+		count*100 > b.config.MaxDeletionPercentage*total {
+		reason = fmt.Sprintf("%d of %d GslbServices deleted in %s, more than maxDeletionPercentage %d%%", count, total,
+			b.window, b.config.MaxDeletionPercentage)
+	}
+	if reason == "" {
+		b.deletions = append(b.deletions, now)
+		return true
+	}
+
+	b.heldID = strconv.FormatInt(now.Unix(), 10)
+	b.held[key] = struct{}{}
+	b.prevStatus = gslbutils.GetGSLBConfigStatusMsg()
+	msg := fmt.Sprintf("GslbService deletions on hold: %s. Verify the GDP and GSLBHostRule objects, and set the annotation %s: \"%s\" on the GSLBConfig object to release the held deletions",
+		reason, gslbutils.ReleaseHeldDeletionsAnnotation, b.heldID)
+	gslbutils.Warnf("key: %s, heldID: %s, msg: %s", key, b.heldID, msg)
+	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeWarning, gslbutils.GSDeletionsHeld, msg)
+	gslbutils.UpdateGSLBConfigStatus(msg)
+	return false
+}
+
+// unhold drops key from the held deletions, as its GslbService isn't to be deleted anymore. The
+// breaker is reset once no deletions are held.
+func (b *deletionBreaker) unhold(key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.released, key)
+	if _, ok := b.held[key]; !ok {
+		return
+	}
+	delete(b.held, key)
+	gslbutils.Logf("key: %s, heldID: %s, msg: GslbService isn't to be deleted anymore, dropped from the held deletions",
+		key, b.heldID)
+	if len(b.held) != 0 {
+		return
+	}
+	gslbutils.Logf("heldID: %s, msg: no more held GslbService deletions, resuming the deletions", b.heldID)
+	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.GSDeletionsHeld,
+		fmt.Sprintf("No more GslbService deletions held for ID %s, resumed the deletions", b.heldID))
+	b.reset()
+}
+
+// isHeld returns true if the deletion of key is held.
+func (b *deletionBreaker) isHeld(key string) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	_, ok := b.held[key]
+	return ok
+}
+
+// reset must be called with the lock held.
+func (b *deletionBreaker) reset() {
+	b.heldID = ""
+	b.held = make(map[string]struct{})
+	b.deletions = nil
+	if b.prevStatus != "" {
+		gslbutils.UpdateGSLBConfigStatus(b.prevStatus)
+		b.prevStatus = ""
+	}
+}
+
+// ReleaseHeldDeletions releases the held GslbService deletions if id matches the ID of the held
+// deletions, the keys for them are published to the rest layer again.
+func ReleaseHeldDeletions(id string) {
+	b := gsDeletionBreaker
+	b.lock.Lock()
+	if b.heldID == "" || id != b.heldID {
+		b.lock.Unlock()
+		return
+	}
+	keys := make([]string, 0, len(b.held))
+	for key := range b.held {
+		keys = append(keys, key)
+		b.released[key] = struct{}{}
+	}
+	gslbutils.Logf("heldID: %s, deletions: %d, msg: releasing the held GslbService deletions", id, len(keys))
+	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.GSDeletionsHeld,
+		fmt.Sprintf("Released %d GslbService deletions held for ID %s", len(keys), id))
+	b.reset()
+	b.lock.Unlock()
+
+	sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	for _, key := range keys {
+		tenant, gsName := utils.ExtractNamespaceObjectName(key)
+		nodes.PublishKeyToRestLayer(tenant, gsName, key, sharedQueue)
+	}
+}
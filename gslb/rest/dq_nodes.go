@@ -15,9 +15,9 @@
 package rest
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"net"
 	"strconv"
 	"strings"
 	"sync"
@@ -50,6 +50,16 @@ const (
 var restLayer *RestOperations
 var restOnce sync.Once
 
+// defaultPKIProfile if set, is used for the GslbServices with site persistence but without a PKI
+// profile, instead of fetching the default PKI profile from the controller.
+var defaultPKIProfile string
+
+// SetDefaultPKIProfile sets the PKI profile to be used for the GslbServices with site persistence
+// but without a PKI profile, used when the controller isn't reachable.
+func SetDefaultPKIProfile(name string) {
+	defaultPKIProfile = name
+}
+
 type RestOperations struct {
 	cache   *avicache.AviCache
 	hmCache *avicache.AviHmCache
@@ -91,6 +101,9 @@ func (restOp *RestOperations) deleteAllStaleHMsForGS(key string) {
 
 func (restOp *RestOperations) DqNodes(key string) {
 	gslbutils.Logf("key: %s, msg: starting rest layer sync", key)
+	if gslbutils.IsDryRunEnabled() {
+		SharedOperationPlan().StartSync(key)
+	}
 	// got the key from graph layer, let's fetch the model
 	// if the key is only in the delete cache, then set deleteOp to true, else false
 	deleteOp := false
@@ -142,6 +155,7 @@ func (restOp *RestOperations) DqNodes(key string) {
 	//    get to Layer 3, layer 2 could have again set the members to 0.
 	if deleteOp || (aviModelCopy != nil && aviModelCopy.MembersLen() == 0) {
 		gslbutils.Logf("key: %s, msg: %s", key, "no model or members found, will delete the GslbService")
+		gsAdoption.resolve(key)
 		if gsCacheObj == nil {
 			gslbutils.Errf("key: %s, msg: %s", key, "no cache object for this GS was found, can't delete")
 			// it could be that the key published was for a stale health monitor too, so remove all the
@@ -149,9 +163,13 @@ func (restOp *RestOperations) DqNodes(key string) {
 			restOp.deleteAllStaleHMsForGS(key)
 			return
 		}
+		if !gslbutils.IsDryRunEnabled() && !gsDeletionBreaker.allowDelete(key, restOp.cache.AviCacheLen()) {
+			return
+		}
 		restOp.deleteGSOper(gsCacheObj, tenant, key, aviModel)
 		return
 	}
+	gsDeletionBreaker.unhold(key)
 
 	gslbutils.Logf("key: %s, msg: GslbService will be created/updated", key)
 	if aviModelCopy == nil {
@@ -210,6 +228,9 @@ func (restOp *RestOperations) getHmPathDiff(aviGSGraph *nodes.AviGSObjectGraph,
 	for _, hmName := range hmNameList {
 		if _, exists := existingHms[hmName]; !exists {
 			toBeAdded = append(toBeAdded, hmName)
+		} else if _, found := restOp.hmCache.AviHmCacheGet(avicache.TenantName{Tenant: aviGSGraph.Tenant, Name: hmName}); !found {
+			// the health monitor was deleted outside of AMKO, has to be created again
+			toBeAdded = append(toBeAdded, hmName)
 		}
 	}
 	existingHMObjs := GetHMCacheObjFromGSCache(gsCacheObj)
@@ -319,10 +340,11 @@ func (restOp *RestOperations) createOrDeletePathHm(aviGSGraph *nodes.AviGSObject
 	for _, hmName := range gsCacheObj.HealthMonitor {
 		hmObj := restOp.getGSHmCacheObj(hmName, aviGSGraph.Tenant, key)
 		if hmObj != nil {
-			if (aviGSGraph.HmTemplate == nil && hmObj.Template == nil) ||
+			sameTemplate := (aviGSGraph.HmTemplate == nil && hmObj.Template == nil) ||
 				(aviGSGraph.HmTemplate != nil &&
 					hmObj.Template != nil &&
-					*aviGSGraph.HmTemplate == *hmObj.Template) {
+					*aviGSGraph.HmTemplate == *hmObj.Template)
+			if cksum, isPathHm := getPathHmChecksum(aviGSGraph, hmName); sameTemplate && (!isPathHm || cksum == hmObj.CloudConfigCksum) {
 				continue
 			}
 			op := restOp.AviGsHmBuild(aviGSGraph, utils.RestPut, hmObj, key, hmName)
@@ -341,9 +363,98 @@ func (restOp *RestOperations) createOrDeletePathHm(aviGSGraph *nodes.AviGSObject
 	return nil
 }
 
+// getPathHmChecksum returns the checksum of the path based health monitor hmName of aviGSGraph, as
+// built by AviGsHmBuild. Returns false if hmName isn't a path based health monitor of aviGSGraph.
+func getPathHmChecksum(aviGSGraph *nodes.AviGSObjectGraph, hmName string) (uint32, bool) {
+	for _, pathHm := range aviGSGraph.Hm.PathHM {
+		if pathHm.Name != hmName {
+			continue
+		}
+		port := int32(gslbutils.DefaultHTTPHealthMonitorPort)
+		if aviGSGraph.Hm.HMProtocol == gslbutils.SystemGslbHealthMonitorHTTPS {
+			port = gslbutils.DefaultHTTPSHealthMonitorPort
+		}
+		return gslbutils.GetGSLBHmChecksum(aviGSGraph.Hm.HMProtocol, port,
+			[]string{pathHm.GetPathHMDescription(aviGSGraph.Name, aviGSGraph.HmTemplate)},
+			gslbutils.AMKOControlConfig().CreatedByField()), true
+	}
+	return 0, false
+}
+
 func (restOp *RestOperations) createOrUpdateNonPathHm(aviGSGraph *nodes.AviGSObjectGraph, gsCacheObj *avicache.AviGSCache,
 	gsKey avicache.TenantName, key string) error {
-	hms := GetHMCacheObjFromGSCache(gsCacheObj)
+	stalePortHms := getStalePortHms(aviGSGraph, gsCacheObj)
+	// the port HMs have to exist before the GS refers to them
+	if err := restOp.createPortHms(aviGSGraph, gsKey, key); err != nil {
+		return err
+	}
+	if err := restOp.createOrUpdatePrimaryNonPathHm(aviGSGraph, gsCacheObj, gsKey, key); err != nil {
+		return err
+	}
+	if len(stalePortHms) == 0 {
+		return nil
+	}
+	// update GS, after adding the HMs and before deleting the stale port HMs
+	restOp.updateGsIfRequired(aviGSGraph, gsCacheObj, gsKey, key)
+	for _, hmName := range stalePortHms {
+		err := restOp.deleteHmIfRequired(gsCacheObj.Name, gsCacheObj.Tenant, key, gsCacheObj, gsKey, hmName)
+		if err != nil {
+			// the key has been already published to the retry queue for an error event, so just return
+			return err
+		}
+	}
+	return nil
+}
+
+// getStalePortHms returns the port HMs of the GS which are not required anymore.
+func getStalePortHms(aviGSGraph *nodes.AviGSObjectGraph, gsCacheObj *avicache.AviGSCache) []string {
+	stalePortHms := []string{}
+	for _, hmObj := range GetHMCacheObjFromGSCache(gsCacheObj) {
+		if !nodes.IsPortHmDescription(hmObj.Description) {
+			continue
+		}
+		if _, exists := aviGSGraph.Hm.GetPortHM(hmObj.Name); !exists {
+			stalePortHms = append(stalePortHms, hmObj.Name)
+		}
+	}
+	return stalePortHms
+}
+
+// createPortHms creates (or updates) the HMs for the additional ports of a multi-port LB service.
+func (restOp *RestOperations) createPortHms(aviGSGraph *nodes.AviGSObjectGraph, gsKey avicache.TenantName, key string) error {
+	for _, portHm := range aviGSGraph.Hm.PortHM {
+		var op *utils.RestOp
+		hmObj := restOp.getGSHmCacheObj(portHm.Name, aviGSGraph.Tenant, key)
+		if hmObj == nil {
+			op = restOp.AviGsHmBuild(aviGSGraph, utils.RestPost, nil, key, portHm.Name)
+		} else if hmObj.CloudConfigCksum != portHm.GetChecksum(aviGSGraph.Name) {
+			op = restOp.AviGsHmBuild(aviGSGraph, utils.RestPut, hmObj, key, portHm.Name)
+		} else {
+			continue
+		}
+		if op == nil {
+			gslbutils.Errf("key: %s, hmName: %s, msg: couldn't build a rest operation for health monitor, returning",
+				key, portHm.Name)
+			return errors.New("couldn't build a rest operation")
+		}
+		hmKey := avicache.TenantName{Tenant: gsKey.Tenant, Name: portHm.Name}
+		restOp.ExecuteRestAndPopulateCache(op, nil, &hmKey, key)
+		if op.Err != nil {
+			gslbutils.Errf("key: %s, hmKey: %v, msg: error while performing rest operation: %v", key, hmKey, op.Err)
+			return op.Err
+		}
+	}
+	return nil
+}
+
+func (restOp *RestOperations) createOrUpdatePrimaryNonPathHm(aviGSGraph *nodes.AviGSObjectGraph, gsCacheObj *avicache.AviGSCache,
+	gsKey avicache.TenantName, key string) error {
+	hms := []avicache.AviHmObj{}
+	for _, hm := range GetHMCacheObjFromGSCache(gsCacheObj) {
+		if !nodes.IsPortHmDescription(hm.Description) {
+			hms = append(hms, hm)
+		}
+	}
 	if len(hms) != 0 {
 		hm := hms[0]
 		hmKey := avicache.TenantName{Tenant: gsCacheObj.Tenant, Name: hm.Name}
@@ -497,6 +608,24 @@ func (restOp *RestOperations) RestOperation(gsName, tenant string, aviGSGraph *n
 				}
 			}
 		}
+		if err = restOp.createPortHms(aviGSGraph, gsKey, key); err != nil {
+			gslbutils.Errf("key: %s, gsKey: %v, msg: got an error in creating port based hms, %s", key, gsKey,
+				err.Error())
+			return
+		}
+	}
+
+	existingGS, proceed := gsAdoption.findAdoptable(aviGSGraph, key)
+	if !proceed {
+		return
+	}
+	if existingGS != nil {
+		// take ownership of the existing GS, its name, description and created_by fields are overwritten
+		gslbutils.Logf("key: %s, operation: PUT, existingGS: %s, msg: GS not found in cache, adopting the existing GS",
+			key, existingGS.Name)
+		operation = restOp.AviGSBuild(aviGSGraph, utils.RestPut, existingGS, key, !aviGSGraph.ControlPlaneHmOnly)
+		restOp.ExecuteRestAndPopulateCache(operation, &gsKey, nil, key)
+		return
 	}
 
 	gslbutils.Logf("key: %s, operation: POST, msg: GS not found in cache", key)
@@ -509,6 +638,7 @@ func (restOp *RestOperations) RestOperation(gsName, tenant string, aviGSGraph *n
 func AviRestOperateWrapper(restOp *RestOperations, aviClient *clients.AviClient, operation *utils.RestOp) error {
 	restTimeoutChan := make(chan error, 1)
 
+	start := time.Now()
 	go func() {
 
 		err := avicache.SharedAviClients(operation.Tenant).AviRestOperate(aviClient, []*utils.RestOp{operation})
@@ -517,19 +647,37 @@ func AviRestOperateWrapper(restOp *RestOperations, aviClient *clients.AviClient,
 
 	select {
 	case err := <-restTimeoutChan:
+		gslbutils.ObserveAviRestRequest(string(operation.Method), operation.Model, getRestStatusForMetrics(err),
+			time.Since(start))
 		return err
 	case <-time.After(gslbutils.RestTimeoutSecs * time.Second):
 		gslbutils.Errf(spew.Sprintf("operation: %v, err: rest timeout occurred", operation))
+		gslbutils.ObserveAviRestRequest(string(operation.Method), operation.Model, "timeout", time.Since(start))
 		return errors.New("rest timeout occurred")
 	}
 }
 
+// getRestStatusForMetrics returns the HTTP status code of a failed rest call, or "success".
+func getRestStatusForMetrics(err error) string {
+	if err == nil {
+		return gslbutils.MetricsResultSuccess
+	}
+	if aviError, ok := err.(session.AviError); ok {
+		return strconv.Itoa(aviError.HttpStatusCode)
+	}
+	return gslbutils.MetricsResultError
+}
+
 func (restOp *RestOperations) ExecuteRestAndPopulateCache(operation *utils.RestOp, gsKey, hmKey *avicache.TenantName,
 	key string) {
 	// Choose a AVI client based on the model name hash. This would ensure that the same worker queue processes updates for a
 	// given GS everytime.
 	bkt := utils.Bkt(key, gslbutils.NumRestWorkers)
 	gslbutils.Logf("key: %s, queue: %d, msg: processing in rest queue", key, bkt)
+	if gslbutils.IsDryRunEnabled() {
+		restOp.planOperation(operation, key)
+		return
+	}
 	var tenant string
 	if gsKey != nil {
 		tenant = gsKey.Tenant
@@ -670,10 +818,15 @@ func setRetryCounterForGraph(key string) error {
 	return nil
 }
 
-func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.TenantName, webApiErr error, key string) {
+// publishKeyToRetryQueue adds the key to the first bucket of the retry queue.
+func publishKeyToRetryQueue(queueName, key string) {
 	var bkt uint32
-	bkt = 0
+	retryQueue := utils.SharedWorkQueue().GetQueueByName(queueName)
+	retryQueue.Workqueue[bkt].AddRateLimited(key)
+	gslbutils.IncRetryCounter(queueName)
+}
 
+func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.TenantName, webApiErr error, key string) {
 	gslbutils.Debugf("key: %s, gsKey: %v, hmKey: %v, msg: evaluating whether to publish to retry queue",
 		key, gsKey, hmKey)
 	if webApiErr.Error() == "rest timeout occurred" {
@@ -686,8 +839,7 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 			gslbutils.SetResyncRequired(true)
 			return
 		}
-		slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
-		slowRetryQueue.Workqueue[bkt].AddRateLimited(key)
+		publishKeyToRetryQueue(gslbutils.SlowRetryQueue, key)
 		gslbutils.Logf("key: %s, msg: Published key to slow path retry queue", key)
 		return
 	}
@@ -700,8 +852,7 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 			gslbutils.SetResyncRequired(true)
 			return
 		}
-		slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.FastRetryQueue)
-		slowRetryQueue.Workqueue[bkt].AddRateLimited(key)
+		publishKeyToRetryQueue(gslbutils.FastRetryQueue, key)
 		gslbutils.Logf("key: %s, msg: Published key to fast path retry queue", key)
 		return
 	}
@@ -716,8 +867,7 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 			gslbutils.SetResyncRequired(true)
 			return
 		}
-		slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
-		slowRetryQueue.Workqueue[bkt].AddRateLimited(key)
+		publishKeyToRetryQueue(gslbutils.SlowRetryQueue, key)
 		gslbutils.Logf("key: %s, msg: Published key to slow path retry queue", key)
 
 	case 400:
@@ -739,8 +889,7 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 				return
 			}
 			// else, publish the key to slowRetryQueue
-			slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
-			slowRetryQueue.Workqueue[bkt].AddRateLimited(key)
+			publishKeyToRetryQueue(gslbutils.SlowRetryQueue, key)
 			gslbutils.Logf("key: %s, msg: Published key to slow path retry queue", key)
 			return
 		}
@@ -748,9 +897,11 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 			// Might be a case where the gfqdn of a hostrule has changed in customFqdnMode
 			// This case calls for a delete of the prev GS and creation of a new GS
 			// Sometimes, it might happen that new GS creation starts before prev is deleted
+			// The conflicting GS could also be one to be adopted, which was created after the existing GSs
+			// were fetched
+			gsAdoption.refetch()
 			gslbutils.Warnf("%s, msg: Published key to slow path retry queue", *aviError.Message)
-			slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
-			slowRetryQueue.Workqueue[bkt].AddRateLimited(key)
+			publishKeyToRetryQueue(gslbutils.SlowRetryQueue, key)
 			return
 		}
 		gslbutils.Errf("can't handle error code 400: %s, won't retry", *aviError.Message)
@@ -763,8 +914,7 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 		} else {
 			restOp.handleErrAndUpdateCacheForHm(aviError.HttpStatusCode, *hmKey, key)
 		}
-		fastRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.FastRetryQueue)
-		fastRetryQueue.Workqueue[bkt].AddRateLimited(key)
+		publishKeyToRetryQueue(gslbutils.FastRetryQueue, key)
 		gslbutils.Logf("key: %s, msg: Published gskey to fast path retry queue", key)
 
 	case 401:
@@ -775,8 +925,7 @@ func (restOp *RestOperations) PublishKeyToRetryLayer(gsKey, hmKey *avicache.Tena
 			return
 		}
 		gslbutils.Errf("key: %s, msg: error code 401, will retry", key)
-		slowRetryQueue := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
-		slowRetryQueue.Workqueue[bkt].AddRateLimited(key)
+		publishKeyToRetryQueue(gslbutils.SlowRetryQueue, key)
 		gslbutils.Logf("key: %s, msg: Published key to slow path retry queue", key)
 		return
 
@@ -829,7 +978,16 @@ func (restOp *RestOperations) AviGsHmBuild(gsMeta *nodes.AviGSObjectGraph, restM
 		Markers:                hmMarkers,
 	}
 
-	if pathHm != "" {
+	if portHm, isPortHm := gsMeta.Hm.GetPortHM(pathHm); isPortHm {
+		// tcp/udp health monitor for one of the additional ports of a multi-port LB service
+		hmProto = portHm.HMProtocol
+		description = portHm.GetPortHMDescription(gsMeta.Name)
+		hmName = portHm.Name
+		monitorPort = portHm.Port
+		if !setNonPathHmMonitor(&aviGsHm, hmProto, key) {
+			return nil
+		}
+	} else if pathHm != "" {
 		// path based http/https health monitor
 		description = nodes.GetDescriptionForPathHMName(pathHm, gsMeta)
 		path := nodes.GetPathFromHmDescription(pathHm, description)
@@ -886,21 +1044,7 @@ func (restOp *RestOperations) AviGsHmBuild(gsMeta *nodes.AviGSObjectGraph, restM
 		}
 		hmName = gsMeta.Hm.Name
 		monitorPort = gsMeta.Hm.Port
-		switch hmProto {
-		case gslbutils.SystemHealthMonitorTypeUDP:
-			udpRequest := "created_by: amko, request string not required"
-			hmUDP := avimodels.HealthMonitorUDP{
-				UDPRequest: &udpRequest,
-			}
-			aviGsHm.UDPMonitor = &hmUDP
-		case gslbutils.SystemHealthMonitorTypeTCP:
-			tcpHalfOpen := false
-			hmTCP := avimodels.HealthMonitorTCP{
-				TCPHalfOpen: &tcpHalfOpen,
-			}
-			aviGsHm.TCPMonitor = &hmTCP
-		default:
-			gslbutils.Errf("key: %s, msg: can't build a health monitor for an unknown protocol %s", key, hmProto)
+		if !setNonPathHmMonitor(&aviGsHm, hmProto, key) {
 			return nil
 		}
 	}
@@ -923,6 +1067,29 @@ func (restOp *RestOperations) AviGsHmBuild(gsMeta *nodes.AviGSObjectGraph, restM
 	return &operation
 }
 
+// setNonPathHmMonitor sets the TCP or UDP monitor for a non path based health monitor, returns false
+// for any other protocol.
+func setNonPathHmMonitor(aviGsHm *avimodels.HealthMonitor, hmProto, key string) bool {
+	switch hmProto {
+	case gslbutils.SystemHealthMonitorTypeUDP:
+		udpRequest := "created_by: amko, request string not required"
+		hmUDP := avimodels.HealthMonitorUDP{
+			UDPRequest: &udpRequest,
+		}
+		aviGsHm.UDPMonitor = &hmUDP
+	case gslbutils.SystemHealthMonitorTypeTCP:
+		tcpHalfOpen := false
+		hmTCP := avimodels.HealthMonitorTCP{
+			TCPHalfOpen: &tcpHalfOpen,
+		}
+		aviGsHm.TCPMonitor = &hmTCP
+	default:
+		gslbutils.Errf("key: %s, msg: can't build a health monitor for an unknown protocol %s", key, hmProto)
+		return false
+	}
+	return true
+}
+
 func (restOp *RestOperations) getGSPoolAlgorithmSettings(gsMeta *nodes.AviGSObjectGraph) (*string, *uint32, *string) {
 	var lbAlgorithm string
 
@@ -952,10 +1119,10 @@ func (restOp *RestOperations) getGSPoolAlgorithmSettings(gsMeta *nodes.AviGSObje
 	return nil, nil, nil
 }
 
-func buildGsPoolMember(member nodes.AviGSK8sObj, key string) *avimodels.GslbPoolMember {
-	enabled := true
-	ipVersion := "V4"
-	ipAddr := member.IPAddr
+func buildGsPoolMember(member nodes.AviGSK8sObj, ipAddr, key string) *avimodels.GslbPoolMember {
+	// a drained member stays in the GS, but isn't part of the DNS responses
+	enabled := !member.Drained
+	ipVersion := gslbutils.GetIPVersion(ipAddr)
 	ratio := uint32(member.Weight)
 	clusterUUID := member.ControllerUUID
 	vsUUID := member.VirtualServiceUUID
@@ -977,26 +1144,21 @@ func buildGsPoolMember(member nodes.AviGSK8sObj, key string) *avimodels.GslbPool
 
 	if member.PublicIP != "" {
 		publicIP := member.PublicIP
-		var publicIpVersion string
-		if net.ParseIP(publicIP).To4() != nil {
-			publicIpVersion = "V4"
-		} else {
-			publicIpVersion = "V6"
-
-		}
+		publicIpVersion := gslbutils.GetIPVersion(publicIP)
 		gsPoolMember.PublicIP = &avimodels.GslbIPAddr{IP: &avimodels.IPAddr{Addr: &publicIP, Type: &publicIpVersion}}
 	}
+	// a configured location overrides the one inherited from the site, it is the only location for the
+	// members synced as VIPs only and the third party members. Without one, the location is left unset
+	// so that the member keeps the location inherited from its site.
+	gsPoolMember.Location = gslbutils.BuildGslbGeoLocation(member.Location)
 
 	return &gsPoolMember
 }
 
-func buildGsPool(gsMeta *nodes.AviGSObjectGraph, gsPoolMembers []*avimodels.GslbPoolMember, priority uint32, restOp *RestOperations) *avimodels.GslbPool {
+func buildGsPool(gsMeta *nodes.AviGSObjectGraph, gsPoolMembers []*avimodels.GslbPoolMember, priority,
+	minHealthMonUp uint32, restOp *RestOperations) *avimodels.GslbPool {
 	poolEnabled := true
 	poolName := GsGroupNamePrefix + strconv.Itoa(int(priority))
-	minHealthMonUp := uint32(1)
-	if !gsMeta.ControlPlaneHmOnly {
-		minHealthMonUp = uint32(2)
-	}
 	poolAlgorithm, hashMask, fallback := restOp.getGSPoolAlgorithmSettings(gsMeta)
 	pool := &avimodels.GslbPool{
 		Algorithm:           poolAlgorithm,
@@ -1015,27 +1177,16 @@ func buildGsPool(gsMeta *nodes.AviGSObjectGraph, gsPoolMembers []*avimodels.Gslb
 
 func buildGslbSvcGroups(gsMeta *nodes.AviGSObjectGraph, key string, restOp *RestOperations) []*avimodels.GslbPool {
 	pools := []*avimodels.GslbPool{}
-	poolPriorityMap := map[uint32][]nodes.AviGSK8sObj{}
-	// first group all members according to their priorities
-	for _, member := range gsMeta.GetUniqueMemberObjs() {
-		poolPriorityMap[member.Priority] = append(poolPriorityMap[member.Priority], member)
-	}
-	// build the pool list from the poolPriorityMap
-	for priority, members := range poolPriorityMap {
-		// each priority makes one pool with `members` as the pool members
+	// each priority makes one pool, the highest priority pool first
+	for _, pool := range gsMeta.GetPools(key) {
 		gsPoolMembers := []*avimodels.GslbPoolMember{}
-		for _, m := range members {
-			if m.IPAddr == "" {
-				gslbutils.Warnf("GS pool member doesn't have an IP address: %v", m)
-				continue
+		for _, m := range pool.Members {
+			// one pool member for each address family of the member object
+			for _, ipAddr := range m.GetIPAddrsForFamily(gsMeta.IPFamily) {
+				gsPoolMembers = append(gsPoolMembers, buildGsPoolMember(m, ipAddr, key))
 			}
-			gsPoolMembers = append(gsPoolMembers, buildGsPoolMember(m, key))
 		}
-		if len(gsPoolMembers) == 0 {
-			continue
-		}
-		// build a new pool for this priority
-		pools = append(pools, buildGsPool(gsMeta, gsPoolMembers, priority, restOp))
+		pools = append(pools, buildGsPool(gsMeta, gsPoolMembers, pool.Priority, pool.MinHealthMonitorsUp, restOp))
 	}
 	return pools
 }
@@ -1051,14 +1202,9 @@ func (restOp *RestOperations) AviGSBuild(gsMeta *nodes.AviGSObjectGraph, restMet
 	ctrlHealthStatusEnabled := true
 	createdBy := gslbutils.AMKOControlConfig().CreatedByField()
 	gsEnabled := true
-	healthMonitorScope := "GSLB_SERVICE_HEALTH_MONITOR_ALL_MEMBERS"
-	isFederated := true
-	minMembers := uint32(0)
+	gsSettings := gsMeta.GetGSSettings()
 	gsName := gsMeta.Name
-	resolveCname := false
 	tenantRef := gslbutils.GetTenantRef(gsMeta.Tenant)
-	useEdnsClientSubnet := true
-	wildcardMatch := false
 	// description field needs references
 	description := strings.Join(gsMeta.GetMemberObjList(), ",")
 	var hmRefs []string
@@ -1073,14 +1219,14 @@ func (restOp *RestOperations) AviGSBuild(gsMeta *nodes.AviGSObjectGraph, restMet
 		DomainNames:                   gsMeta.DomainNames,
 		Enabled:                       &gsEnabled,
 		Groups:                        gslbSvcGroups,
-		HealthMonitorScope:            &healthMonitorScope,
-		IsFederated:                   &isFederated,
-		MinMembers:                    &minMembers,
+		HealthMonitorScope:            gsSettings.HealthMonitorScope,
+		IsFederated:                   gsSettings.IsFederated,
+		MinMembers:                    gsSettings.MinMembers,
 		Name:                          &gsName,
 		PoolAlgorithm:                 &gsAlgorithm,
-		ResolveCname:                  &resolveCname,
-		UseEdnsClientSubnet:           &useEdnsClientSubnet,
-		WildcardMatch:                 &wildcardMatch,
+		ResolveCname:                  gsSettings.ResolveCname,
+		UseEdnsClientSubnet:           gsSettings.UseEdnsClientSubnet,
+		WildcardMatch:                 gsSettings.WildcardMatch,
 		TenantRef:                     &tenantRef,
 		Description:                   &description,
 	}
@@ -1097,8 +1243,13 @@ func (restOp *RestOperations) AviGSBuild(gsMeta *nodes.AviGSObjectGraph, restMet
 		if gsMeta.PkiProfileRef != nil {
 			pkiProfileRef := "/api/pkiprofile?name=" + *gsMeta.PkiProfileRef
 			aviGslbSvc.PkiProfileRef = &pkiProfileRef
+		} else if defaultPKIProfile != "" {
+			pkiProfileRef := "/api/pkiprofile?name=" + defaultPKIProfile
+			aviGslbSvc.PkiProfileRef = &pkiProfileRef
+		} else if aviClients := cache.SharedAviClients(gsMeta.Tenant).AviClient; len(aviClients) != 0 {
+			aviGslbSvc.PkiProfileRef = gslbutils.GetDefaultPKI(aviClients[0])
 		} else {
-			aviGslbSvc.PkiProfileRef = gslbutils.GetDefaultPKI(cache.SharedAviClients(gsMeta.Tenant).AviClient[0])
+			gslbutils.Errf("gsName: %s, msg: no avi client to fetch the default PKI profile", gsMeta.Name)
 		}
 	} else {
 		sitePersistenceEnabled := false
@@ -1115,6 +1266,9 @@ func (restOp *RestOperations) AviGSBuild(gsMeta *nodes.AviGSObjectGraph, restMet
 				gslbutils.Errf("gs %s doesn't have a health monitor", gsMeta.Name)
 			}
 			aviGslbSvc.HealthMonitorRefs = []string{hmAPI + gsMeta.Hm.Name}
+			for _, portHm := range gsMeta.Hm.PortHM {
+				aviGslbSvc.HealthMonitorRefs = append(aviGslbSvc.HealthMonitorRefs, hmAPI+portHm.Name)
+			}
 		} else {
 			aviGslbSvc.HealthMonitorRefs = []string{}
 			for _, hmName := range gsMeta.Hm.PathHM {
@@ -1122,8 +1276,6 @@ func (restOp *RestOperations) AviGSBuild(gsMeta *nodes.AviGSObjectGraph, restMet
 			}
 		}
 	} else if len(gsMeta.HmRefs) > 0 {
-		minHmUp := uint32(len(gsMeta.HmRefs) + 1)
-		aviGslbSvc.Groups[0].MinHealthMonitorsUp = &minHmUp
 		// Add the custom health monitors here
 		aviGslbSvc.HealthMonitorRefs = []string{}
 		for _, hmName := range gsMeta.HmRefs {
@@ -1138,7 +1290,7 @@ func (restOp *RestOperations) AviGSBuild(gsMeta *nodes.AviGSObjectGraph, restMet
 		if gsMeta.GslbDownResponse.Type == gslbalphav1.GSLBServiceDownResponseFallbackIP {
 			fallbackIP := avimodels.IPAddr{
 				Addr: &gsMeta.GslbDownResponse.FallbackIP,
-				Type: proto.String("V4"),
+				Type: proto.String(gslbutils.GetIPVersion(gsMeta.GslbDownResponse.FallbackIP)),
 			}
 			gsDownResponse.FallbackIP = &fallbackIP
 		}
@@ -1246,6 +1398,10 @@ func (restOp *RestOperations) deleteHmIfRequired(gsName, tenant, key string, gsC
 	}
 	hmKey := avicache.TenantName{Tenant: gsKey.Tenant, Name: hmName}
 	operation := restOp.AviGsHmDel(hmCacheObj.UUID, hmCacheObj.Tenant, key, hmCacheObj.Name)
+	if gslbutils.IsDryRunEnabled() {
+		restOp.planOperation(operation, key)
+		return nil
+	}
 	restOps = operation
 	err := AviRestOperateWrapper(restOp, aviclient, restOps)
 	if err != nil {
@@ -1286,7 +1442,12 @@ func (restOp *RestOperations) deleteGSOper(gsCacheObj *avicache.AviGSCache, tena
 	gsKey := avicache.TenantName{Tenant: tenant, Name: gsCacheObj.Name}
 	operation := restOp.AviGSDel(gsCacheObj.Uuid, tenant, key, gsCacheObj.Name)
 	restOps = operation
-	err := AviRestOperateWrapper(restOp, aviclient, restOps)
+	var err error
+	if gslbutils.IsDryRunEnabled() {
+		restOp.planOperation(operation, key)
+	} else {
+		err = AviRestOperateWrapper(restOp, aviclient, restOps)
+	}
 	gslbutils.Debugf("key: %s, GSLBService: %s, msg: avi rest operate wrapper response %v", key, gsCacheObj.Uuid, err)
 	if err != nil {
 		gslbutils.Errf("key: %s, GSLBService: %s, msg: %s", key, gsCacheObj.Uuid,
@@ -1306,7 +1467,9 @@ func (restOp *RestOperations) deleteGSOper(gsCacheObj *avicache.AviGSCache, tena
 	}
 
 	// Clear all the cache objects which were deleted
-	restOp.AviGSCacheDel(restOp.cache, operation, key)
+	if !gslbutils.IsDryRunEnabled() {
+		restOp.AviGSCacheDel(restOp.cache, operation, key)
+	}
 
 	// if no HM refs for this GS, delete all HMs for this GS
 	if len(gsGraph.HmRefs) == 0 {
@@ -1399,6 +1562,12 @@ func (restOp *RestOperations) AviGSHmCacheAdd(operation *utils.RestOp, key strin
 	}
 
 	cksum := gslbutils.GetGSLBHmChecksum(hmType, port, []string{description}, createdBy)
+	// the settings are taken from the response, as the controller fills the ones not set by AMKO
+	var respHm avimodels.HealthMonitor
+	if respBytes, err := json.Marshal(respElem); err != nil || json.Unmarshal(respBytes, &respHm) != nil {
+		gslbutils.Warnf("key: %s, resp: %s, msg: unable to parse the health monitor settings in response", key, respElem)
+	}
+	settingsCksum := avicache.GetHmSettingsChecksum(respHm)
 	k := avicache.TenantName{Tenant: operation.Tenant, Name: name}
 	addNew := false
 	hmCache, ok := restOp.hmCache.AviHmCacheGet(k)
@@ -1413,6 +1582,7 @@ func (restOp *RestOperations) AviGSHmCacheAdd(operation *utils.RestOp, key strin
 			hmCacheObj.Port = port
 			hmCacheObj.Description = description
 			hmCacheObj.Template = nodes.GetTemplateFromHmDescription(name, description)
+			hmCacheObj.SettingsCksum = settingsCksum
 			gslbutils.Logf(spew.Sprintf("key: %s, cacheKey: %v, value: %v, msg: updated HM cache\n", key, k,
 				utils.Stringify(hmCacheObj)))
 		} else {
@@ -1435,6 +1605,7 @@ func (restOp *RestOperations) AviGSHmCacheAdd(operation *utils.RestOp, key strin
 			CloudConfigCksum: cksum,
 			Description:      description,
 			Template:         nodes.GetTemplateFromHmDescription(name, description),
+			SettingsCksum:    settingsCksum,
 		}
 		restOp.hmCache.AviHmCacheAdd(k, &hmCacheObj)
 		gslbutils.Logf(spew.Sprintf("key: %s, cacheKey: %v, value: %v, msg: added HM to the cache", key, k,
@@ -1533,6 +1704,11 @@ func SyncFromNodesLayer(key interface{}, wg *sync.WaitGroup) error {
 		gslbutils.Errf("unexpected object type: expected string, got %T", key)
 		return nil
 	}
+	if !gslbutils.IsReplicaElected() {
+		// the elected replica syncs this key, all keys are synced again if this replica takes over
+		gslbutils.Debugf("key: %s, msg: this AMKO replica is a standby, skipping the key in rest layer", key)
+		return nil
+	}
 	cache := avicache.GetAviCache()
 	hmCache := avicache.GetAviHmCache()
 	restLayerF := NewRestOperations(cache, hmCache)
@@ -0,0 +1,327 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+const (
+	OrphanGS = "GSLBService"
+	OrphanHM = "HealthMonitor"
+)
+
+// Orphan is a GslbService or health monitor created by this AMKO instance, which doesn't belong to
+// any GslbService built by AMKO.
+type Orphan struct {
+	ObjType string
+	Tenant  string
+	Name    string
+	Uuid    string
+	// FirstSeen is the time of the sweep which first found this object orphaned
+	FirstSeen time.Time
+}
+
+func (o Orphan) key() string {
+	return o.ObjType + "/" + o.Tenant + "/" + o.Name
+}
+
+// orphanSweeper finds the GslbServices and health monitors left behind by a crashed AMKO, a changed
+// FQDN or a changed tenant, which the rest layer never gets a key for. The orphans are only reported
+// in the ReportOnly mode, and deleted in the Delete mode once they stay orphaned for the grace period.
+type orphanSweeper struct {
+	lock        sync.Mutex
+	mode        string
+	gracePeriod time.Duration
+	orphans     map[string]*Orphan
+	// reported are the keys of the orphans in the last event, so that an event is raised only when
+	// the orphans change
+	reported string
+}
+
+var gsOrphanSweeper = &orphanSweeper{
+	mode:    gslbalphav1.OrphanCleanupDisabled,
+	orphans: make(map[string]*Orphan),
+}
+
+// SetOrphanCleanup sets the mode and grace period of the orphan sweeper.
+func SetOrphanCleanup(oc *gslbalphav1.OrphanCleanup) {
+	s := gsOrphanSweeper
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.mode = gslbalphav1.OrphanCleanupDisabled
+	s.gracePeriod = gslbutils.DefaultOrphanGracePeriod * time.Second
+	if oc != nil {
+		switch oc.Mode {
+		case gslbalphav1.OrphanCleanupReportOnly, gslbalphav1.OrphanCleanupDelete:
+			s.mode = oc.Mode
+		case "", gslbalphav1.OrphanCleanupDisabled:
+		default:
+			gslbutils.Errf("mode: %s, msg: unrecognised orphan cleanup mode, orphan cleanup disabled", oc.Mode)
+		}
+		if oc.GracePeriod > 0 {
+			s.gracePeriod = time.Duration(oc.GracePeriod) * time.Second
+		}
+	}
+	if s.mode == gslbalphav1.OrphanCleanupDisabled {
+		s.orphans = make(map[string]*Orphan)
+		s.reported = ""
+	}
+	gslbutils.Logf("mode: %s, gracePeriod: %s, msg: orphan cleanup set", s.mode, s.gracePeriod)
+}
+
+// IsOrphanCleanupEnabled returns true if the orphans are to be reported or deleted.
+func IsOrphanCleanupEnabled() bool {
+	s := gsOrphanSweeper
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.mode != gslbalphav1.OrphanCleanupDisabled
+}
+
+// GetOrphans returns the orphans found in the last sweep.
+func GetOrphans() []Orphan {
+	s := gsOrphanSweeper
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.orphanList()
+}
+
+// orphanList must be called with the lock held.
+func (s *orphanSweeper) orphanList() []Orphan {
+	orphans := make([]Orphan, 0, len(s.orphans))
+	for _, o := range s.orphans {
+		orphans = append(orphans, *o)
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].key() < orphans[j].key()
+	})
+	return orphans
+}
+
+// SweepOrphans finds the orphans among the GslbServices and health monitors in gsCache and hmCache,
+// which must be freshly fetched from the controller, and deletes the ones which stayed orphaned for
+// the grace period in the Delete mode. Must only be called after the boot up sync, and while all the
+// member clusters are synced, else the GslbServices of the missing objects are seen as orphans.
+func SweepOrphans(gsCache *avicache.AviCache, hmCache *avicache.AviHmCache) {
+	s := gsOrphanSweeper
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.mode == gslbalphav1.OrphanCleanupDisabled {
+		return
+	}
+
+	now := time.Now()
+	found := make(map[string]*Orphan)
+	for _, o := range findOrphanGSs(gsCache) {
+		found[o.key()] = o
+	}
+	for _, o := range findOrphanHMs(gsCache, hmCache) {
+		found[o.key()] = o
+	}
+	for key, o := range found {
+		if prev, ok := s.orphans[key]; ok && prev.Uuid == o.Uuid {
+			o.FirstSeen = prev.FirstSeen
+		} else {
+			o.FirstSeen = now
+		}
+	}
+	s.orphans = found
+	s.report()
+
+	if s.mode != gslbalphav1.OrphanCleanupDelete || gslbutils.IsDryRunEnabled() {
+		return
+	}
+	if !gslbutils.IsControllerLeader() {
+		gslbutils.Warnf("msg: controller is not the leader, won't delete the orphans")
+		return
+	}
+	restOp := NewRestOperations(avicache.GetAviCache(), avicache.GetAviHmCache())
+	deletedGSs := make(map[avicache.TenantName]bool)
+	// the GslbServices are deleted first, as the health monitors can't be deleted while referred to
+	for _, objType := range []string{OrphanGS, OrphanHM} {
+		for _, o := range s.orphanList() {
+			if o.ObjType != objType || now.Sub(o.FirstSeen) < s.gracePeriod {
+				continue
+			}
+			if objType == OrphanHM && isHmReferred(gsCache, o, deletedGSs) {
+				gslbutils.Logf("tenant: %s, hmName: %s, msg: orphaned health monitor is still referred to by a GslbService, won't delete",
+					o.Tenant, o.Name)
+				continue
+			}
+			// the orphaned GslbServices count towards the deletion protection thresholds, like the ones
+			// deleted by the rest layer
+			if objType == OrphanGS && !gsDeletionBreaker.allowDelete(o.Tenant+"/"+o.Name, restOp.cache.AviCacheLen()) {
+				continue
+			}
+			if err := restOp.deleteOrphan(o); err != nil {
+				gslbutils.Errf("objType: %s, tenant: %s, name: %s, uuid: %s, msg: error in deleting the orphan, will retry in the next sweep: %v",
+					o.ObjType, o.Tenant, o.Name, o.Uuid, err)
+				continue
+			}
+			gslbutils.Logf("objType: %s, tenant: %s, name: %s, uuid: %s, orphanedSince: %s, msg: deleted the orphan",
+				o.ObjType, o.Tenant, o.Name, o.Uuid, o.FirstSeen.Format(time.RFC3339))
+			gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.OrphanedObjects,
+				fmt.Sprintf("Deleted the orphaned %s %s/%s", o.ObjType, o.Tenant, o.Name))
+			delete(s.orphans, o.key())
+			if objType == OrphanGS {
+				deletedGSs[avicache.TenantName{Tenant: o.Tenant, Name: o.Name}] = true
+			}
+		}
+	}
+}
+
+// report logs the orphans, and raises an event if they changed since the last report. Must be
+// called with the lock held.
+func (s *orphanSweeper) report() {
+	orphans := s.orphanList()
+	keys := make([]string, 0, len(orphans))
+	for _, o := range orphans {
+		keys = append(keys, o.key())
+		gslbutils.Warnf("objType: %s, tenant: %s, name: %s, uuid: %s, orphanedSince: %s, mode: %s, msg: found an orphan",
+			o.ObjType, o.Tenant, o.Name, o.Uuid, o.FirstSeen.Format(time.RFC3339), s.mode)
+	}
+	reported := strings.Join(keys, ",")
+	if reported == s.reported {
+		return
+	}
+	s.reported = reported
+	if len(keys) == 0 {
+		gslbutils.Logf("msg: no orphaned GslbServices or health monitors")
+		return
+	}
+	msg := fmt.Sprintf("Found %d orphaned GslbServices and health monitors: %s", len(keys), reported)
+	if s.mode == gslbalphav1.OrphanCleanupDelete {
+		msg += fmt.Sprintf(", will delete them once orphaned for %s", s.gracePeriod)
+	}
+	gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeWarning, gslbutils.OrphanedObjects, msg)
+}
+
+// findOrphanGSs returns the GslbServices created by AMKO without a model in the graph layer. The ones
+// pending deletion in the rest layer keep their model in the delete cache, even if their deletion is
+// held, so, they aren't orphans. The held orphans stay orphans, and are deleted by the first sweep
+// after their release.
+func findOrphanGSs(gsCache *avicache.AviCache) []*Orphan {
+	var orphans []*Orphan
+	for _, k := range gsCache.AviCacheGetAllKeys() {
+		gsIntf, _ := gsCache.AviCacheGet(k)
+		gsObj, ok := gsIntf.(*avicache.AviGSCache)
+		if !ok {
+			continue
+		}
+		key := k.Tenant + "/" + k.Name
+		if found, _ := nodes.SharedAviGSGraphLister().Get(key); found {
+			continue
+		}
+		if found, _ := nodes.SharedDeleteGSGraphLister().Get(key); found {
+			continue
+		}
+		orphans = append(orphans, &Orphan{ObjType: OrphanGS, Tenant: k.Tenant, Name: k.Name, Uuid: gsObj.Uuid})
+	}
+	return orphans
+}
+
+// findOrphanHMs returns the health monitors created by this AMKO instance, whose GslbService doesn't
+// have a model in the graph layer, or whose GslbService's model doesn't use them anymore.
+func findOrphanHMs(gsCache *avicache.AviCache, hmCache *avicache.AviHmCache) []*Orphan {
+	var orphans []*Orphan
+	createdBy := gslbutils.AMKOControlConfig().CreatedByField()
+	passthroughHm := gslbutils.SystemGslbHealthMonitorPassthrough + gslbutils.AMKOControlConfig().GetAMKOUUID()
+	for _, k := range hmCache.AviHmGetAllKeys() {
+		hmKey, ok := k.(avicache.TenantName)
+		if !ok {
+			continue
+		}
+		hmIntf, _ := hmCache.AviHmCacheGet(hmKey)
+		hmObj, ok := hmIntf.(*avicache.AviHmObj)
+		if !ok || hmObj.CreatedBy != createdBy || !gslbutils.HMCreatedByAMKO(hmObj.Name) || hmObj.Name == passthroughHm {
+			continue
+		}
+		gsName := nodes.GetGSNameFromHmDescription(hmObj.Description)
+		if gsName == "" {
+			continue
+		}
+		key := hmKey.Tenant + "/" + gsName
+		if found, _ := nodes.SharedDeleteGSGraphLister().Get(key); found || gsDeletionBreaker.isHeld(key) {
+			continue
+		}
+		if found, gsGraphIntf := nodes.SharedAviGSGraphLister().Get(key); found {
+			gsGraph, ok := gsGraphIntf.(*nodes.AviGSObjectGraph)
+			if !ok || utils.HasElem(gsGraph.GetHmNames(), hmObj.Name) {
+				continue
+			}
+		}
+		orphans = append(orphans, &Orphan{ObjType: OrphanHM, Tenant: hmKey.Tenant, Name: hmObj.Name, Uuid: hmObj.UUID})
+	}
+	return orphans
+}
+
+// isHmReferred returns true if the health monitor o is referred to by a GslbService in gsCache which
+// isn't in deletedGSs.
+func isHmReferred(gsCache *avicache.AviCache, o Orphan, deletedGSs map[avicache.TenantName]bool) bool {
+	for _, k := range gsCache.AviCacheGetAllKeys() {
+		if k.Tenant != o.Tenant || deletedGSs[k] {
+			continue
+		}
+		gsIntf, _ := gsCache.AviCacheGet(k)
+		gsObj, ok := gsIntf.(*avicache.AviGSCache)
+		if !ok {
+			continue
+		}
+		for _, hmName := range gsObj.HealthMonitor {
+			if hmName == o.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deleteOrphan deletes the orphan o from the controller and from the AMKO caches.
+func (restOp *RestOperations) deleteOrphan(o Orphan) error {
+	key := o.Tenant + "/" + o.Name
+	aviRestPoolClient := avicache.SharedAviClients(o.Tenant)
+	if aviRestPoolClient == nil || len(aviRestPoolClient.AviClient) == 0 {
+		return fmt.Errorf("no avi clients available for tenant %s", o.Tenant)
+	}
+	aviclient := aviRestPoolClient.AviClient[utils.Bkt(key, gslbutils.NumRestWorkers)]
+
+	var operation *utils.RestOp
+	if o.ObjType == OrphanGS {
+		operation = restOp.AviGSDel(o.Uuid, o.Tenant, key, o.Name)
+	} else {
+		operation = restOp.AviGsHmDel(o.Uuid, o.Tenant, key, o.Name)
+	}
+	if err := AviRestOperateWrapper(restOp, aviclient, operation); err != nil {
+		return err
+	}
+	if o.ObjType == OrphanGS {
+		restOp.AviGSCacheDel(restOp.cache, operation, key)
+	} else {
+		restOp.AviGSHmCacheDel(restOp.hmCache, operation, key)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+)
+
+// PlannedOperation is a rest operation which would have been executed on the Avi controller, if AMKO
+// was not running in the dry run mode.
+type PlannedOperation struct {
+	Key    string `json:"key"`
+	Method string `json:"method"`
+	Model  string `json:"model"`
+	Tenant string `json:"tenant"`
+	Name   string `json:"name"`
+	// Object is the Avi object which would have been sent to the controller, nil for deletes.
+	Object interface{} `json:"object,omitempty"`
+	// Existing is the cached object which would have been updated or deleted, nil for creates.
+	Existing  interface{} `json:"existing,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+func (p PlannedOperation) equals(other PlannedOperation) bool {
+	return p.Method == other.Method && p.Model == other.Model && p.Tenant == other.Tenant &&
+		p.Name == other.Name && utils.Stringify(p.Object) == utils.Stringify(other.Object)
+}
+
+// OperationPlan holds the planned operations for each of the rest layer keys (<tenant>/<gsName>).
+// The planned operations for a key are rebuilt every time the key is synced.
+type OperationPlan struct {
+	lock     sync.RWMutex
+	ops      map[string][]PlannedOperation
+	previous map[string][]PlannedOperation
+}
+
+var operationPlan *OperationPlan
+var operationPlanOnce sync.Once
+
+func SharedOperationPlan() *OperationPlan {
+	operationPlanOnce.Do(func() {
+		operationPlan = &OperationPlan{
+			ops:      make(map[string][]PlannedOperation),
+			previous: make(map[string][]PlannedOperation),
+		}
+	})
+	return operationPlan
+}
+
+// StartSync clears the planned operations for a key before the key is synced again.
+func (p *OperationPlan) StartSync(key string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.previous[key] = p.ops[key]
+	delete(p.ops, key)
+}
+
+// Record adds a planned operation, returns true if the operation wasn't planned in the previous sync
+// of the key. As the caches aren't updated in the dry run mode, the same operation can be built more
+// than once in a sync, such duplicates are ignored.
+func (p *OperationPlan) Record(op PlannedOperation) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, plannedOp := range p.ops[op.Key] {
+		if plannedOp.equals(op) {
+			return false
+		}
+	}
+	p.ops[op.Key] = append(p.ops[op.Key], op)
+	for _, prevOp := range p.previous[op.Key] {
+		if prevOp.equals(op) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the planned operations for a key.
+func (p *OperationPlan) Get(key string) []PlannedOperation {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	ops := make([]PlannedOperation, len(p.ops[key]))
+	copy(ops, p.ops[key])
+	return ops
+}
+
+// GetAll returns all the planned operations, sorted by their keys.
+func (p *OperationPlan) GetAll() []PlannedOperation {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	keys := make([]string, 0, len(p.ops))
+	for key := range p.ops {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ops := []PlannedOperation{}
+	for _, key := range keys {
+		ops = append(ops, p.ops[key]...)
+	}
+	return ops
+}
+
+// planOperation records the rest operation in the operation plan instead of executing it.
+func (restOp *RestOperations) planOperation(operation *utils.RestOp, key string) {
+	plannedOp := PlannedOperation{
+		Key:       key,
+		Method:    string(operation.Method),
+		Model:     operation.Model,
+		Tenant:    operation.Tenant,
+		Name:      getRestOpObjName(operation),
+		Timestamp: time.Now(),
+	}
+	if operation.Method != utils.RestDelete {
+		plannedOp.Object = operation.Obj
+	}
+	if operation.Method != utils.RestPost {
+		objKey := avicache.TenantName{Tenant: operation.Tenant, Name: plannedOp.Name}
+		switch operation.Model {
+		case "GSLBService":
+			if obj, found := restOp.cache.AviCacheGet(objKey); found {
+				plannedOp.Existing = obj
+			}
+		case "HealthMonitor":
+			if obj, found := restOp.hmCache.AviHmCacheGet(objKey); found {
+				plannedOp.Existing = obj
+			}
+		}
+	}
+	gslbutils.Logf("key: %s, method: %s, model: %s, name: %s, msg: dry run mode, operation planned and won't be executed",
+		key, plannedOp.Method, plannedOp.Model, plannedOp.Name)
+	if SharedOperationPlan().Record(plannedOp) {
+		gslbutils.AMKOControlConfig().PodEventf(corev1.EventTypeNormal, gslbutils.DryRunOperationPlanned,
+			fmt.Sprintf("%s %s %s/%s", plannedOp.Method, plannedOp.Model, plannedOp.Tenant, plannedOp.Name))
+	}
+}
+
+// getRestOpObjName returns the name of the Avi object of a rest operation. The ObjName of the health
+// monitor create/update operations is the GS name, so, the name is fetched from the object itself.
+func getRestOpObjName(operation *utils.RestOp) string {
+	switch obj := operation.Obj.(type) {
+	case avimodels.HealthMonitor:
+		if obj.Name != nil {
+			return *obj.Name
+		}
+	case avimodels.GslbService:
+		if obj.Name != nil {
+			return *obj.Name
+		}
+	}
+	return operation.ObjName
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package simulate
+
+import (
+	"context"
+
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	hrcs "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned"
+	akov1beta1client "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned/typed/ako/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hostRuleClientset serves the HostRules of a member cluster, there's no fake clientset for the
+// v1beta1 AKO objects. Only the HostRule List API is implemented, as that's the only one used
+// during the boot up sync, calling any other API panics.
+type hostRuleClientset struct {
+	hrcs.Interface
+	hostRules []*akov1beta1.HostRule
+}
+
+func (c *hostRuleClientset) AkoV1beta1() akov1beta1client.AkoV1beta1Interface {
+	return &hostRuleGetter{hostRules: c.hostRules}
+}
+
+type hostRuleGetter struct {
+	akov1beta1client.AkoV1beta1Interface
+	hostRules []*akov1beta1.HostRule
+}
+
+func (g *hostRuleGetter) HostRules(namespace string) akov1beta1client.HostRuleInterface {
+	return &hostRuleLister{namespace: namespace, hostRules: g.hostRules}
+}
+
+type hostRuleLister struct {
+	akov1beta1client.HostRuleInterface
+	namespace string
+	hostRules []*akov1beta1.HostRule
+}
+
+func (l *hostRuleLister) List(ctx context.Context, opts metav1.ListOptions) (*akov1beta1.HostRuleList, error) {
+	hrList := &akov1beta1.HostRuleList{}
+	for _, hr := range l.hostRules {
+		if l.namespace == metav1.NamespaceAll || l.namespace == hr.Namespace {
+			hrList.Items = append(hrList.Items, *hr.DeepCopy())
+		}
+	}
+	return hrList, nil
+}
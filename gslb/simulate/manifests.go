@@ -0,0 +1,263 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package simulate
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	routev1 "github.com/openshift/api/route/v1"
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+)
+
+// ClusterLabel specifies the member cluster of an object. Objects without this label belong to the
+// member cluster named after the sub-directory they are in.
+const ClusterLabel = "amko.vmware.com/cluster"
+
+// clusterObjects are the objects of a member cluster.
+type clusterObjects struct {
+	namespaces          []*corev1.Namespace
+	services            []*corev1.Service
+	ingresses           []*networkingv1.Ingress
+	routes              []*routev1.Route
+	hostRules           []*akov1beta1.HostRule
+	multiClusterIngress []*akov1alpha1.MultiClusterIngress
+}
+
+// manifests are the objects read from the input directory.
+type manifests struct {
+	gslbConfig    *gslbalphav1.GSLBConfig
+	gdps          []*gdpalphav2.GlobalDeploymentPolicy
+	gslbHostRules []*gslbalphav1.GSLBHostRule
+	clusters      map[string]*clusterObjects
+}
+
+func (m *manifests) cluster(name string) *clusterObjects {
+	if _, ok := m.clusters[name]; !ok {
+		m.clusters[name] = &clusterObjects{}
+	}
+	return m.clusters[name]
+}
+
+// loadManifests reads all the YAML and JSON files in dir and its sub-directories. A file can have
+// multiple objects separated by "---".
+func loadManifests(dir string) (*manifests, error) {
+	m := &manifests{clusters: make(map[string]*clusterObjects)}
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+			if !d.IsDir() {
+				files = append(files, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error in reading directory %s: %v", dir, err)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		// the first sub-directory is the default cluster for the objects in this file
+		cluster := ""
+		if rel, err := filepath.Rel(dir, filepath.Dir(file)); err == nil && rel != "." {
+			cluster = strings.Split(filepath.ToSlash(rel), "/")[0]
+		}
+		if err := m.loadFile(file, cluster); err != nil {
+			return nil, err
+		}
+	}
+	if m.gslbConfig == nil {
+		return nil, fmt.Errorf("no GSLBConfig object found in %s", dir)
+	}
+	return m, nil
+}
+
+func (m *manifests) loadFile(file, defaultCluster string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("error in reading file %s: %v", file, err)
+	}
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error in reading file %s: %v", file, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		if err := m.addObject(doc, defaultCluster); err != nil {
+			return fmt.Errorf("file %s: %v", file, err)
+		}
+	}
+}
+
+func (m *manifests) addObject(doc []byte, defaultCluster string) error {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return fmt.Errorf("error in parsing object: %v", err)
+	}
+	if typeMeta.Kind == "" {
+		// comment only documents
+		return nil
+	}
+
+	var obj metav1.Object
+	switch typeMeta.GroupVersionKind() {
+	case gslbalphav1.SchemeGroupVersion.WithKind("GSLBConfig"):
+		gc := &gslbalphav1.GSLBConfig{}
+		if m.gslbConfig != nil {
+			return errors.New("only one GSLBConfig object is allowed")
+		}
+		m.gslbConfig = gc
+		obj = gc
+	case gslbalphav1.SchemeGroupVersion.WithKind("GSLBHostRule"):
+		gslbhr := &gslbalphav1.GSLBHostRule{}
+		m.gslbHostRules = append(m.gslbHostRules, gslbhr)
+		obj = gslbhr
+	case gdpalphav2.SchemeGroupVersion.WithKind("GlobalDeploymentPolicy"):
+		gdp := &gdpalphav2.GlobalDeploymentPolicy{}
+		m.gdps = append(m.gdps, gdp)
+		obj = gdp
+	default:
+		return m.addClusterObject(doc, typeMeta, defaultCluster)
+	}
+	if err := yaml.Unmarshal(doc, obj); err != nil {
+		return fmt.Errorf("error in parsing %s: %v", typeMeta.Kind, err)
+	}
+	// the AMKO objects are created in the leader cluster, in avi-system unless specified
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(gslbutils.AVISystem)
+	}
+	return nil
+}
+
+func (m *manifests) addClusterObject(doc []byte, typeMeta metav1.TypeMeta, defaultCluster string) error {
+	var objMeta metav1.PartialObjectMetadata
+	if err := yaml.Unmarshal(doc, &objMeta); err != nil {
+		return fmt.Errorf("error in parsing object: %v", err)
+	}
+	cname := objMeta.Labels[ClusterLabel]
+	if cname == "" {
+		cname = defaultCluster
+	}
+	if cname == "" {
+		return fmt.Errorf("%s %s/%s: member cluster not known, add the label %s or move it to the member cluster's directory",
+			typeMeta.Kind, objMeta.Namespace, objMeta.Name, ClusterLabel)
+	}
+	if objMeta.Namespace == "" && typeMeta.Kind != "Namespace" {
+		objMeta.Namespace = metav1.NamespaceDefault
+	}
+	c := m.cluster(cname)
+
+	var obj metav1.Object
+	switch typeMeta.GroupVersionKind() {
+	case corev1.SchemeGroupVersion.WithKind("Namespace"):
+		ns := &corev1.Namespace{}
+		c.namespaces = append(c.namespaces, ns)
+		obj = ns
+	case corev1.SchemeGroupVersion.WithKind("Service"):
+		svc := &corev1.Service{}
+		c.services = append(c.services, svc)
+		obj = svc
+	case networkingv1.SchemeGroupVersion.WithKind("Ingress"):
+		ing := &networkingv1.Ingress{}
+		c.ingresses = append(c.ingresses, ing)
+		obj = ing
+	case routev1.SchemeGroupVersion.WithKind("Route"):
+		route := &routev1.Route{}
+		c.routes = append(c.routes, route)
+		obj = route
+	case akov1beta1.SchemeGroupVersion.WithKind("HostRule"):
+		hr := &akov1beta1.HostRule{}
+		c.hostRules = append(c.hostRules, hr)
+		obj = hr
+	case akov1alpha1.SchemeGroupVersion.WithKind("MultiClusterIngress"):
+		mci := &akov1alpha1.MultiClusterIngress{}
+		c.multiClusterIngress = append(c.multiClusterIngress, mci)
+		obj = mci
+	default:
+		return fmt.Errorf("unsupported object %s %s", typeMeta.APIVersion, typeMeta.Kind)
+	}
+	if err := yaml.Unmarshal(doc, obj); err != nil {
+		return fmt.Errorf("error in parsing %s %s/%s: %v", typeMeta.Kind, objMeta.Namespace, objMeta.Name, err)
+	}
+	if typeMeta.Kind != "Namespace" {
+		obj.SetNamespace(objMeta.Namespace)
+	}
+	return nil
+}
+
+// namespaceObjects returns the namespaces of the cluster, including the ones which aren't in the
+// manifests but have objects in them, and the extra namespaces.
+func (c *clusterObjects) namespaceObjects(extraNamespaces ...string) []runtime.Object {
+	present := make(map[string]bool)
+	var objs []runtime.Object
+	for _, ns := range c.namespaces {
+		present[ns.Name] = true
+		objs = append(objs, ns)
+	}
+	// the boot up sync needs at least one namespace in a cluster
+	names := append([]string{metav1.NamespaceDefault}, extraNamespaces...)
+	for _, svc := range c.services {
+		names = append(names, svc.Namespace)
+	}
+	for _, ing := range c.ingresses {
+		names = append(names, ing.Namespace)
+	}
+	for _, route := range c.routes {
+		names = append(names, route.Namespace)
+	}
+	for _, hr := range c.hostRules {
+		names = append(names, hr.Namespace)
+	}
+	for _, mci := range c.multiClusterIngress {
+		names = append(names, mci.Namespace)
+	}
+	for _, name := range names {
+		if present[name] {
+			continue
+		}
+		present[name] = true
+		objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return objs
+}
@@ -0,0 +1,329 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// Package simulate renders the GslbServices and health monitors which AMKO would create on the
+// GSLB leader for a set of manifests, without any member cluster or Avi controller. The manifests
+// go through the same filters, graph layer and rest layer builders as the ones in a running AMKO.
+package simulate
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	oshiftfake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	crdfake "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	avirest "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
+	gslbfake "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	gdpfake "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
+)
+
+// offlineAmkoUUID is the AMKO UUID used for the created_by fields, if the GSLBConfig object doesn't
+// have one.
+const offlineAmkoUUID = "00000000-0000-0000-0000-000000000000"
+
+// Options are the inputs for a simulation.
+type Options struct {
+	// Dir has the manifests, see loadManifests.
+	Dir string
+	// LeaderCluster is the member cluster which has the GDP and GSLBHostRule objects, defaults to the
+	// first member cluster in the GSLBConfig object.
+	LeaderCluster string
+	// PKIProfile is used for the GslbServices with site persistence but without a PKI profile,
+	// instead of the default PKI profile on the controller. It's required, as the controller
+	// can't be queried offline.
+	PKIProfile string
+}
+
+// Result has the Avi objects which AMKO would create for the manifests, sorted by their names.
+type Result struct {
+	GslbServices   []avimodels.GslbService   `json:"gslbServices"`
+	HealthMonitors []avimodels.HealthMonitor `json:"healthMonitors"`
+}
+
+// Run renders the GslbServices and health monitors for the manifests in opts.Dir. It sets up the
+// global state of AMKO, so it can be run only once in a process.
+func Run(opts Options) (*Result, error) {
+	if opts.PKIProfile == "" {
+		return nil, fmt.Errorf("a PKI profile is required for the GslbServices with site persistence")
+	}
+	m, err := loadManifests(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	ctrlList, err := setup(m, opts)
+	if err != nil {
+		return nil, err
+	}
+	ingestion.BuildGSGraphsOffline(ctrlList)
+	return render()
+}
+
+func setup(m *manifests, opts Options) ([]*ingestion.GSLBMemberController, error) {
+	gc, err := ingestion.IsGSLBConfigValid(m.gslbConfig)
+	if err != nil {
+		return nil, err
+	}
+	if len(gc.Spec.MemberClusters) == 0 {
+		return nil, fmt.Errorf("no member clusters in GSLBConfig %s", gc.Name)
+	}
+	memberClusters := make(map[string]bool)
+	for _, mc := range gc.Spec.MemberClusters {
+		memberClusters[mc.ClusterContext] = true
+	}
+	for cname := range m.clusters {
+		if !memberClusters[cname] {
+			return nil, fmt.Errorf("cluster %s isn't a member cluster in GSLBConfig %s", cname, gc.Name)
+		}
+	}
+	for _, gslbhr := range m.gslbHostRules {
+		// the third party member sites are verified on the GSLB leader
+		if len(gslbhr.Spec.ThirdPartyMembers) != 0 {
+			return nil, fmt.Errorf("GSLBHostRule %s/%s: third party members can't be simulated", gslbhr.Namespace,
+				gslbhr.Name)
+		}
+	}
+	leaderCluster := opts.LeaderCluster
+	if leaderCluster == "" {
+		leaderCluster = gc.Spec.MemberClusters[0].ClusterContext
+	}
+	if !memberClusters[leaderCluster] {
+		return nil, fmt.Errorf("leader cluster %s isn't a member cluster in GSLBConfig %s", leaderCluster, gc.Name)
+	}
+
+	tenant := utils.ADMIN_NS
+	if gc.Spec.GSLBLeader.Tenant != nil {
+		tenant = *gc.Spec.GSLBLeader.Tenant
+	}
+	gslbutils.NewAviControllerConfig("", "", gc.Spec.GSLBLeader.ControllerIP, gc.Spec.GSLBLeader.ControllerVersion, tenant)
+	if _, ok := gc.Annotations[gslbutils.AmkoUuid]; ok {
+		if err := ingestion.GetUUIDFromGSLBConfig(gc); err != nil {
+			return nil, err
+		}
+	} else {
+		gslbutils.AMKOControlConfig().SetCreatedByField("amko-" + offlineAmkoUUID)
+	}
+	gslbutils.SetGSLBConfigObj(gc)
+	gslbutils.SetCustomFqdnMode(gc.Spec.UseCustomGlobalFqdn)
+	gslbutils.SetControllerAsLeader()
+	avicache.UseOfflineAviClients()
+	gslbutils.LeaderClusterContext = leaderCluster
+	avirest.SetDefaultPKIProfile(opts.PKIProfile)
+
+	gslbObjs := []runtime.Object{gc}
+	for _, gslbhr := range m.gslbHostRules {
+		gslbObjs = append(gslbObjs, gslbhr)
+	}
+	gslbutils.AMKOControlConfig().SetGSLBClientset(gslbfake.NewSimpleClientset(gslbObjs...))
+	var gdpObjs []runtime.Object
+	for _, gdp := range m.gdps {
+		gdpObjs = append(gdpObjs, gdp)
+	}
+	gslbutils.AMKOControlConfig().SetGDPClientset(gdpfake.NewSimpleClientset(gdpObjs...))
+
+	for _, c := range m.clusters {
+		if len(c.multiClusterIngress) != 0 {
+			os.Setenv(utils.MCI_ENABLED, "true")
+		}
+	}
+
+	var ctrlList []*ingestion.GSLBMemberController
+	for _, mc := range gc.Spec.MemberClusters {
+		cname := mc.ClusterContext
+		gslbutils.AddClusterContext(cname)
+		c := m.cluster(cname)
+
+		var leaderNamespaces []string
+		if cname == leaderCluster {
+			leaderNamespaces = append(leaderNamespaces, gslbutils.AVISystem)
+			for _, gdp := range m.gdps {
+				leaderNamespaces = append(leaderNamespaces, gdp.Namespace)
+			}
+			for _, gslbhr := range m.gslbHostRules {
+				leaderNamespaces = append(leaderNamespaces, gslbhr.Namespace)
+			}
+		}
+		kubeObjs := c.namespaceObjects(leaderNamespaces...)
+		for _, svc := range c.services {
+			kubeObjs = append(kubeObjs, svc)
+		}
+		for _, ing := range c.ingresses {
+			kubeObjs = append(kubeObjs, ing)
+		}
+		var routeObjs []runtime.Object
+		for _, route := range c.routes {
+			routeObjs = append(routeObjs, route)
+		}
+		var mciObjs []runtime.Object
+		for _, mci := range c.multiClusterIngress {
+			mciObjs = append(mciObjs, mci)
+		}
+		aviCtrl, err := ingestion.NewOfflineMemberController(cname, k8sfake.NewSimpleClientset(kubeObjs...),
+			oshiftfake.NewSimpleClientset(routeObjs...), &hostRuleClientset{hostRules: c.hostRules},
+			crdfake.NewSimpleClientset(mciObjs...))
+		if err != nil {
+			return nil, err
+		}
+		ctrlList = append(ctrlList, aviCtrl)
+	}
+	addAviObjectsToCache(m)
+	return ctrlList, nil
+}
+
+// addAviObjectsToCache adds the health monitors, site persistence and PKI profiles referred by the
+// GDP and GSLBHostRule objects to the caches, as if they were present on the controller. A health
+// monitor template is taken as an HTTP(S) health monitor with AMKO's default request and
+// response codes.
+func addAviObjectsToCache(m *manifests) {
+	hmCache := avicache.GetAviHmCache()
+	spCache := avicache.GetAviSpCache()
+	pkiCache := avicache.GetAviPkiCache()
+
+	addHm := func(tenant, name string) {
+		hmCache.AviHmCacheAdd(avicache.TenantName{Tenant: tenant, Name: name},
+			&avicache.AviHmObj{Tenant: tenant, Name: name})
+	}
+	addHmTemplate := func(tenant, name string) {
+		hmCache.AviHmCacheAdd(avicache.TenantName{Tenant: tenant, Name: name}, &avicache.AviHmObj{
+			Tenant: tenant,
+			Name:   name,
+			Type:   gslbutils.SystemGslbHealthMonitorHTTP,
+			CustomHmSettings: &avicache.CustomHmSettings{
+				RequestHeader: "HEAD / HTTP/1.0",
+				ResponseCode:  []string{"HTTP_2XX", "HTTP_3XX"},
+			},
+		})
+	}
+	addSp := func(tenant, name string) {
+		spCache.AviSpCacheAdd(avicache.TenantName{Tenant: tenant, Name: name},
+			&avimodels.ApplicationPersistenceProfile{Name: &name})
+	}
+	addPki := func(tenant, name string) {
+		pkiCache.AviPkiCacheAdd(avicache.TenantName{Tenant: tenant, Name: name}, &avimodels.PKIprofile{Name: &name})
+	}
+
+	for _, gdp := range m.gdps {
+		tenant := gslbutils.GetTenantInNamespace(gdp.Namespace, gslbutils.LeaderClusterContext)
+		for _, hmRef := range gdp.Spec.HealthMonitorRefs {
+			addHm(tenant, hmRef)
+		}
+		if gdp.Spec.HealthMonitorTemplate != nil {
+			addHmTemplate(tenant, *gdp.Spec.HealthMonitorTemplate)
+		}
+		if gdp.Spec.SitePersistenceRef != nil {
+			addSp(tenant, *gdp.Spec.SitePersistenceRef)
+		}
+		if gdp.Spec.PKIProfileRef != nil {
+			addPki(tenant, *gdp.Spec.PKIProfileRef)
+		}
+	}
+	for _, gslbhr := range m.gslbHostRules {
+		tenant := gslbutils.GetTenantInNamespace(gslbhr.Namespace, gslbutils.LeaderClusterContext)
+		for _, hmRef := range gslbhr.Spec.HealthMonitorRefs {
+			addHm(tenant, hmRef)
+		}
+		if gslbhr.Spec.HealthMonitorTemplate != nil {
+			addHmTemplate(tenant, *gslbhr.Spec.HealthMonitorTemplate)
+		}
+		if sp := gslbhr.Spec.SitePersistence; sp != nil && sp.Enabled {
+			addSp(tenant, sp.ProfileRef)
+			if sp.PKIProfileRef != nil {
+				addPki(tenant, *sp.PKIProfileRef)
+			}
+		}
+	}
+}
+
+// render builds the GslbServices and health monitors for the GS graphs, the same way as the rest
+// layer does for GslbServices not present on the controller.
+func render() (*Result, error) {
+	restOp := avirest.NewRestOperations(avicache.GetAviCache(), avicache.GetAviHmCache())
+	agl := nodes.SharedAviGSGraphLister()
+	result := &Result{
+		GslbServices:   []avimodels.GslbService{},
+		HealthMonitors: []avimodels.HealthMonitor{},
+	}
+
+	for _, key := range agl.GetAll() {
+		found, obj := agl.Get(key)
+		if !found {
+			continue
+		}
+		gsGraph, ok := obj.(*nodes.AviGSObjectGraph)
+		if !ok || gsGraph.MembersLen() == 0 {
+			continue
+		}
+		gsGraph = gsGraph.GetCopy()
+
+		var hmNames []string
+		if pathNames := gsGraph.GetHmPathNamesList(); len(pathNames) > 0 {
+			hmNames = pathNames
+		} else {
+			if gsGraph.IsHmTypeCustom(gsGraph.Hm.Name) {
+				hmNames = append(hmNames, "")
+			}
+			for _, portHm := range gsGraph.Hm.PortHM {
+				hmNames = append(hmNames, portHm.Name)
+			}
+		}
+		for _, hmName := range hmNames {
+			op := restOp.AviGsHmBuild(gsGraph, utils.RestPost, nil, key, hmName)
+			if op == nil {
+				return nil, fmt.Errorf("couldn't build the health monitor for GslbService %s", key)
+			}
+			result.HealthMonitors = append(result.HealthMonitors, op.Obj.(avimodels.HealthMonitor))
+		}
+		op := restOp.AviGSBuild(gsGraph, utils.RestPost, nil, key, !gsGraph.ControlPlaneHmOnly)
+		gs := op.Obj.(avimodels.GslbService)
+		sortGslbService(&gs)
+		result.GslbServices = append(result.GslbServices, gs)
+	}
+
+	sort.Slice(result.GslbServices, func(i, j int) bool {
+		return *result.GslbServices[i].Name < *result.GslbServices[j].Name
+	})
+	sort.Slice(result.HealthMonitors, func(i, j int) bool {
+		return *result.HealthMonitors[i].Name < *result.HealthMonitors[j].Name
+	})
+	return result, nil
+}
+
+// sortGslbService orders the pools, their members and the members in the description of gs, which
+// depend on the order in which the objects were synced.
+func sortGslbService(gs *avimodels.GslbService) {
+	sort.Slice(gs.Groups, func(i, j int) bool {
+		return *gs.Groups[i].Priority > *gs.Groups[j].Priority
+	})
+	for _, pool := range gs.Groups {
+		sort.SliceStable(pool.Members, func(i, j int) bool {
+			return *pool.Members[i].IP.Addr < *pool.Members[j].IP.Addr
+		})
+	}
+	if gs.Description != nil {
+		members := strings.Split(*gs.Description, ",")
+		sort.Strings(members)
+		description := strings.Join(members, ",")
+		gs.Description = &description
+	}
+}
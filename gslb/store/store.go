@@ -37,6 +37,8 @@ var (
 	HostRuleStore                    *ClusterStore
 	AcceptedMultiClusterIngressStore *ClusterStore
 	RejectedMultiClusterIngressStore *ClusterStore
+	AcceptedHTTPRouteStore           *ClusterStore
+	RejectedHTTPRouteStore           *ClusterStore
 	NamespaceToTenantStore           *ObjectStore
 )
 
@@ -125,6 +127,26 @@ func GetRejectedMultiClusterIngressStore() *ClusterStore {
 	return RejectedMultiClusterIngressStore
 }
 
+var acceptedHTTPRouteOnce sync.Once
+
+// GetAcceptedHTTPRouteStore initializes and returns a new accepted gateway API HTTPRoute store.
+func GetAcceptedHTTPRouteStore() *ClusterStore {
+	acceptedHTTPRouteOnce.Do(func() {
+		AcceptedHTTPRouteStore = NewClusterStore()
+	})
+	return AcceptedHTTPRouteStore
+}
+
+var rejectedHTTPRouteOnce sync.Once
+
+// GetRejectedHTTPRouteStore initializes and returns a new rejected gateway API HTTPRoute store.
+func GetRejectedHTTPRouteStore() *ClusterStore {
+	rejectedHTTPRouteOnce.Do(func() {
+		RejectedHTTPRouteStore = NewClusterStore()
+	})
+	return RejectedHTTPRouteStore
+}
+
 var acceptedNSOnce sync.Once
 
 // GetAcceptedNSStore initializes and returns a new accepted NSStore.
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
+)
+
+// createExistingGS creates a GslbService the way a user would, without AMKO's created_by and
+// description.
+func createExistingGS(g *gomega.WithT, name, domain string, memberIPs ...string) string {
+	var members []interface{}
+	for _, ip := range memberIPs {
+		members = append(members, map[string]interface{}{
+			"ip":      map[string]interface{}{"addr": ip, "type": "V4"},
+			"ratio":   1,
+			"enabled": true,
+		})
+	}
+	gs, err := sim.Create("gslbservice", utils.ADMIN_NS, map[string]interface{}{
+		"name":         name,
+		"domain_names": []interface{}{domain},
+		"created_by":   "admin",
+		"description":  "hand built GslbService",
+		"groups": []interface{}{
+			map[string]interface{}{"name": "pool-1", "priority": 10, "members": members},
+		},
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	return gs["uuid"].(string)
+}
+
+func TestAdoptExistingGS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "adopt.avi.com"
+	existingUUID := createExistingGS(g, "adopt-existing-gs", host, "10.50.1.1")
+	rest.SetGSAdoption(true)
+	defer rest.SetGSAdoption(false)
+
+	syncGSGraph(buildTestGSGraph(host, []string{"10.50.1.1", "10.50.1.2"}))
+
+	_, found := sim.Get("gslbservice", utils.ADMIN_NS, "adopt-existing-gs")
+	g.Expect(found).To(gomega.BeFalse())
+	gs, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(gs["uuid"]).To(gomega.Equal(existingUUID))
+	g.Expect(gs["created_by"]).To(gomega.Equal(testAmkoCreatedBy))
+	g.Expect(gs["description"]).To(gomega.ContainSubstring("INGRESS/cluster1/default/ing/" + host))
+	g.Expect(gsMemberIPs(gs)).To(gomega.ConsistOf("10.50.1.1", "10.50.1.2"))
+	g.Expect(rest.GetAdoptionConflicts()).To(gomega.BeEmpty())
+
+	gsCache, found := avicache.GetAviCache().AviCacheGet(avicache.TenantName{Tenant: utils.ADMIN_NS, Name: host})
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(gsCache.(*avicache.AviGSCache).Uuid).To(gomega.Equal(existingUUID))
+}
+
+func TestAdoptionConflict(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "adopt-conflict.avi.com"
+	existingUUID := createExistingGS(g, "adopt-conflict-gs", host, "10.50.2.1", "10.50.2.9")
+	rest.SetGSAdoption(true)
+	defer rest.SetGSAdoption(false)
+
+	// 10.50.2.9 isn't a member of the GslbService built by AMKO, so the existing one is left as is
+	syncGSGraph(buildTestGSGraph(host, []string{"10.50.2.1"}))
+
+	_, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeFalse())
+	gs, found := sim.Get("gslbservice", utils.ADMIN_NS, "adopt-conflict-gs")
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(gs["created_by"]).To(gomega.Equal("admin"))
+	conflicts := rest.GetAdoptionConflicts()
+	g.Expect(conflicts).To(gomega.HaveLen(1))
+	g.Expect(conflicts[0].GslbService).To(gomega.Equal(host))
+	g.Expect(conflicts[0].ExistingGslbService).To(gomega.Equal("adopt-conflict-gs"))
+	g.Expect(conflicts[0].Reason).To(gomega.ContainSubstring("10.50.2.9"))
+
+	// once the extra member is removed, the GslbService is adopted on the next periodic check
+	g.Expect(sim.Update("gslbservice", utils.ADMIN_NS, "adopt-conflict-gs", func(data map[string]interface{}) {
+		group := data["groups"].([]interface{})[0].(map[string]interface{})
+		group["members"] = group["members"].([]interface{})[:1]
+	})).To(gomega.Succeed())
+	rest.RetryAdoptionConflicts()
+
+	g.Eventually(func() interface{} {
+		gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		return gs["uuid"]
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(existingUUID))
+	g.Expect(rest.GetAdoptionConflicts()).To(gomega.BeEmpty())
+}
+
+func TestAdoptionDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "adopt-disabled.avi.com"
+	createExistingGS(g, "adopt-disabled-gs", host, "10.50.3.1")
+	drainRetryKeys()
+
+	// without adoption, the GslbService can't be created due to the conflicting domain name
+	syncGSGraph(buildTestGSGraph(host, []string{"10.50.3.1"}))
+
+	_, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeFalse())
+	gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, "adopt-disabled-gs")
+	g.Expect(gs["created_by"]).To(gomega.Equal("admin"))
+	g.Eventually(retryKeys, 5*time.Second).Should(gomega.Receive(gomega.Equal(utils.ADMIN_NS + "/" + host)))
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+func TestGSMemberDrain(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "drain.avi.com"
+	memberEnabled := func() map[string]interface{} {
+		return getGSMemberField(host, "enabled")
+	}
+	ingress1 := buildIngress("drain-ing", map[string]string{host: "10.130.1.1"})
+	syncIngress(ingress1, "cluster1")
+	syncIngress(buildIngress("drain-ing", map[string]string{host: "10.130.1.2"}), "cluster2")
+	g.Eventually(memberEnabled, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(
+		map[string]interface{}{"10.130.1.1": true, "10.130.1.2": true}))
+
+	// a drained cluster's member stays in the GslbService
+	setClusterProperties("cluster2", gslbutils.ClusterProperties{SyncVipsOnly: true, Drain: true})
+	g.Eventually(memberEnabled, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(
+		map[string]interface{}{"10.130.1.1": true, "10.130.1.2": false}))
+
+	setClusterProperties("cluster2", gslbutils.ClusterProperties{SyncVipsOnly: true})
+	g.Eventually(memberEnabled, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(
+		map[string]interface{}{"10.130.1.1": true, "10.130.1.2": true}))
+
+	// a cluster drained in the GSLBConfig object is drained irrespective of the GDP objects, and the
+	// drain is reverted with one edit
+	memberClusters := []gslbalphav1.MemberCluster{{ClusterContext: "cluster1"}, {ClusterContext: "cluster2"}}
+	drainedClusters := []gslbalphav1.MemberCluster{{ClusterContext: "cluster1"}, {ClusterContext: "cluster2", Drain: true}}
+	defer gslbutils.SetMemberClusterSettings(nil)
+	ingestion.UpdateMemberClusters(memberClusters, drainedClusters)
+	g.Eventually(memberEnabled, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(
+		map[string]interface{}{"10.130.1.1": true, "10.130.1.2": false}))
+	ingestion.UpdateMemberClusters(drainedClusters, memberClusters)
+	g.Eventually(memberEnabled, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(
+		map[string]interface{}{"10.130.1.1": true, "10.130.1.2": true}))
+
+	// the drain annotation only drains the member of the annotated object
+	ingress1.Annotations[gslbutils.DrainAnnotation] = "true"
+	syncIngress(ingress1, "cluster1")
+	g.Eventually(memberEnabled, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(
+		map[string]interface{}{"10.130.1.1": false, "10.130.1.2": true}))
+}
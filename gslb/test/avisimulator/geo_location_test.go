@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+func TestGSMemberGeoLocation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "geo-location.avi.com"
+	gslbutils.AddClusterContext("cluster1")
+	gslbutils.AddClusterContext("cluster2")
+
+	// the GDP filter sets the location of cluster1, the nodes of cluster2 are in a region
+	latitude, longitude := 37.5, -121.75
+	setClusterProperties("cluster1", gslbutils.ClusterProperties{SyncVipsOnly: true, Location: &gslbalphav1.GeoLocation{
+		Name: "US/California/San Jose", Region: "us-west", Latitude: &latitude, Longitude: &longitude}})
+	defer setClusterProperties("cluster1", gslbutils.ClusterProperties{SyncVipsOnly: true})
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1",
+		Labels: map[string]string{gslbutils.NodeRegionLabel: "us-east"}}}
+	cluster2Client := k8sfake.NewSimpleClientset(node)
+	informersArg := map[string]interface{}{utils.INFORMERS_INSTANTIATE_ONCE: false}
+	gslbutils.SetInformersPerCluster("cluster1", utils.NewInformers(utils.KubeClientIntf{ClientSet: k8sfake.NewSimpleClientset()},
+		[]string{utils.NSInformer}, informersArg))
+	gslbutils.SetInformersPerCluster("cluster2", utils.NewInformers(utils.KubeClientIntf{ClientSet: cluster2Client},
+		[]string{utils.NSInformer}, informersArg))
+	defer gslbutils.SetClusterRegion("cluster2", "")
+
+	syncIngress(buildIngress("geo-ing", map[string]string{host: "10.110.1.1"}), "cluster1")
+	syncIngress(buildIngress("geo-ing", map[string]string{host: "10.110.1.2"}), "cluster2")
+	g.Eventually(func() map[string]interface{} {
+		return getGSMemberField(host, "location")
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.HaveLen(2))
+	locations := getGSMemberField(host, "location")
+	g.Expect(locations["10.110.1.1"]).To(gomega.Equal(map[string]interface{}{
+		"source": gslbutils.GeoLocationSourceUserConfigured,
+		"location": map[string]interface{}{
+			"name":      "US/California/San Jose",
+			"tag":       "us-west",
+			"latitude":  37.5,
+			"longitude": -121.75,
+		},
+	}))
+	// the region of cluster2 isn't derived yet, so, the member has no location
+	g.Expect(locations["10.110.1.2"]).To(gomega.BeNil())
+
+	// the region of cluster2 isn't derived from its nodes unless opted in
+	ingestion.RefreshClusterRegions()
+	g.Expect(gslbutils.GetClusterRegion("cluster2")).To(gomega.BeEmpty())
+	g.Consistently(func() interface{} {
+		return getGSMemberField(host, "location")["10.110.1.2"]
+	}, time.Second, 100*time.Millisecond).Should(gomega.BeNil())
+
+	// once opted in, the region of cluster2 is derived from its nodes, and only sets the location tag of
+	// its member synced as a VIP only
+	gslbutils.SetMemberClusterSettings([]gslbalphav1.MemberCluster{{ClusterContext: "cluster1"},
+		{ClusterContext: "cluster2", UseNodeRegion: true}})
+	defer gslbutils.SetMemberClusterSettings(nil)
+	ingestion.RefreshClusterRegions()
+	g.Eventually(func() interface{} {
+		return getGSMemberField(host, "location")["10.110.1.2"]
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(map[string]interface{}{
+		"source":   gslbutils.GeoLocationSourceUserConfigured,
+		"location": map[string]interface{}{"tag": "us-east"},
+	}))
+
+	// the region is refreshed once the nodes are relabelled
+	node.Labels[gslbutils.NodeRegionLabel] = "eu-west"
+	_, err := cluster2Client.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	ingestion.RefreshClusterRegions()
+	g.Eventually(func() interface{} {
+		return getGSMemberField(host, "location")["10.110.1.2"]
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(map[string]interface{}{
+		"source":   gslbutils.GeoLocationSourceUserConfigured,
+		"location": map[string]interface{}{"tag": "eu-west"},
+	}))
+
+	// the region is removed once opted out
+	gslbutils.SetMemberClusterSettings(nil)
+	ingestion.RefreshClusterRegions()
+	g.Expect(gslbutils.GetClusterRegion("cluster2")).To(gomega.BeEmpty())
+	g.Eventually(func() map[string]interface{} {
+		return getGSMemberField(host, "location")
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.HaveKeyWithValue("10.110.1.2", gomega.BeNil()))
+}
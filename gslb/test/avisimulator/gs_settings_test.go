@@ -0,0 +1,90 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+)
+
+func TestGSSettings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "gs-settings.avi.com"
+	gsGraph := buildTestGSGraph(host, []string{"10.90.1.1", "10.90.1.2"})
+	// two pools
+	gsGraph.MemberObjs[1].Priority = 5
+	minMembers := uint32(1)
+	useEdnsClientSubnet := false
+	gsGraph.GSSettings = gslbutils.GSSettings{MinMembers: &minMembers, UseEdnsClientSubnet: &useEdnsClientSubnet}
+	syncGSGraph(gsGraph)
+
+	gs, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(gs["min_members"]).To(gomega.BeNumerically("==", 1))
+	g.Expect(gs["use_edns_client_subnet"]).To(gomega.BeFalse())
+	// the properties which aren't set take the defaults
+	g.Expect(gs["health_monitor_scope"]).To(gomega.Equal(gslbutils.DefaultGSHealthMonitorScope))
+	g.Expect(gs["is_federated"]).To(gomega.BeTrue())
+	g.Expect(gs["wildcard_match"]).To(gomega.BeFalse())
+	g.Expect(gs["resolve_cname"]).To(gomega.BeFalse())
+	// the highest priority pool is the first one
+	g.Expect(gsPoolField(gs, "priority")).To(gomega.Equal([]interface{}{5.0, 0.0}))
+	g.Expect(gsPoolField(gs, "min_health_monitors_up")).To(gomega.Equal([]interface{}{2.0, 2.0}))
+
+	// a change on the controller changes the checksum of the cached GslbService
+	cksum, _, _, _, _, _, err := avicache.GetDetailsFromAviGSLB(gs)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(sim.Update("gslbservice", utils.ADMIN_NS, host, func(data map[string]interface{}) {
+		data["wildcard_match"] = true
+	})).To(gomega.Succeed())
+	gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	driftCksum, _, _, _, _, _, err := avicache.GetDetailsFromAviGSLB(gs)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(driftCksum).NotTo(gomega.Equal(cksum))
+
+	minHmUp := uint32(3)
+	gsGraph.GSSettings.MinHealthMonitorsUp = &minHmUp
+	syncGSGraph(gsGraph)
+
+	gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(gs["wildcard_match"]).To(gomega.BeFalse())
+	g.Expect(gsPoolField(gs, "min_health_monitors_up")).To(gomega.Equal([]interface{}{3.0, 3.0}))
+	newCksum, _, _, _, _, _, err := avicache.GetDetailsFromAviGSLB(gs)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(newCksum).NotTo(gomega.Equal(cksum))
+
+	// with custom health monitors, only the first pool needs one more than the custom health monitors
+	gsGraph.GSSettings.MinHealthMonitorsUp = nil
+	gsGraph.HmRefs = []string{"System-GSLB-TCP", "System-GSLB-HTTP"}
+	syncGSGraph(gsGraph)
+
+	gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(gsPoolField(gs, "min_health_monitors_up")).To(gomega.Equal([]interface{}{3.0, 2.0}))
+	// every pool is parsed for the checksum of the cached GslbService
+	cksum, _, _, _, _, _, err = avicache.GetDetailsFromAviGSLB(gs)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(sim.Update("gslbservice", utils.ADMIN_NS, host, func(data map[string]interface{}) {
+		gsPools(data)[1]["min_health_monitors_up"] = 3
+	})).To(gomega.Succeed())
+	gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	driftCksum, _, _, _, _, _, err = avicache.GetDetailsFromAviGSLB(gs)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(driftCksum).NotTo(gomega.Equal(cksum))
+}
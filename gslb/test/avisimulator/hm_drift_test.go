@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+)
+
+func checkHmDrift(g *gomega.WithT) {
+	hmCache, err := avicache.FetchHMCache()
+	g.Expect(err).To(gomega.BeNil())
+	ingestion.CheckHmDrift(hmCache)
+}
+
+func TestHmDriftEdited(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "hm-drift.avi.com"
+	gsGraph := buildTestGSGraph(host, []string{"10.70.1.1"})
+	syncGSGraph(gsGraph)
+	hmName := gsGraph.Hm.PathHM[0].Name
+
+	// an unchanged health monitor isn't updated again
+	hmPuts := sim.RequestCount("PUT", "healthmonitor")
+	syncGSGraph(gsGraph)
+	checkHmDrift(g)
+	g.Consistently(func() int {
+		return sim.RequestCount("PUT", "healthmonitor")
+	}, time.Second, 100*time.Millisecond).Should(gomega.Equal(hmPuts))
+
+	g.Expect(sim.Update("healthmonitor", utils.ADMIN_NS, hmName, func(data map[string]interface{}) {
+		data["monitor_port"] = 8443
+	})).To(gomega.Succeed())
+	checkHmDrift(g)
+
+	g.Eventually(func() interface{} {
+		hm, _ := sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+		return hm["monitor_port"]
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.BeNumerically("==", 443))
+
+	// the health check settings aren't a part of the checksum, but are corrected too
+	g.Expect(sim.Update("healthmonitor", utils.ADMIN_NS, hmName, func(data map[string]interface{}) {
+		data["send_interval"] = 60
+		data["https_monitor"].(map[string]interface{})["http_request"] = "GET / HTTP/1.0"
+	})).To(gomega.Succeed())
+	checkHmDrift(g)
+
+	g.Eventually(func() []interface{} {
+		hm, _ := sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+		return []interface{}{hm["send_interval"], hm["https_monitor"].(map[string]interface{})["http_request"]}
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal([]interface{}{float64(10),
+		"HEAD / HTTP/1.0"}))
+
+	// the corrected health monitor isn't updated again
+	hmPuts = sim.RequestCount("PUT", "healthmonitor")
+	checkHmDrift(g)
+	g.Consistently(func() int {
+		return sim.RequestCount("PUT", "healthmonitor")
+	}, time.Second, 100*time.Millisecond).Should(gomega.Equal(hmPuts))
+}
+
+func TestHmDriftDeleted(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "hm-drift-deleted.avi.com"
+	gsGraph := buildTestGSGraph(host, []string{"10.70.2.1"})
+	syncGSGraph(gsGraph)
+	hmName := gsGraph.Hm.PathHM[0].Name
+
+	g.Expect(sim.Delete("healthmonitor", utils.ADMIN_NS, hmName)).To(gomega.BeTrue())
+	checkHmDrift(g)
+
+	g.Eventually(func() bool {
+		_, found := sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+		return found
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.BeTrue())
+	hm, _ := sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+	g.Eventually(func() interface{} {
+		gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		return gs["health_monitor_refs"]
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.ConsistOf(
+		gomega.HaveSuffix("/api/healthmonitor/" + hm["uuid"].(string) + "#" + hmName)))
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+)
+
+func TestStandbyReplicaTakeOver(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "standby.avi.com"
+	newHost := "standby-new.avi.com"
+	gsGraph := buildTestGSGraph(host, []string{"10.80.1.1"})
+	syncGSGraph(gsGraph)
+	gsKey := avicache.TenantName{Tenant: utils.ADMIN_NS, Name: host}
+	hmKey := avicache.TenantName{Tenant: utils.ADMIN_NS, Name: gsGraph.Hm.PathHM[0].Name}
+	gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+
+	// the objects were created by the previously elected replica after this replica populated its caches
+	avicache.GetAviCache().AviCacheDelete(gsKey)
+	avicache.GetAviHmCache().AviHmCacheDelete(hmKey)
+
+	os.Setenv("LEADER_ELECTION_ENABLED", "true")
+	defer os.Unsetenv("LEADER_ELECTION_ENABLED")
+	gslbutils.SetReplicaElected(false)
+	defer gslbutils.SetReplicaElected(false)
+
+	// a standby doesn't sync the GslbServices
+	gsPosts := sim.RequestCount("POST", "gslbservice")
+	syncGSGraph(buildTestGSGraph(newHost, []string{"10.80.2.1"}))
+	g.Expect(sim.RequestCount("POST", "gslbservice")).To(gomega.Equal(gsPosts))
+	_, found := sim.Get("gslbservice", utils.ADMIN_NS, newHost)
+	g.Expect(found).To(gomega.BeFalse())
+
+	drainRetryKeys()
+	gslbutils.SetReplicaElected(true)
+	ingestion.TakeOver()
+
+	g.Eventually(func() bool {
+		_, found := sim.Get("gslbservice", utils.ADMIN_NS, newHost)
+		return found
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.BeTrue())
+	// the objects of the other GslbService are found in the synced caches, so they aren't created again
+	g.Consistently(retryKeys, time.Second).ShouldNot(gomega.Receive(gomega.Equal(utils.ADMIN_NS + "/" + host)))
+	gsCache, found := avicache.GetAviCache().AviCacheGet(gsKey)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(gsCache.(*avicache.AviGSCache).Uuid).To(gomega.Equal(gs["uuid"]))
+	_, found = avicache.GetAviHmCache().AviHmCacheGet(hmKey)
+	g.Expect(found).To(gomega.BeTrue())
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+)
+
+func sweepOrphans() {
+	rest.SweepOrphans(avicache.PopulateGSCache(false), avicache.PopulateHMCache(false))
+}
+
+func orphanNames() []string {
+	var names []string
+	for _, o := range rest.GetOrphans() {
+		names = append(names, o.ObjType+"/"+o.Name)
+	}
+	return names
+}
+
+func TestOrphanSweep(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "orphan.avi.com"
+	liveHost := "orphan-live.avi.com"
+	gsGraph := buildTestGSGraph(host, []string{"10.60.1.1"})
+	syncGSGraph(gsGraph)
+	liveGraph := buildTestGSGraph(liveHost, []string{"10.60.2.1"})
+	syncGSGraph(liveGraph)
+	hmName := gsGraph.Hm.PathHM[0].Name
+	_, found := sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+	g.Expect(found).To(gomega.BeTrue())
+
+	// the model is gone without a deletion reaching the rest layer, as after a restart of AMKO
+	nodes.SharedAviGSGraphLister().Delete(utils.ADMIN_NS + "/" + host)
+
+	rest.SetOrphanCleanup(&gslbalphav1.OrphanCleanup{Mode: gslbalphav1.OrphanCleanupReportOnly})
+	defer rest.SetOrphanCleanup(nil)
+	sweepOrphans()
+	g.Expect(orphanNames()).To(gomega.ConsistOf(rest.OrphanGS+"/"+host, rest.OrphanHM+"/"+hmName))
+	_, found = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeTrue())
+	_, found = sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+	g.Expect(found).To(gomega.BeTrue())
+
+	// the orphans are deleted only once they stay orphaned for the grace period
+	rest.SetOrphanCleanup(&gslbalphav1.OrphanCleanup{Mode: gslbalphav1.OrphanCleanupDelete, GracePeriod: 1})
+	sweepOrphans()
+	time.Sleep(1100 * time.Millisecond)
+	sweepOrphans()
+
+	_, found = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeFalse())
+	_, found = sim.Get("healthmonitor", utils.ADMIN_NS, hmName)
+	g.Expect(found).To(gomega.BeFalse())
+	_, found = avicache.GetAviCache().AviCacheGet(avicache.TenantName{Tenant: utils.ADMIN_NS, Name: host})
+	g.Expect(found).To(gomega.BeFalse())
+	_, found = avicache.GetAviHmCache().AviHmCacheGet(avicache.TenantName{Tenant: utils.ADMIN_NS, Name: hmName})
+	g.Expect(found).To(gomega.BeFalse())
+	g.Expect(rest.GetOrphans()).To(gomega.BeEmpty())
+
+	_, found = sim.Get("gslbservice", utils.ADMIN_NS, liveHost)
+	g.Expect(found).To(gomega.BeTrue())
+	_, found = sim.Get("healthmonitor", utils.ADMIN_NS, liveGraph.Hm.PathHM[0].Name)
+	g.Expect(found).To(gomega.BeTrue())
+}
+
+func TestOrphanSweepDeletionProtection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	hosts := []string{"orphan-dp-1.avi.com", "orphan-dp-2.avi.com", "orphan-dp-3.avi.com"}
+	for idx, host := range hosts {
+		syncGSGraph(buildTestGSGraph(host, []string{fmt.Sprintf("10.61.1.%d", idx+1)}))
+		nodes.SharedAviGSGraphLister().Delete(utils.ADMIN_NS + "/" + host)
+	}
+	gsPresent := func(host string) bool {
+		_, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		return found
+	}
+
+	rest.SetDeletionProtection(&gslbalphav1.DeletionProtection{MaxDeletions: 1, Window: 300})
+	defer rest.SetDeletionProtection(nil)
+	rest.SetOrphanCleanup(&gslbalphav1.OrphanCleanup{Mode: gslbalphav1.OrphanCleanupDelete, GracePeriod: 1})
+	defer rest.SetOrphanCleanup(nil)
+	sweepOrphans()
+	time.Sleep(1100 * time.Millisecond)
+	sweepOrphans()
+
+	// only the deletions within maxDeletions go through, the rest are held
+	g.Expect(gsPresent(hosts[0])).To(gomega.BeFalse())
+	g.Expect(gsPresent(hosts[1])).To(gomega.BeTrue())
+	g.Expect(gsPresent(hosts[2])).To(gomega.BeTrue())
+	heldID, heldKeys := rest.GetHeldDeletions()
+	g.Expect(heldKeys).To(gomega.ConsistOf(utils.ADMIN_NS+"/"+hosts[1], utils.ADMIN_NS+"/"+hosts[2]))
+	g.Expect(orphanNames()).To(gomega.ContainElements(rest.OrphanGS+"/"+hosts[1], rest.OrphanGS+"/"+hosts[2]))
+	sweepOrphans()
+	g.Expect(gsPresent(hosts[1])).To(gomega.BeTrue())
+
+	// the released orphans are deleted by the next sweep
+	rest.ReleaseHeldDeletions(heldID)
+	sweepOrphans()
+	g.Expect(gsPresent(hosts[1])).To(gomega.BeFalse())
+	g.Expect(gsPresent(hosts[2])).To(gomega.BeFalse())
+	_, heldKeys = rest.GetHeldDeletions()
+	g.Expect(heldKeys).To(gomega.BeEmpty())
+}
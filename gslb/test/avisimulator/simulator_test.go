@@ -0,0 +1,504 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/vmware/alb-sdk/go/session"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/k8sobjects"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/test/mockaviserver"
+	gdpv1alpha2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+)
+
+const (
+	DefaultNS         = "default"
+	testGDP           = "test-gdp"
+	testAmkoCreatedBy = "amko-7c2b4a4e-86a3-4b9b-8d1c-2c4d6d1c5f1a"
+)
+
+var (
+	// testAppLabels are the labels of the k8s objects selected by the test GDP filter
+	testAppLabels = map[string]string{"app": "gslb"}
+
+	sim       *mockaviserver.AviSimulator
+	retryKeys chan string
+)
+
+func TestMain(m *testing.M) {
+	setUp()
+	ret := m.Run()
+	os.Exit(ret)
+}
+
+func syncFuncForRetryQueue(key interface{}, wg *sync.WaitGroup) error {
+	keyStr, ok := key.(string)
+	if !ok {
+		gslbutils.Errf("unexpected object type: expected string, got %T", key)
+		return nil
+	}
+	retryKeys <- keyStr
+	return nil
+}
+
+func setUp() {
+	retryKeys = make(chan string, 100)
+	testStopCh := utils.SetupSignalHandler()
+
+	// the slow retry queue is drained every second instead of gslbutils.SlowSyncTime
+	slowRetryQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: gslbutils.SlowRetryQueue, SlowSyncTime: 1}
+	fastRetryQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: gslbutils.FastRetryQueue}
+	graphQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: utils.GraphLayer}
+	ingestionQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: utils.ObjectIngestionLayer}
+	utils.SharedWorkQueue(&slowRetryQParams, &fastRetryQParams, &graphQParams, &ingestionQParams)
+	for _, qName := range []string{gslbutils.SlowRetryQueue, gslbutils.FastRetryQueue} {
+		retryQ := utils.SharedWorkQueue().GetQueueByName(qName)
+		retryQ.SyncFunc = syncFuncForRetryQueue
+		retryQ.Run(testStopCh, &sync.WaitGroup{})
+	}
+	graphQ := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	graphQ.SyncFunc = rest.SyncFromNodesLayer
+	graphQ.Run(testStopCh, &sync.WaitGroup{})
+	ingestionQ := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	ingestionQ.SyncFunc = nodes.SyncFromIngestionLayer
+	ingestionQ.Run(testStopCh, &sync.WaitGroup{})
+
+	// the test GDP filter selects the labelled objects from both the member clusters, the namespace
+	// doesn't have a tenant annotation in the member clusters, so, the objects are in the default tenant
+	gf := gslbutils.NewGDPFilter(testGDP)
+	gf.AppFilter = &gslbutils.AppFilter{Selector: labels.SelectorFromSet(testAppLabels)}
+	for _, cname := range []string{"cluster1", "cluster2"} {
+		store.GetNamespaceToTenantStore().AddOrUpdate(cname, DefaultNS, "")
+		gf.ApplicableClusters[cname] = gslbutils.ClusterProperties{SyncVipsOnly: true}
+	}
+	gslbutils.GetGlobalFilter().AddFilter(gf)
+
+	sim = mockaviserver.NewAviSimulator()
+	server := httptest.NewTLSServer(sim)
+	gslbutils.SetControllerAsLeader()
+	gslbutils.AMKOControlConfig().SetCreatedByField(testAmkoCreatedBy)
+	gslbutils.NewAviControllerConfig("admin", "admin", strings.TrimPrefix(server.URL, "https://"),
+		mockaviserver.SimulatorVersion, utils.ADMIN_NS)
+}
+
+func adminClient() *session.AviSession {
+	return avicache.SharedAviClients(utils.ADMIN_NS).AviClient[0].AviSession
+}
+
+func buildTestGSGraph(host string, ipList []string) *nodes.AviGSObjectGraph {
+	memberObjs := []nodes.AviGSK8sObj{}
+	for idx, ip := range ipList {
+		memberObjs = append(memberObjs, nodes.AviGSK8sObj{
+			Cluster:   "cluster" + string(rune('1'+idx)),
+			ObjType:   gdpv1alpha2.IngressObj,
+			Name:      "ing/" + host,
+			Namespace: DefaultNS,
+			IPAddr:    ip,
+			Weight:    10,
+		})
+	}
+	gsGraph := &nodes.AviGSObjectGraph{
+		Name:        host,
+		Tenant:      gslbutils.GetTenant(),
+		DomainNames: []string{host},
+		MemberObjs:  memberObjs,
+		Hm: nodes.HealthMonitor{
+			HMProtocol: gslbutils.SystemGslbHealthMonitorHTTPS,
+			Port:       443,
+			Type:       nodes.PathHM,
+		},
+		Lock: &sync.RWMutex{},
+	}
+	gsGraph.Hm.PathHM = []nodes.PathHealthMonitorDetails{gsGraph.BuildPathHM(host, "/", true)}
+	gsGraph.GetChecksum()
+	gsGraph.SetRetryCounter()
+	return gsGraph
+}
+
+func syncGSGraph(gsGraph *nodes.AviGSObjectGraph) {
+	modelName := gsGraph.Tenant + "/" + gsGraph.Name
+	nodes.SharedAviGSGraphLister().Save(modelName, gsGraph)
+	rest.SyncFromNodesLayer(modelName, &sync.WaitGroup{})
+}
+
+// syncMetaObj adds a k8s object to its accepted store and syncs its GslbService through the graph layer,
+// the GslbService is synced to the simulator by the graph queue.
+func syncMetaObj(acceptedStore *store.ClusterStore, metaObj k8sobjects.MetaObject, objName string) {
+	cname, ns := metaObj.GetCluster(), metaObj.GetNamespace()
+	acceptedStore.AddOrUpdate(metaObj, cname, ns, objName)
+	key := gslbutils.MultiClusterKey(gslbutils.ObjectAdd, metaObj.GetType(), cname, ns, objName, metaObj.GetTenant())
+	nodes.AddUpdateObjOperation(key, cname, ns, metaObj.GetType(), objName,
+		utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer), false, nodes.SharedAviGSGraphLister())
+}
+
+// syncIngress syncs the GslbServices of all the hosts of an ingress.
+func syncIngress(ingress *networkingv1.Ingress, cname string) {
+	for _, ihm := range k8sobjects.GetIngressHostMeta(ingress, cname) {
+		syncMetaObj(store.GetAcceptedIngressStore(), ihm, ihm.ObjName)
+	}
+}
+
+// syncRoute syncs the GslbService of a route.
+func syncRoute(route *routev1.Route, cname string) {
+	syncMetaObj(store.GetAcceptedRouteStore(), k8sobjects.GetRouteMeta(route, cname), route.Name)
+}
+
+// buildIngress returns an ingress in the default namespace with a rule and an AKO status entry for each
+// host in hostIPs.
+func buildIngress(name string, hostIPs map[string]string) *networkingv1.Ingress {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: DefaultNS,
+			Labels:    testAppLabels,
+			Annotations: map[string]string{
+				gslbutils.VSAnnotation:         "",
+				gslbutils.ControllerAnnotation: "",
+			},
+		},
+	}
+	for host, ip := range hostIPs {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{Host: host})
+		ingress.Status.LoadBalancer.Ingress = append(ingress.Status.LoadBalancer.Ingress,
+			networkingv1.IngressLoadBalancerIngress{IP: ip, Hostname: host})
+	}
+	return ingress
+}
+
+// buildRoute returns a route in the default namespace with an AKO status entry for its host.
+func buildRoute(name, host, ip string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: DefaultNS,
+			Labels:    testAppLabels,
+			Annotations: map[string]string{
+				gslbutils.VSAnnotation:         "",
+				gslbutils.ControllerAnnotation: "",
+			},
+		},
+		Spec: routev1.RouteSpec{Host: host},
+		Status: routev1.RouteStatus{
+			Ingress: []routev1.RouteIngress{{
+				Host:       host,
+				RouterName: "ako-test",
+				Conditions: []routev1.RouteIngressCondition{{Message: ip}},
+			}},
+		},
+	}
+}
+
+// setClusterProperties sets the properties of a member cluster in the test GDP filter, and syncs the
+// objects of the cluster again, as done for a change in the GDP object.
+func setClusterProperties(cname string, properties gslbutils.ClusterProperties) {
+	gf := gslbutils.GetGlobalFilter().GetFilter(testGDP)
+	gf.Lock.Lock()
+	gf.ApplicableClusters[cname] = properties
+	gf.Lock.Unlock()
+	ingestionQ := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	ingestion.WriteChangedObjsToQueue(ingestionQ.Workqueue, ingestionQ.NumWorkers, false, []string{cname})
+}
+
+// getGSMemberField returns a field of each member of a GslbService in the simulator, keyed by the member
+// IP address, nil if the GslbService isn't present.
+func getGSMemberField(name, field string) map[string]interface{} {
+	gs, found := sim.Get("gslbservice", utils.ADMIN_NS, name)
+	if !found {
+		return nil
+	}
+	return gsMemberField(gs, field)
+}
+
+// gsPools returns the pools of a GslbService fetched from the simulator, in the order of the pools.
+func gsPools(gs map[string]interface{}) []map[string]interface{} {
+	var pools []map[string]interface{}
+	for _, group := range gs["groups"].([]interface{}) {
+		pools = append(pools, group.(map[string]interface{}))
+	}
+	return pools
+}
+
+// gsMembers returns the members of all the pools of a GslbService fetched from the simulator.
+func gsMembers(gs map[string]interface{}) []map[string]interface{} {
+	var members []map[string]interface{}
+	for _, pool := range gsPools(gs) {
+		for _, member := range pool["members"].([]interface{}) {
+			members = append(members, member.(map[string]interface{}))
+		}
+	}
+	return members
+}
+
+func gsMemberIP(member map[string]interface{}) string {
+	return member["ip"].(map[string]interface{})["addr"].(string)
+}
+
+func gsMemberIPs(gs map[string]interface{}) []string {
+	var ips []string
+	for _, member := range gsMembers(gs) {
+		ips = append(ips, gsMemberIP(member))
+	}
+	return ips
+}
+
+// gsMemberField returns a field of each member of a GslbService, keyed by the member IP address.
+func gsMemberField(gs map[string]interface{}, field string) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, member := range gsMembers(gs) {
+		values[gsMemberIP(member)] = member[field]
+	}
+	return values
+}
+
+// gsPoolField returns a field of each pool of a GslbService, in the order of the pools.
+func gsPoolField(gs map[string]interface{}, field string) []interface{} {
+	var values []interface{}
+	for _, pool := range gsPools(gs) {
+		values = append(values, pool[field])
+	}
+	return values
+}
+
+func drainRetryKeys() {
+	for {
+		select {
+		case <-retryKeys:
+		default:
+			return
+		}
+	}
+}
+
+func TestCRUD(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	client := adminClient()
+
+	hm := map[string]interface{}{
+		"name":         "crud-hm",
+		"type":         "HEALTH_MONITOR_TCP",
+		"is_federated": true,
+	}
+	var created map[string]interface{}
+	g.Expect(client.Post("/api/healthmonitor", hm, &created)).To(gomega.Succeed())
+	uuid := created["uuid"].(string)
+	g.Expect(uuid).To(gomega.HavePrefix("healthmonitor-"))
+	g.Expect(created["tenant_ref"]).To(gomega.HaveSuffix("/api/tenant/admin#admin"))
+
+	err := client.Post("/api/healthmonitor", hm, &created)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.(session.AviError).HttpStatusCode).To(gomega.Equal(http.StatusConflict))
+
+	hm["send_interval"] = 30
+	var updated map[string]interface{}
+	g.Expect(client.Put("/api/healthmonitor/"+uuid, hm, &updated)).To(gomega.Succeed())
+	g.Expect(updated["uuid"]).To(gomega.Equal(uuid))
+	simHM, found := sim.Get("healthmonitor", utils.ADMIN_NS, "crud-hm")
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(simHM["send_interval"]).To(gomega.BeEquivalentTo(30))
+
+	gs := map[string]interface{}{
+		"name":                "crud-gs",
+		"domain_names":        []string{"crud.avi.com"},
+		"health_monitor_refs": []string{"/api/healthmonitor?name=unknown-hm"},
+	}
+	err = client.Post("/api/gslbservice", gs, &created)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.(session.AviError).HttpStatusCode).To(gomega.Equal(http.StatusBadRequest))
+	gs["health_monitor_refs"] = []string{"/api/healthmonitor?name=crud-hm"}
+	g.Expect(client.Post("/api/gslbservice", gs, &created)).To(gomega.Succeed())
+	g.Expect(created["health_monitor_refs"]).To(gomega.ConsistOf(gomega.HaveSuffix("/api/healthmonitor/" + uuid + "#crud-hm")))
+
+	gs["name"] = "crud-gs-2"
+	err = client.Post("/api/gslbservice", gs, &created)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(*err.(session.AviError).Message).To(gomega.ContainSubstring("domain name conflicting with existing domain name"))
+
+	simGS, _ := sim.Get("gslbservice", utils.ADMIN_NS, "crud-gs")
+	g.Expect(client.Delete("/api/gslbservice/" + simGS["uuid"].(string))).To(gomega.Succeed())
+	g.Expect(client.Delete("/api/healthmonitor/" + uuid)).To(gomega.Succeed())
+	err = client.Delete("/api/healthmonitor/" + uuid)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.(session.AviError).HttpStatusCode).To(gomega.Equal(http.StatusNotFound))
+}
+
+func TestPagination(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for i := 0; i < 25; i++ {
+		_, err := sim.Create("applicationpersistenceprofile", utils.ADMIN_NS, map[string]interface{}{
+			"name":         "page-profile-" + string(rune('a'+i)),
+			"is_federated": true,
+			"description":  "pagination",
+		})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	client := avicache.SharedAviClients(utils.ADMIN_NS).AviClient[0]
+	uri := "/api/applicationpersistenceprofile?include_name&page_size=10&description=pagination"
+	var names []string
+	pages := 0
+	for uri != "" {
+		result, err := gslbutils.GetUriFromAvi(uri, client, false)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.Count).To(gomega.Equal(25))
+		var elems []map[string]interface{}
+		g.Expect(json.Unmarshal(result.Results, &elems)).To(gomega.Succeed())
+		for _, elem := range elems {
+			names = append(names, elem["name"].(string))
+		}
+		pages++
+		uri = ""
+		if result.Next != "" {
+			uri = "/api/applicationpersistenceprofile" + strings.Split(result.Next, "/api/applicationpersistenceprofile")[1]
+		}
+	}
+	g.Expect(pages).To(gomega.Equal(3))
+	g.Expect(names).To(gomega.HaveLen(25))
+	g.Expect(names[0]).To(gomega.Equal("page-profile-a"))
+}
+
+func TestTenants(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, err := sim.Create("tenant", utils.ADMIN_NS, map[string]interface{}{"name": "tenant-1"})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	tenantClient := avicache.SharedAviClients("tenant-1").AviClient[0].AviSession
+	gs := map[string]interface{}{
+		"name":                "tenant-gs",
+		"domain_names":        []string{"tenant.avi.com"},
+		"health_monitor_refs": []string{"/api/healthmonitor?name=System-GSLB-TCP"},
+	}
+	var created map[string]interface{}
+	// the health monitors in the admin tenant are visible in the other tenants
+	g.Expect(tenantClient.Post("/api/gslbservice", gs, &created)).To(gomega.Succeed())
+	g.Expect(created["tenant_ref"]).To(gomega.HaveSuffix("#tenant-1"))
+
+	var resp session.AviCollectionResult
+	resp, err = adminClient().GetCollectionRaw("/api/gslbservice?name=tenant-gs")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(resp.Count).To(gomega.Equal(0))
+	resp, err = tenantClient.GetCollectionRaw("/api/gslbservice?name=tenant-gs")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(resp.Count).To(gomega.Equal(1))
+	resp, err = adminClient().GetCollectionRaw("/api/gslbservice?name=tenant-gs", session.SetOptTenant("*"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(resp.Count).To(gomega.Equal(1))
+
+	g.Expect(sim.Delete("gslbservice", "tenant-1", "tenant-gs")).To(gomega.BeTrue())
+}
+
+func TestRestLayerAndCacheRefresh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "e2e.avi.com"
+	gsGraph := buildTestGSGraph(host, []string{"10.10.10.1", "10.10.10.2"})
+	syncGSGraph(gsGraph)
+
+	gs, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(gsMemberIPs(gs)).To(gomega.ConsistOf("10.10.10.1", "10.10.10.2"))
+	g.Expect(gs["health_monitor_refs"]).To(gomega.HaveLen(1))
+	_, found = sim.Get("healthmonitor", utils.ADMIN_NS, gsGraph.Hm.PathHM[0].Name)
+	g.Expect(found).To(gomega.BeTrue())
+	_, found = avicache.GetAviCache().AviCacheGet(avicache.TenantName{Tenant: utils.ADMIN_NS, Name: host})
+	g.Expect(found).To(gomega.BeTrue())
+
+	// a member removed on the controller is added back by the cache refresh
+	g.Expect(sim.Update("gslbservice", utils.ADMIN_NS, host, func(data map[string]interface{}) {
+		group := data["groups"].([]interface{})[0].(map[string]interface{})
+		group["members"] = group["members"].([]interface{})[:1]
+	})).To(gomega.Succeed())
+	gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(gsMemberIPs(gs)).To(gomega.HaveLen(1))
+
+	ingestion.CacheRefreshRoutine()
+	g.Eventually(func() []string {
+		gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		return gsMemberIPs(gs)
+	}, 10*time.Second, 500*time.Millisecond).Should(gomega.ConsistOf("10.10.10.1", "10.10.10.2"))
+}
+
+func TestServerErrorFault(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	drainRetryKeys()
+	host := "fault.avi.com"
+	sim.AddFault(mockaviserver.Fault{Method: http.MethodPost, ObjType: "gslbservice", StatusCode: http.StatusServiceUnavailable, Count: 1})
+	defer sim.ClearFaults()
+
+	gsGraph := buildTestGSGraph(host, []string{"10.10.20.1"})
+	syncGSGraph(gsGraph)
+	_, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeFalse())
+	g.Eventually(retryKeys, 5*time.Second).Should(gomega.Receive(gomega.Equal(utils.ADMIN_NS + "/" + host)))
+
+	// the fault was injected once, so the retry succeeds
+	syncGSGraph(gsGraph)
+	_, found = sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeTrue())
+}
+
+func TestLatencyFault(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	sim.AddFault(mockaviserver.Fault{Method: http.MethodGet, ObjType: "cluster", Latency: 300 * time.Millisecond, Count: 1})
+	defer sim.ClearFaults()
+
+	start := time.Now()
+	leader, err := avicache.IsAviSiteLeader()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(leader).To(gomega.BeTrue())
+	g.Expect(time.Since(start)).To(gomega.BeNumerically(">=", 300*time.Millisecond))
+}
+
+func TestLeaderChange(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "leader.avi.com"
+	sim.SetLeader(false)
+	defer func() {
+		sim.SetLeader(true)
+		gslbutils.SetControllerAsLeader()
+	}()
+
+	g.Expect(ingestion.CheckAndSetGslbLeader()).To(gomega.HaveOccurred())
+	g.Expect(gslbutils.IsControllerLeader()).To(gomega.BeFalse())
+
+	// the rest layer stops syncing once the controller rejects the writes as a follower
+	gslbutils.SetControllerAsLeader()
+	syncGSGraph(buildTestGSGraph(host, []string{"10.10.30.1"}))
+	_, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(found).To(gomega.BeFalse())
+	g.Expect(gslbutils.IsControllerLeader()).To(gomega.BeFalse())
+
+	sim.SetLeader(true)
+	g.Expect(ingestion.CheckAndSetGslbLeader()).To(gomega.Succeed())
+	g.Expect(gslbutils.IsControllerLeader()).To(gomega.BeTrue())
+}
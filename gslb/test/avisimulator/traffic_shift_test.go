@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	gslbfake "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
+	gslbListers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/listers/amko/v1alpha1"
+)
+
+// trafficShiftSplit returns the traffic split set by the GSLBTrafficShift objects for an fqdn, nil if
+// there's none.
+func trafficShiftSplit(fqdn string) []gslbalphav1.TrafficSplitElem {
+	var ghr gslbutils.GSHostRules
+	if !gslbutils.GetGSHostRulesList().ApplyTrafficShift(fqdn, &ghr) {
+		return nil
+	}
+	return ghr.TrafficSplit
+}
+
+func getTrafficShiftStatus(g *gomega.WithT, ts *gslbalphav1.GSLBTrafficShift) *gslbalphav1.GSLBTrafficShift {
+	obj, err := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBTrafficShifts(ts.Namespace).Get(context.TODO(),
+		ts.Name, metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	return obj
+}
+
+// expireTrafficShiftStep sets the object in the lister to the one in the clientset, with the step
+// interval of its current step passed.
+func expireTrafficShiftStep(g *gomega.WithT, indexer cache.Indexer, ts *gslbalphav1.GSLBTrafficShift) {
+	obj := getTrafficShiftStatus(g, ts)
+	lastStepTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	obj.Status.LastStepTime = &lastStepTime
+	g.Expect(indexer.Update(obj)).To(gomega.Succeed())
+}
+
+func TestGSLBTrafficShift(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "traffic-shift.avi.com"
+	gslbutils.AddClusterContext("cluster1")
+	gslbutils.AddClusterContext("cluster2")
+	// the namespace doesn't have a tenant annotation, the GslbServices are in the default tenant
+	store.GetNamespaceToTenantStore().AddOrUpdate(gslbutils.LeaderClusterContext, DefaultNS, "")
+	syncGSGraph(buildTestGSGraph(host, []string{"10.120.1.1", "10.120.1.2"}))
+
+	stepInterval := 30
+	ts := &gslbalphav1.GSLBTrafficShift{
+		ObjectMeta: metav1.ObjectMeta{Name: "shift", Namespace: DefaultNS, ResourceVersion: "1"},
+		Spec: gslbalphav1.GSLBTrafficShiftSpec{
+			Fqdn:           host,
+			SourceClusters: []string{"cluster1"},
+			TargetClusters: []string{"cluster2"},
+			Steps:          []uint32{20, 100},
+			StepInterval:   &stepInterval,
+		},
+	}
+	gslbutils.AMKOControlConfig().SetGSLBClientset(gslbfake.NewSimpleClientset(ts))
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := gslbListers.NewGSLBTrafficShiftLister(indexer)
+	k8swq := []workqueue.RateLimitingInterface{workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+	defer k8swq[0].ShutDown()
+
+	ingestion.AddGSLBTrafficShiftObj(ts, k8swq, 1)
+	obj := getTrafficShiftStatus(g, ts)
+	g.Expect(obj.Status.Phase).To(gomega.Equal(gslbalphav1.TrafficShiftProgressing))
+	g.Expect(obj.Status.CurrentStep).To(gomega.Equal(1))
+	g.Expect(trafficShiftSplit(host)).To(gomega.ConsistOf(
+		gslbalphav1.TrafficSplitElem{Cluster: "cluster1", Weight: 4, Priority: 10},
+		gslbalphav1.TrafficSplitElem{Cluster: "cluster2", Weight: 1, Priority: 10},
+	))
+	g.Eventually(k8swq[0].Len, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(1))
+
+	// the step interval hasn't passed yet
+	g.Expect(indexer.Add(obj)).To(gomega.Succeed())
+	ingestion.SyncTrafficShifts(lister, k8swq, 1)
+	g.Expect(getTrafficShiftStatus(g, ts).Status.CurrentStep).To(gomega.Equal(1))
+
+	// at 100%, the source cluster only serves as a backup
+	expireTrafficShiftStep(g, indexer, ts)
+	ingestion.SyncTrafficShifts(lister, k8swq, 1)
+	obj = getTrafficShiftStatus(g, ts)
+	g.Expect(obj.Status.Phase).To(gomega.Equal(gslbalphav1.TrafficShiftProgressing))
+	g.Expect(obj.Status.CurrentStep).To(gomega.Equal(2))
+	g.Expect(trafficShiftSplit(host)).To(gomega.ConsistOf(
+		gslbalphav1.TrafficSplitElem{Cluster: "cluster1", Weight: 1, Priority: 9},
+		gslbalphav1.TrafficSplitElem{Cluster: "cluster2", Weight: 1, Priority: 10},
+	))
+
+	expireTrafficShiftStep(g, indexer, ts)
+	ingestion.SyncTrafficShifts(lister, k8swq, 1)
+	obj = getTrafficShiftStatus(g, ts)
+	g.Expect(obj.Status.Phase).To(gomega.Equal(gslbalphav1.TrafficShiftCompleted))
+	g.Expect(trafficShiftSplit(host)).To(gomega.HaveLen(2))
+
+	// a spec change restarts the traffic shift, which is rolled back if a target member goes down
+	sim.SetMemberOperState("10.120.1.2", "OPER_DOWN")
+	defer sim.SetMemberOperState("10.120.1.2", "")
+	newTs := obj.DeepCopy()
+	newTs.Spec.Steps = []uint32{50, 100}
+	newTs.ResourceVersion = "2"
+	_, err := gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBTrafficShifts(DefaultNS).Update(context.TODO(),
+		newTs, metav1.UpdateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	ingestion.UpdateGSLBTrafficShiftObj(obj, newTs, k8swq, 1)
+	obj = getTrafficShiftStatus(g, ts)
+	g.Expect(obj.Status.Phase).To(gomega.Equal(gslbalphav1.TrafficShiftProgressing))
+	g.Expect(obj.Status.CurrentStep).To(gomega.Equal(1))
+	g.Expect(trafficShiftSplit(host)).To(gomega.ConsistOf(
+		gslbalphav1.TrafficSplitElem{Cluster: "cluster1", Weight: 1, Priority: 10},
+		gslbalphav1.TrafficSplitElem{Cluster: "cluster2", Weight: 1, Priority: 10},
+	))
+
+	expireTrafficShiftStep(g, indexer, ts)
+	ingestion.SyncTrafficShifts(lister, k8swq, 1)
+	obj = getTrafficShiftStatus(g, ts)
+	g.Expect(obj.Status.Phase).To(gomega.Equal(gslbalphav1.TrafficShiftRolledBack))
+	g.Expect(obj.Status.CurrentStep).To(gomega.Equal(1))
+	g.Expect(obj.Status.Message).To(gomega.ContainSubstring("10.120.1.2"))
+	g.Expect(trafficShiftSplit(host)).To(gomega.BeNil())
+
+	// a rejected object doesn't shift the traffic
+	rejectedTs := &gslbalphav1.GSLBTrafficShift{
+		ObjectMeta: metav1.ObjectMeta{Name: "rejected-shift", Namespace: DefaultNS, ResourceVersion: "1"},
+		Spec: gslbalphav1.GSLBTrafficShiftSpec{
+			Fqdn:           host,
+			SourceClusters: []string{"cluster1"},
+			TargetClusters: []string{"cluster3"},
+			Steps:          []uint32{50},
+		},
+	}
+	_, err = gslbutils.AMKOControlConfig().GSLBClientset().AmkoV1alpha1().GSLBTrafficShifts(DefaultNS).Create(context.TODO(),
+		rejectedTs, metav1.CreateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	ingestion.AddGSLBTrafficShiftObj(rejectedTs, k8swq, 1)
+	obj = getTrafficShiftStatus(g, rejectedTs)
+	g.Expect(obj.Status.Phase).To(gomega.Equal(gslbalphav1.TrafficShiftRejected))
+	g.Expect(obj.Status.Message).To(gomega.ContainSubstring("cluster3"))
+	g.Expect(trafficShiftSplit(host)).To(gomega.BeNil())
+}
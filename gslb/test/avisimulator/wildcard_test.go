@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package avisimulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+func TestWildcardGS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	wildcardHost := "*.wildcard.avi.com"
+	host := "app.wildcard.avi.com"
+
+	// a route with the Subdomain wildcard policy serves the wildcard fqdn of the domain of its host
+	route := buildRoute("wildcard-route", host, "10.100.1.1")
+	route.Spec.WildcardPolicy = routev1.WildcardPolicySubdomain
+	syncRoute(route, "cluster1")
+	// the status hostname of the wildcard host of an ingress is its domain
+	ingress := buildIngress("wildcard-ing", map[string]string{host: "10.100.2.1", wildcardHost: "10.100.2.2"})
+	for idx := range ingress.Status.LoadBalancer.Ingress {
+		if ingress.Status.LoadBalancer.Ingress[idx].Hostname == wildcardHost {
+			ingress.Status.LoadBalancer.Ingress[idx].Hostname = "wildcard.avi.com"
+		}
+	}
+	syncIngress(ingress, "cluster2")
+
+	var gs map[string]interface{}
+	g.Eventually(func() []string {
+		gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, wildcardHost)
+		if gs == nil {
+			return nil
+		}
+		return gsMemberIPs(gs)
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.ConsistOf("10.100.1.1", "10.100.2.2"))
+	g.Expect(gs["domain_names"]).To(gomega.ConsistOf(wildcardHost))
+	g.Expect(gs["wildcard_match"]).To(gomega.BeTrue())
+
+	// the GslbService of a specific fqdn in the wildcard domain is kept separate
+	g.Eventually(func() []string {
+		gs, _ = sim.Get("gslbservice", utils.ADMIN_NS, host)
+		if gs == nil {
+			return nil
+		}
+		return gsMemberIPs(gs)
+	}, 5*time.Second, 100*time.Millisecond).Should(gomega.ConsistOf("10.100.2.1"))
+	g.Expect(gs["domain_names"]).To(gomega.ConsistOf(host))
+	g.Expect(gs["wildcard_match"]).To(gomega.BeFalse())
+}
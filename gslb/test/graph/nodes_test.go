@@ -25,6 +25,7 @@ import (
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/test/ingestion"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
 
 	"github.com/onsi/gomega"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -65,10 +66,10 @@ func setUp() {
 	keyChan = make(chan string)
 
 	setupQueue(testStopCh)
-	gf := gslbutils.GetGlobalFilter()
-	gf.ApplicableClusters = make(map[string]gslbutils.ClusterProperties)
+	gf := gslbutils.NewGDPFilter("test-gdp")
 	gf.ApplicableClusters[FooCluster] = gslbutils.ClusterProperties{SyncVipsOnly: true}
 	gf.ApplicableClusters[BarCluster] = gslbutils.ClusterProperties{SyncVipsOnly: true}
+	gslbutils.GetGlobalFilter().AddFilter(gf)
 	registeredInformers := []string{
 		utils.NSInformer,
 	}
@@ -647,3 +648,394 @@ func TestGSGraphsForMultiMCIhms(t *testing.T) {
 	waitAndVerify(t, "admin"+"/"+ihm2.Hostname, false)
 	verifyGsGraph(t, ihm2, false, 0, false)
 }
+
+func addMultiPortSvcMeta(name, host, ip, cname string, ports []k8sobjects.SvcPort, create bool) k8sobjects.SvcMeta {
+	op := gslbutils.ObjectAdd
+	if !create {
+		op = gslbutils.ObjectUpdate
+	}
+	svcMeta := k8sobjects.SvcMeta{
+		Name:      name,
+		Namespace: DefNS,
+		Hostname:  host,
+		IPAddr:    ip,
+		Cluster:   cname,
+		Port:      ports[0].Port,
+		Protocol:  ports[0].Protocol,
+		Ports:     ports,
+		Tenant:    "admin",
+	}
+	store.GetAcceptedLBSvcStore().AddOrUpdate(svcMeta, cname, DefNS, name)
+	addKeyToIngestionQueue(DefNS, ingestion.GetSvcKey(op, cname, DefNS, name, "admin"))
+	return svcMeta
+}
+
+func getPortHmPorts(t *testing.T, gsName string) []k8sobjects.SvcPort {
+	ok, aviModelIntf := nodes.SharedAviGSGraphLister().Get("admin/" + gsName)
+	if !ok {
+		t.Fatalf("GS graph %s not found", gsName)
+	}
+	gsGraph := aviModelIntf.(*nodes.AviGSObjectGraph).GetCopy()
+	ports := []k8sobjects.SvcPort{}
+	for _, portHm := range gsGraph.Hm.PortHM {
+		ports = append(ports, k8sobjects.SvcPort{Port: portHm.Port, Protocol: portHm.Protocol})
+	}
+	return ports
+}
+
+func TestGSGraphsForMultiPortSvc(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	prefix := "mps-"
+	hostname := prefix + "host1.avi.com"
+	fooSvc := prefix + "foo-svc1"
+	barSvc := prefix + "bar-svc1"
+
+	svc1 := addMultiPortSvcMeta(fooSvc, hostname, "10.10.10.10", FooCluster, []k8sobjects.SvcPort{
+		{Port: 53, Protocol: gslbutils.ProtocolUDP},
+		{Port: 80, Protocol: gslbutils.ProtocolTCP},
+		{Port: 443, Protocol: gslbutils.ProtocolTCP},
+	}, true)
+	ok, msg := waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	verifyGsGraph(t, svc1, true, 1, true)
+	_, aviModelIntf := nodes.SharedAviGSGraphLister().Get("admin/" + hostname)
+	gsGraph := aviModelIntf.(*nodes.AviGSObjectGraph).GetCopy()
+	// the lowest port is monitored by the primary non path HM, and the rest by the port HMs
+	g.Expect(gsGraph.Hm.Port).To(gomega.Equal(int32(53)))
+	g.Expect(gsGraph.Hm.HMProtocol).To(gomega.Equal(gslbutils.SystemHealthMonitorTypeUDP))
+	g.Expect(getPortHmPorts(t, hostname)).To(gomega.Equal([]k8sobjects.SvcPort{
+		{Port: 80, Protocol: gslbutils.ProtocolTCP},
+		{Port: 443, Protocol: gslbutils.ProtocolTCP},
+	}))
+	g.Expect(gsGraph.Hm.PortHM[0].GetPortHMDescription(hostname)).To(gomega.Equal(
+		nodes.CreatedByAMKO + ", gsname: " + hostname + ", port: 80, protocol: TCP"))
+	g.Expect(nodes.IsPortHmDescription(gsGraph.Hm.PortHM[0].GetPortHMDescription(hostname))).To(gomega.BeTrue())
+
+	// a member in another cluster adds its ports to the GS
+	svc2 := addMultiPortSvcMeta(barSvc, hostname, "10.10.10.20", BarCluster, []k8sobjects.SvcPort{
+		{Port: 53, Protocol: gslbutils.ProtocolUDP},
+		{Port: 8080, Protocol: gslbutils.ProtocolTCP},
+	}, true)
+	ok, msg = waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	verifyGsGraph(t, svc2, true, 2, true)
+	g.Expect(getPortHmPorts(t, hostname)).To(gomega.Equal([]k8sobjects.SvcPort{
+		{Port: 80, Protocol: gslbutils.ProtocolTCP},
+		{Port: 443, Protocol: gslbutils.ProtocolTCP},
+		{Port: 8080, Protocol: gslbutils.ProtocolTCP},
+	}))
+
+	// removing ports from a member removes their HMs
+	addMultiPortSvcMeta(fooSvc, hostname, "10.10.10.10", FooCluster, []k8sobjects.SvcPort{
+		{Port: 53, Protocol: gslbutils.ProtocolUDP},
+	}, false)
+	ok, msg = waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	g.Expect(getPortHmPorts(t, hostname)).To(gomega.Equal([]k8sobjects.SvcPort{
+		{Port: 8080, Protocol: gslbutils.ProtocolTCP},
+	}))
+
+	// delete the svcs
+	for _, svc := range []k8sobjects.SvcMeta{svc1, svc2} {
+		store.GetAcceptedLBSvcStore().DeleteClusterNSObj(svc.Cluster, svc.Namespace, svc.Name)
+		addKeyToIngestionQueue(DefNS, GetSvcKey(gslbutils.ObjectDelete, svc))
+		waitAndVerify(t, "admin/"+hostname, false)
+	}
+	verifyGsGraph(t, svc1, false, 0, false)
+}
+
+func getGSMemberWeights(t *testing.T, gsName string) map[string][2]uint32 {
+	ok, aviModelIntf := nodes.SharedAviGSGraphLister().Get("admin/" + gsName)
+	if !ok {
+		t.Fatalf("GS graph for %s not found", gsName)
+	}
+	weights := make(map[string][2]uint32)
+	for _, member := range aviModelIntf.(*nodes.AviGSObjectGraph).GetCopy().MemberObjs {
+		weights[member.Name] = [2]uint32{member.Weight, member.Priority}
+	}
+	return weights
+}
+
+func TestGSGraphsForMemberTrafficSplit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gslbutils.NewAviControllerConfig("admin", "admin", "url", "18.2.9", "admin")
+
+	prefix := "mts-"
+	hostname := prefix + "host1.avi.com"
+	blueIng := prefix + "blue-ing"
+	greenIng := prefix + "green-ing"
+	gsHostRulesList := gslbutils.GetGSHostRulesList()
+	gsHostRulesList.BuildAndSetGSHostRulesForFQDN(&gslbalphav1.GSLBHostRule{
+		Spec: gslbalphav1.GSLBHostRuleSpec{
+			Fqdn: hostname,
+			MemberTrafficSplit: []gslbalphav1.MemberTrafficSplitElem{
+				{Cluster: FooCluster, Namespace: DefNS, Kind: gslbutils.MemberKindIngress, Name: blueIng, Weight: 18, Priority: 5},
+			},
+		},
+	})
+	defer gsHostRulesList.DeleteGSHostRulesForFQDN(hostname)
+
+	ihm1 := AddIngressMeta(t, blueIng, DefNS, hostname, DefSvc, "10.10.10.10", FooCluster, true)
+	ok, msg := waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	ihm2 := AddIngressMeta(t, greenIng, DefNS, hostname, DefSvc, "10.10.10.20", FooCluster, true)
+	ok, msg = waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	verifyGsGraph(t, ihm2, true, 2, true)
+
+	// only the member named in the GSLBHostRule is weighted, the other member in the same cluster
+	// still gets the GDP's weight
+	weights := getGSMemberWeights(t, hostname)
+	g.Expect(weights[ihm1.ObjName]).To(gomega.Equal([2]uint32{18, 5}))
+	g.Expect(weights[ihm2.ObjName]).To(gomega.Equal([2]uint32{nodes.GetObjTrafficRatio(DefNS, FooCluster),
+		nodes.GetObjTrafficPriority(DefNS, FooCluster)}))
+
+	// delete the ingresses
+	for _, ihm := range []k8sobjects.IngressHostMeta{ihm1, ihm2} {
+		store.GetAcceptedIngressStore().DeleteClusterNSObj(ihm.Cluster, ihm.Namespace, ihm.ObjName)
+		addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectDelete, ihm))
+		waitAndVerify(t, "admin/"+hostname, false)
+	}
+	verifyGsGraph(t, ihm1, false, 0, false)
+}
+
+func TestGSGraphsForTrafficShift(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gslbutils.NewAviControllerConfig("admin", "admin", "url", "18.2.9", "admin")
+
+	prefix := "gts-"
+	hostname := prefix + "host1.avi.com"
+	gsHostRulesList := gslbutils.GetGSHostRulesList()
+	gsHostRulesList.BuildAndSetGSHostRulesForFQDN(&gslbalphav1.GSLBHostRule{
+		Spec: gslbalphav1.GSLBHostRuleSpec{
+			Fqdn: hostname,
+			TrafficSplit: []gslbalphav1.TrafficSplitElem{
+				{Cluster: FooCluster, Weight: 15, Priority: 5},
+				{Cluster: BarCluster, Weight: 5, Priority: 5},
+			},
+		},
+	})
+	defer gsHostRulesList.DeleteGSHostRulesForFQDN(hostname)
+
+	// the traffic split of the GSLBTrafficShift object takes precedence over the GSLBHostRule's
+	gsHostRulesList.SetTrafficShiftForFQDN(hostname, []gslbalphav1.TrafficSplitElem{
+		{Cluster: FooCluster, Weight: 1, Priority: 4},
+		{Cluster: BarCluster, Weight: 1, Priority: 5},
+	})
+	defer gsHostRulesList.DeleteTrafficShiftForFQDN(hostname)
+
+	ihm1 := AddIngressMeta(t, prefix+"foo-ing", DefNS, hostname, DefSvc, "10.10.10.30", FooCluster, true)
+	ok, msg := waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	ihm2 := AddIngressMeta(t, prefix+"bar-ing", DefNS, hostname, DefSvc, "10.10.10.40", BarCluster, true)
+	ok, msg = waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	verifyGsGraph(t, ihm2, true, 2, true)
+
+	weights := getGSMemberWeights(t, hostname)
+	g.Expect(weights[ihm1.ObjName]).To(gomega.Equal([2]uint32{1, 4}))
+	g.Expect(weights[ihm2.ObjName]).To(gomega.Equal([2]uint32{1, 5}))
+
+	// delete the ingresses
+	for _, ihm := range []k8sobjects.IngressHostMeta{ihm1, ihm2} {
+		store.GetAcceptedIngressStore().DeleteClusterNSObj(ihm.Cluster, ihm.Namespace, ihm.ObjName)
+		addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectDelete, ihm))
+		waitAndVerify(t, "admin/"+hostname, false)
+	}
+	verifyGsGraph(t, ihm1, false, 0, false)
+}
+
+func getGSMemberDrained(t *testing.T, gsName string) map[string]bool {
+	ok, aviModelIntf := nodes.SharedAviGSGraphLister().Get("admin/" + gsName)
+	if !ok {
+		t.Fatalf("GS graph for %s not found", gsName)
+	}
+	drained := make(map[string]bool)
+	for _, member := range aviModelIntf.(*nodes.AviGSObjectGraph).GetCopy().MemberObjs {
+		drained[member.Name] = member.Drained
+	}
+	return drained
+}
+
+func TestGSGraphsForDrain(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gslbutils.NewAviControllerConfig("admin", "admin", "url", "18.2.9", "admin")
+
+	prefix := "drain-"
+	hostname := prefix + "host1.avi.com"
+	ihm1 := AddIngressMeta(t, prefix+"foo-ing", DefNS, hostname, DefSvc, "10.10.10.50", FooCluster, true)
+	ok, msg := waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	ihm2 := AddIngressMeta(t, prefix+"bar-ing", DefNS, hostname, DefSvc, "10.10.10.60", BarCluster, true)
+	ok, msg = waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	verifyGsGraph(t, ihm2, true, 2, true)
+	g.Expect(getGSMemberDrained(t, hostname)).To(gomega.Equal(map[string]bool{ihm1.ObjName: false, ihm2.ObjName: false}))
+
+	// drain the bar cluster
+	gf := gslbutils.GetGlobalFilter().GetFilter("test-gdp")
+	gf.Lock.Lock()
+	gf.ApplicableClusters[BarCluster] = gslbutils.ClusterProperties{SyncVipsOnly: true, Drain: true}
+	gf.Lock.Unlock()
+	addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectUpdate, ihm2))
+	waitAndVerify(t, "admin/"+hostname, false)
+	g.Expect(getGSMemberDrained(t, hostname)).To(gomega.Equal(map[string]bool{ihm1.ObjName: false, ihm2.ObjName: true}))
+
+	gf.Lock.Lock()
+	gf.ApplicableClusters[BarCluster] = gslbutils.ClusterProperties{SyncVipsOnly: true}
+	gf.Lock.Unlock()
+	addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectUpdate, ihm2))
+	waitAndVerify(t, "admin/"+hostname, false)
+	g.Expect(getGSMemberDrained(t, hostname)).To(gomega.Equal(map[string]bool{ihm1.ObjName: false, ihm2.ObjName: false}))
+
+	// drain only the foo ingress
+	ihm1.Drained = true
+	store.GetAcceptedIngressStore().AddOrUpdate(ihm1, ihm1.Cluster, ihm1.Namespace, ihm1.ObjName)
+	addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectUpdate, ihm1))
+	waitAndVerify(t, "admin/"+hostname, false)
+	g.Expect(getGSMemberDrained(t, hostname)).To(gomega.Equal(map[string]bool{ihm1.ObjName: true, ihm2.ObjName: false}))
+
+	// delete the ingresses
+	for _, ihm := range []k8sobjects.IngressHostMeta{ihm1, ihm2} {
+		store.GetAcceptedIngressStore().DeleteClusterNSObj(ihm.Cluster, ihm.Namespace, ihm.ObjName)
+		addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectDelete, ihm))
+		waitAndVerify(t, "admin/"+hostname, false)
+	}
+	verifyGsGraph(t, ihm1, false, 0, false)
+}
+
+func AddRouteMeta(t *testing.T, name, ns, host, wildcardFqdn, ip, cname string, create bool) k8sobjects.RouteMeta {
+	acceptedRouteStore := store.GetAcceptedRouteStore()
+	op := gslbutils.ObjectAdd
+	if !create {
+		op = gslbutils.ObjectUpdate
+	}
+	key := ingestion.GetRouteKey(op, cname, ns, name, "admin")
+	routeMeta := k8sobjects.RouteMeta{
+		Name:         name,
+		Namespace:    ns,
+		Hostname:     host,
+		WildcardFqdn: wildcardFqdn,
+		IPAddr:       ip,
+		Cluster:      cname,
+		Paths:        []string{"/"},
+		Tenant:       "admin",
+	}
+	acceptedRouteStore.AddOrUpdate(routeMeta, cname, ns, name)
+	addKeyToIngestionQueue(ns, key)
+	return routeMeta
+}
+
+func getGSMemberNames(t *testing.T, gsName string) []string {
+	ok, aviModelIntf := nodes.SharedAviGSGraphLister().Get("admin/" + gsName)
+	if !ok {
+		return nil
+	}
+	names := []string{}
+	for _, member := range aviModelIntf.(*nodes.AviGSObjectGraph).GetCopy().MemberObjs {
+		names = append(names, member.Name)
+	}
+	return names
+}
+
+func TestGSGraphsForWildcardAndSpecificFqdns(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gslbutils.NewAviControllerConfig("admin", "admin", "url", "18.2.9", "admin")
+
+	prefix := "wc-"
+	wildcardFqdn := "*." + prefix + "avi.com"
+	hostname := prefix + "app." + prefix + "avi.com"
+
+	// a route with the Subdomain wildcard policy, for the same host as the ingress below
+	routeMeta := AddRouteMeta(t, prefix+"foo-route", DefNS, hostname, wildcardFqdn, "10.10.10.70", FooCluster, true)
+	ok, msg := waitAndVerify(t, "admin/"+wildcardFqdn, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	ihm := AddIngressMeta(t, prefix+"bar-ing", DefNS, hostname, DefSvc, "10.10.10.80", BarCluster, true)
+	ok, msg = waitAndVerify(t, "admin/"+hostname, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	// an ingress with a wildcard host joins the route in the wildcard GS
+	wcIhm := AddIngressMeta(t, prefix+"bar-wc-ing", DefNS, wildcardFqdn, DefSvc, "10.10.10.90", BarCluster, true)
+	ok, msg = waitAndVerify(t, "admin/"+wildcardFqdn, false)
+	if !ok {
+		t.Fatalf("%s", msg)
+	}
+	g.Expect(getGSMemberNames(t, wildcardFqdn)).To(gomega.ConsistOf(routeMeta.Name, wcIhm.ObjName))
+	g.Expect(getGSMemberNames(t, hostname)).To(gomega.ConsistOf(ihm.ObjName))
+
+	// deleting the route removes it from the wildcard GS only
+	store.GetAcceptedRouteStore().DeleteClusterNSObj(routeMeta.Cluster, routeMeta.Namespace, routeMeta.Name)
+	addKeyToIngestionQueue(DefNS, ingestion.GetRouteKey(gslbutils.ObjectDelete, routeMeta.Cluster, DefNS, routeMeta.Name, "admin"))
+	waitAndVerify(t, "admin/"+wildcardFqdn, false)
+	g.Expect(getGSMemberNames(t, wildcardFqdn)).To(gomega.ConsistOf(wcIhm.ObjName))
+	g.Expect(getGSMemberNames(t, hostname)).To(gomega.ConsistOf(ihm.ObjName))
+
+	for _, obj := range []k8sobjects.IngressHostMeta{ihm, wcIhm} {
+		store.GetAcceptedIngressStore().DeleteClusterNSObj(obj.Cluster, obj.Namespace, obj.ObjName)
+		addKeyToIngestionQueue(DefNS, GetIhmKey(gslbutils.ObjectDelete, obj))
+		waitAndVerify(t, "admin/"+obj.Hostname, false)
+	}
+	g.Expect(getGSMemberNames(t, wildcardFqdn)).To(gomega.BeNil())
+	g.Expect(getGSMemberNames(t, hostname)).To(gomega.BeNil())
+}
+
+func TestGSGraphPools(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	gsGraph := &nodes.AviGSObjectGraph{
+		Name:     "pools.avi.com",
+		Lock:     new(sync.RWMutex),
+		IPFamily: gslbalphav1.IPFamilyV4,
+		HmRefs:   []string{"hm1", "hm2"},
+		MemberObjs: []nodes.AviGSK8sObj{
+			// the highest priority member has no address of the GS's IP family, so it makes no pool
+			{Cluster: FooCluster, Name: "v6-only", IPAddr: "2001:db8::10", Priority: 30},
+			{Cluster: FooCluster, Name: "foo", IPAddr: "10.10.10.10", Priority: 20},
+			{Cluster: BarCluster, Name: "bar", IPAddr: "10.10.10.20", Priority: 10},
+			// a duplicate address makes no new member
+			{Cluster: BarCluster, Name: "bar-dup", IPAddr: "10.10.10.20", Priority: 5},
+		},
+	}
+	pools := gsGraph.GetPools("test")
+	g.Expect(pools).To(gomega.HaveLen(2))
+	g.Expect(pools[0].Priority).To(gomega.Equal(uint32(20)))
+	g.Expect(pools[0].Members).To(gomega.HaveLen(1))
+	g.Expect(pools[0].Members[0].Name).To(gomega.Equal("foo"))
+	g.Expect(pools[1].Priority).To(gomega.Equal(uint32(10)))
+	g.Expect(pools[1].Members).To(gomega.HaveLen(1))
+	g.Expect(pools[1].Members[0].Name).To(gomega.Equal("bar"))
+
+	// the first pool which is built needs one more than the number of custom health monitors
+	g.Expect(pools[0].MinHealthMonitorsUp).To(gomega.Equal(uint32(3)))
+	g.Expect(pools[1].MinHealthMonitorsUp).To(gomega.Equal(uint32(2)))
+	g.Expect(gsGraph.GetGSSettings().PoolsMinHealthMonitorsUp).To(gomega.Equal([]uint32{3, 2}))
+
+	// a configured minimum applies to all the pools
+	minHmUp := uint32(1)
+	gsGraph.GSSettings.MinHealthMonitorsUp = &minHmUp
+	pools = gsGraph.GetPools("test")
+	g.Expect(pools[0].MinHealthMonitorsUp).To(gomega.Equal(uint32(1)))
+	g.Expect(pools[1].MinHealthMonitorsUp).To(gomega.Equal(uint32(1)))
+	g.Expect(gsGraph.GetGSSettings().PoolsMinHealthMonitorsUp).To(gomega.Equal([]uint32{1, 1}))
+}
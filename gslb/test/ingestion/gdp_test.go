@@ -23,12 +23,14 @@ import (
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
 
 	gslbingestion "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
 	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
 	gdpfake "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned/fake"
 	gdpinformers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha2/informers/externalversions"
 
 	"github.com/onsi/gomega"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
@@ -241,8 +243,13 @@ func TestMultipleGDPObjectsForSameNS(t *testing.T) {
 	t.Logf("adding another gdp object")
 	AddTestGDPObj(anotherGdp)
 
-	// check the status of this new object
-	g.Expect(anotherGdp.Status.ErrorStatus).To(gomega.Equal("a GDP object already exists, can't add another"))
+	// multiple GDP objects are allowed, the new object must be accepted
+	g.Expect(anotherGdp.Status.ErrorStatus).To(gomega.Equal("success"))
+
+	// the accepted ingresses have to be re-evaluated for the GDP properties
+	keys1 := GetMultipleIngKeys(t, "UPDATE", ingList1, cname1, ns)
+	keys2 := GetMultipleIngKeys(t, "UPDATE", ingList2, cname2, ns)
+	VerifyAllKeys(t, append(keys1, keys2...), false)
 
 	t.Logf("Deleting ingresses for cluster1")
 	DeleteMultipleIngresses(t, fooKubeClient, ingList1)
@@ -250,14 +257,315 @@ func TestMultipleGDPObjectsForSameNS(t *testing.T) {
 	DeleteMultipleIngresses(t, barKubeClient, ingList2)
 
 	// verify delete keys
-	keys1 := GetMultipleIngDeleteKeys(t, ingList1, cname1, ns)
-	keys2 := GetMultipleIngDeleteKeys(t, ingList2, cname2, ns)
+	keys1 = GetMultipleIngDeleteKeys(t, ingList1, cname1, ns)
+	keys2 = GetMultipleIngDeleteKeys(t, ingList2, cname2, ns)
 	allKeys = append(keys1, keys2...)
 	VerifyAllKeys(t, allKeys, false)
 	DeleteTestGDPObj(gdp)
 	DeleteTestGDPObj(anotherGdp)
 }
 
+func TestMultipleGDPObjectsScopedToClusters(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "mgsc-"
+	ingNameList := []string{testPrefix + "def-ing1", testPrefix + "def-ing2"}
+	hosts := []string{testPrefix + TestDomain1, testPrefix + TestDomain2}
+	ipAddrs := []string{"10.10.10.10", "10.10.10.11"}
+	cname1 := "cluster1"
+	cname2 := "cluster2"
+	ns := "default"
+	svc := "test-svc"
+
+	extIngName := testPrefix + "def-ing3"
+	extHost := testPrefix + TestDomain3
+	extHostMap := map[string]string{extHost: "10.10.10.12"}
+
+	buildAndAddTestGSLBObject(t)
+
+	t.Logf("Adding GDP objects")
+	// first GDP object selects the "key": "value" ingresses of cluster1
+	gdp := getTestGDPObject(true, false)
+	gdp.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: cname1, SyncVipOnly: true}}
+	AddTestGDPObj(gdp)
+	// second GDP object selects the "key": "test" ingresses of cluster2
+	anotherGdp := getTestGDPObject(true, false)
+	anotherGdp.ObjectMeta.Name = "test-gdp-2"
+	UpdateGDPMatchRuleAppLabel(anotherGdp, "key", "test")
+	anotherGdp.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: cname2, SyncVipOnly: true}}
+	AddTestGDPObj(anotherGdp)
+
+	g.Expect(gdp.Status.ErrorStatus).To(gomega.Equal("success"))
+	g.Expect(anotherGdp.Status.ErrorStatus).To(gomega.Equal("success"))
+
+	ingList1, allKeys := CreateMultipleIngresses(t, fooKubeClient, ingNameList, hosts, ipAddrs, ns, svc, cname1)
+	CreateIngressObjWithLabel(t, fooKubeClient, extIngName, ns, svc, cname1, extHostMap, true, "key", "test")
+	ingList2, _ := CreateMultipleIngresses(t, barKubeClient, ingNameList, hosts, ipAddrs, ns, svc, cname2)
+	CreateIngressObjWithLabel(t, barKubeClient, extIngName, ns, svc, cname2, extHostMap, true, "key", "test")
+
+	// only the "key": "value" ingresses of cluster1 and the "key": "test" ingress of cluster2 must be accepted
+	allKeys = append(allKeys, GetIngressKey("ADD", cname2, ns, extIngName, extHost, tenant))
+	t.Logf("verifying keys")
+	VerifyAllKeys(t, allKeys, false)
+
+	t.Logf("deleting the second GDP object")
+	DeleteTestGDPObj(anotherGdp)
+	// the ingress selected by the second GDP object must be deleted, and the rest re-evaluated
+	allKeys = GetMultipleIngKeys(t, "UPDATE", ingList1, cname1, ns)
+	allKeys = append(allKeys, GetIngressKey("DELETE", cname2, ns, extIngName, extHost, tenant))
+	VerifyAllKeys(t, allKeys, false)
+
+	t.Logf("Deleting ingresses for cluster1")
+	DeleteMultipleIngresses(t, fooKubeClient, ingList1)
+	k8sDeleteIngress(t, fooKubeClient, extIngName, ns)
+	t.Logf("Deleting ingresses for cluster2")
+	DeleteMultipleIngresses(t, barKubeClient, ingList2)
+	k8sDeleteIngress(t, barKubeClient, extIngName, ns)
+	VerifyAllKeys(t, GetMultipleIngDeleteKeys(t, ingList1, cname1, ns), false)
+	DeleteTestGDPObj(gdp)
+}
+
+func TestGDPPropertiesPrecedence(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ttlA, ttlB := 10, 20
+	hmA := []string{"hm-a"}
+	domainB := "b.avi.com"
+
+	gdpA := getTestGDPObject(true, false)
+	gdpA.ObjectMeta.Name = "prec-gdp-a"
+	gdpA.Spec.DefaultDomain = nil
+	gdpA.Spec.HealthMonitorRefs = hmA
+	gdpA.Spec.TrafficSplit = []gdpalphav2.TrafficSplitElem{{Cluster: "cluster1", Weight: 5}}
+	gdpA.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1", SyncVipOnly: true}}
+
+	gdpB := getTestGDPObject(true, false)
+	gdpB.ObjectMeta.Name = "prec-gdp-b"
+	gdpB.Spec.DefaultDomain = &domainB
+	gdpB.Spec.TTL = &ttlB
+	gdpB.Spec.TrafficSplit = []gdpalphav2.TrafficSplitElem{{Cluster: "cluster1", Weight: 8},
+		{Cluster: "cluster2", Weight: 3}}
+	gdpB.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1", SyncVipOnly: false},
+		{Cluster: "cluster2", SyncVipOnly: true}}
+
+	gf := gslbutils.GetGlobalFilter()
+	for _, gdp := range []*gdpalphav2.GlobalDeploymentPolicy{gdpB, gdpA} {
+		gdpFilter := gslbutils.NewGDPFilter(gdp.Name)
+		gdpFilter.AddToFilter(gdp)
+		gf.AddFilter(gdpFilter)
+	}
+	defer gf.DeleteFilter(gdpA.Name)
+	defer gf.DeleteFilter(gdpB.Name)
+
+	// properties not set on the GDP object with the higher precedence are taken from the next one
+	merged := gf.GetMergedFilter([]string{gdpB.Name, gdpA.Name})
+	g.Expect(merged.Name).To(gomega.Equal(gdpA.Name))
+	g.Expect(merged.GetAviHmRefs()).To(gomega.Equal(hmA))
+	g.Expect(*merged.GetTTL()).To(gomega.Equal(uint32(ttlB)))
+	g.Expect(*merged.GetDefaultDomain()).To(gomega.Equal(domainB))
+	g.Expect(merged.ApplicableClusters).To(gomega.HaveLen(2))
+	g.Expect(merged.ApplicableClusters["cluster1"].SyncVipsOnly).To(gomega.BeTrue())
+
+	// cluster weights are taken from the first GDP object with a traffic split for that cluster
+	weight, err := gf.GetTrafficWeight("cluster1", gdpA.Name, gdpB.Name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(weight).To(gomega.Equal(uint32(5)))
+	weight, err = gf.GetTrafficWeight("cluster1", gdpB.Name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(weight).To(gomega.Equal(uint32(8)))
+	weight, err = gf.GetTrafficWeight("cluster2", gdpA.Name, gdpB.Name)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(weight).To(gomega.Equal(uint32(3)))
+
+	// setting the TTL on the GDP object with the higher precedence overrides the other one
+	gdpA.Spec.TTL = &ttlA
+	gdpFilter := gslbutils.NewGDPFilter(gdpA.Name)
+	gdpFilter.AddToFilter(gdpA)
+	gf.AddFilter(gdpFilter)
+	merged = gf.GetMergedFilter([]string{gdpA.Name, gdpB.Name})
+	g.Expect(*merged.GetTTL()).To(gomega.Equal(uint32(ttlA)))
+}
+
+func TestGDPOwnerPrecedence(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	gsName := "owner-prec.avi.com"
+
+	gdp := getTestGDPObject(true, false)
+	gdp.ObjectMeta.Name = "avi-gdp"
+	nsGdp := getTestNamespacedGDPObject("gdp", "team-a")
+	gf := gslbutils.GetGlobalFilter()
+	for _, obj := range []*gdpalphav2.GlobalDeploymentPolicy{gdp, nsGdp} {
+		gdpFilter := gslbutils.NewGDPFilter(gslbutils.GetGDPFilterKey(obj.Namespace, obj.Name))
+		gdpFilter.AddToFilter(obj)
+		gf.AddFilter(gdpFilter)
+	}
+	nsGdpKey := gslbutils.GetGDPFilterKey(nsGdp.Namespace, nsGdp.Name)
+	defer gf.DeleteFilter(gdp.Name)
+	defer gf.DeleteFilter(nsGdpKey)
+
+	// the namespaced GDP object takes precedence, even though its key sorts after the other one
+	gdps := gf.SortByPrecedence([]string{gdp.Name, nsGdpKey})
+	g.Expect(gdps).To(gomega.Equal([]string{nsGdpKey, gdp.Name}))
+
+	gsGDPMap := gslbutils.GetGSGDPMap()
+	gsGDPMap.SetGDPsForGS(gsName, gdps)
+	defer gsGDPMap.DeleteGS(gsName)
+	owned, conflicts := gsGDPMap.GetGDPGslbServices(nsGdpKey)
+	g.Expect(owned).To(gomega.ContainElement(gsName))
+	g.Expect(conflicts).NotTo(gomega.ContainElement(gomega.HavePrefix(gsName)))
+	owned, conflicts = gsGDPMap.GetGDPGslbServices(gdp.Name)
+	g.Expect(owned).NotTo(gomega.ContainElement(gsName))
+	g.Expect(conflicts).To(gomega.ContainElement(gsName + " (owned by " + nsGdpKey + ")"))
+}
+
+func getTestNamespacedGDPObject(name, ns string) *gdpalphav2.GlobalDeploymentPolicy {
+	gdp := getTestGDPObject(true, false)
+	gdp.ObjectMeta.Name = name
+	gdp.ObjectMeta.Namespace = ns
+	gdp.Spec.DefaultDomain = nil
+	UpdateGDPMatchRuleAppLabel(gdp, "key", "test")
+	gdp.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1", SyncVipOnly: true}}
+	return gdp
+}
+
+func TestNamespacedGDPWithGuardrails(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "nsgdp-"
+	ingName := testPrefix + "def-ing1"
+	host := testPrefix + TestDomain1
+	hostMap := map[string]string{host: "10.10.10.10"}
+	cname1 := "cluster1"
+	cname2 := "cluster2"
+	appNS := "app-ns"
+	ns := "default"
+	svc := "test-svc"
+
+	buildAndAddTestGSLBObject(t)
+	appNSObj := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        appNS,
+			Annotations: map[string]string{gslbutils.TenantAnnotation: Tenant},
+		},
+	}
+	fooKubeClient.CoreV1().Namespaces().Create(context.TODO(), &appNSObj, metav1.CreateOptions{})
+
+	t.Logf("Adding GDP objects")
+	gdp := getTestGDPObject(true, false)
+	AddTestGDPObj(gdp)
+	g.Expect(gdp.Status.ErrorStatus).To(gomega.Equal("success"))
+
+	// no guardrails in the GDP object in avi-system, the namespaced GDP object must be rejected
+	nsGdp := getTestNamespacedGDPObject("app-gdp", appNS)
+	AddTestGDPObj(nsGdp)
+	g.Expect(nsGdp.Status.ErrorStatus).To(gomega.ContainSubstring("namespaced GDP objects are not allowed"))
+	gf := gslbutils.GetGlobalFilter()
+	nsGdpKey := gslbutils.GetGDPFilterKey(appNS, nsGdp.Name)
+	g.Expect(gf.GetFilter(nsGdpKey)).To(gomega.BeNil())
+
+	t.Logf("adding guardrails to the GDP object in avi-system")
+	newGdp := gdp.DeepCopy()
+	newGdp.Spec.NamespacedPolicyGuardrails = &gdpalphav2.NamespacedPolicyGuardrails{
+		AllowedClusters: []string{cname1},
+		TTLRange:        &gdpalphav2.TTLRange{Min: 10, Max: 60},
+	}
+	newGdp.ObjectMeta.ResourceVersion = "101"
+	UpdateTestGDPObj(gdp, newGdp)
+	g.Expect(gf.GetFilter(nsGdpKey)).NotTo(gomega.BeNil())
+
+	// a TTL outside the guardrails is rejected
+	ttl := 100
+	ttlGdp := getTestNamespacedGDPObject("app-gdp-ttl", appNS)
+	ttlGdp.Spec.TTL = &ttl
+	AddTestGDPObj(ttlGdp)
+	g.Expect(ttlGdp.Status.ErrorStatus).To(gomega.ContainSubstring("not within the range"))
+
+	// a cluster outside the guardrails is rejected
+	clusterGdp := getTestNamespacedGDPObject("app-gdp-cluster", appNS)
+	clusterGdp.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: cname2}}
+	AddTestGDPObj(clusterGdp)
+	g.Expect(clusterGdp.Status.ErrorStatus).To(gomega.ContainSubstring("not allowed by the guardrails"))
+
+	t.Logf("creating ingresses")
+	// only the ingress in the namespace of the namespaced GDP object must be selected
+	CreateIngressObjWithLabel(t, fooKubeClient, ingName, appNS, svc, cname1, hostMap, true, "key", "test")
+	VerifyAllKeys(t, []string{GetIngressKey("ADD", cname1, appNS, ingName, host, tenant)}, false)
+	CreateIngressObjWithLabel(t, fooKubeClient, ingName, ns, svc, cname1, hostMap, true, "key", "test")
+	VerifyAllKeys(t, []string{GetIngressKey("ADD", cname1, ns, ingName, host, tenant)}, true)
+
+	t.Logf("removing the guardrails from the GDP object in avi-system")
+	newerGdp := gdp.DeepCopy()
+	newerGdp.ObjectMeta.ResourceVersion = "102"
+	UpdateTestGDPObj(newGdp, newerGdp)
+	g.Expect(gf.GetFilter(nsGdpKey)).To(gomega.BeNil())
+	VerifyAllKeys(t, []string{GetIngressKey("DELETE", cname1, appNS, ingName, host, tenant)}, false)
+
+	k8sDeleteIngress(t, fooKubeClient, ingName, appNS)
+	k8sDeleteIngress(t, fooKubeClient, ingName, ns)
+	DeleteTestGDPObj(clusterGdp)
+	DeleteTestGDPObj(ttlGdp)
+	DeleteTestGDPObj(nsGdp)
+	DeleteTestGDPObj(newerGdp)
+}
+
+func TestGDPSelectObjsWithMatchExpressions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "gme-"
+	cname := "cluster1"
+	ns := "default"
+	svc := "test-svc"
+
+	buildAndAddTestGSLBObject(t)
+
+	// select the objects with "key":"value", a tier of web or api and without an env label
+	gdp := getTestGDPObject(true, false)
+	gdp.Spec.MatchRules.AppSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+		{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"web", "api"}},
+		{Key: "env", Operator: metav1.LabelSelectorOpDoesNotExist},
+	}
+	g.Expect(gslbingestion.GDPSanityChecks(gdp, false)).To(gomega.BeNil())
+	AddTestGDPObj(gdp)
+
+	ingLabels := map[string]map[string]string{
+		testPrefix + "ing1": {"key": "value", "tier": "web"},
+		testPrefix + "ing2": {"key": "value", "tier": "db"},
+		testPrefix + "ing3": {"key": "value", "tier": "api", "env": "dev"},
+		testPrefix + "ing4": {"tier": "web"},
+	}
+	ingObjs := make(map[string]*networkingv1.Ingress)
+	for name, lbls := range ingLabels {
+		ingObj := buildIngressObj(name, ns, svc, cname, setAndGetHostMap(name+"."+TestDomain1, "10.10.10.10"), true)
+		ingObj.Labels = lbls
+		if _, err := fooKubeClient.NetworkingV1().Ingresses(ns).Create(context.TODO(), ingObj, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error in creating ingress: %v", err)
+		}
+		ingObjs[name] = ingObj
+	}
+
+	// only the ingress satisfying all the labels and the requirements is selected
+	ing1Host := testPrefix + "ing1." + TestDomain1
+	VerifyAllKeys(t, []string{GetIngressKey("ADD", cname, ns, testPrefix+"ing1", ing1Host, tenant)}, false)
+	VerifyAllKeys(t, []string{"none"}, true)
+
+	// a label change which satisfies the requirements selects the ingress
+	ing2 := ingObjs[testPrefix+"ing2"]
+	ing2.Labels["tier"] = "api"
+	k8sUpdateIngress(t, fooKubeClient, ns, cname, ing2)
+	ing2Host := testPrefix + "ing2." + TestDomain1
+	VerifyAllKeys(t, []string{GetIngressKey("ADD", cname, ns, testPrefix+"ing2", ing2Host, tenant)}, false)
+
+	// an invalid operator is rejected
+	invalidGdp := gdp.DeepCopy()
+	invalidGdp.Spec.MatchRules.NamespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+		{Key: "env", Operator: "Equals", Values: []string{"prod"}},
+	}
+	g.Expect(gslbingestion.GDPSanityChecks(invalidGdp, false)).NotTo(gomega.BeNil())
+
+	for name := range ingObjs {
+		k8sDeleteIngress(t, fooKubeClient, name, ns)
+	}
+	VerifyAllKeys(t, []string{GetIngressKey("DELETE", cname, ns, testPrefix+"ing1", ing1Host, tenant),
+		GetIngressKey("DELETE", cname, ns, testPrefix+"ing2", ing2Host, tenant)}, false)
+	DeleteTestGDPObj(gdp)
+}
+
 func TestUpdateGDPSelectFew(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	testPrefix := "mgo-"
@@ -626,10 +934,100 @@ func CreateIngressObjWithLabel(t *testing.T, kc *k8sfake.Clientset, name, ns, sv
 }
 
 func GetMultipleIngDeleteKeys(t *testing.T, ingList []*networkingv1.Ingress, cname, ns string) []string {
+	return GetMultipleIngKeys(t, "DELETE", ingList, cname, ns)
+}
+
+func GetMultipleIngKeys(t *testing.T, op string, ingList []*networkingv1.Ingress, cname, ns string) []string {
 	allKeys := []string{}
 	for _, ing := range ingList {
-		key := GetIngressKey("DELETE", cname, ns, ing.ObjectMeta.Name, ing.Status.LoadBalancer.Ingress[0].Hostname, tenant)
+		key := GetIngressKey(op, cname, ns, ing.ObjectMeta.Name, ing.Status.LoadBalancer.Ingress[0].Hostname, tenant)
 		allKeys = append(allKeys, key)
 	}
 	return allKeys
 }
+
+func TestGDPClusterLocation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	latitude, longitude := 37.33, -121.89
+	locA := &gslbalphav1.GeoLocation{Name: "US/California/San Jose", Latitude: &latitude, Longitude: &longitude}
+	locB := &gslbalphav1.GeoLocation{Region: "us-west"}
+	buildAndAddTestGSLBObject(t)
+
+	gdpA := getTestGDPObject(true, false)
+	gdpA.ObjectMeta.Name = "loc-gdp-a"
+	gdpA.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1", Location: locA}}
+	gdpB := getTestGDPObject(true, false)
+	gdpB.ObjectMeta.Name = "loc-gdp-b"
+	gdpB.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1", Location: locB},
+		{Cluster: "cluster2"}}
+	g.Expect(gslbingestion.GDPSanityChecks(gdpA, false)).To(gomega.BeNil())
+	g.Expect(gslbingestion.GDPSanityChecks(gdpB, false)).To(gomega.BeNil())
+
+	gf := gslbutils.GetGlobalFilter()
+	for _, gdp := range []*gdpalphav2.GlobalDeploymentPolicy{gdpA, gdpB} {
+		gdpFilter := gslbutils.NewGDPFilter(gdp.Name)
+		gdpFilter.AddToFilter(gdp)
+		gf.AddFilter(gdpFilter)
+	}
+	defer gf.DeleteFilter(gdpA.Name)
+	defer gf.DeleteFilter(gdpB.Name)
+
+	// the location is taken from the GDP object with the highest precedence which has a location for the cluster
+	g.Expect(gf.GetClusterLocation("cluster1", gdpA.Name, gdpB.Name)).To(gomega.Equal(locA))
+	g.Expect(gf.GetClusterLocation("cluster1", gdpB.Name)).To(gomega.Equal(locB))
+	g.Expect(gf.GetClusterLocation("cluster2", gdpA.Name, gdpB.Name)).To(gomega.BeNil())
+
+	// the region of the cluster's nodes isn't a location set in the GDP objects
+	gslbutils.SetClusterRegion("cluster2", "us-east")
+	defer gslbutils.SetClusterRegion("cluster2", "")
+	g.Expect(gf.GetClusterLocation("cluster2", gdpA.Name, gdpB.Name)).To(gomega.BeNil())
+	g.Expect(gf.GetClusterLocation("cluster1", gdpA.Name, gdpB.Name)).To(gomega.Equal(locA))
+
+	gdpA.Spec.MatchClusters[0].Location = &gslbalphav1.GeoLocation{Latitude: &latitude}
+	g.Expect(gslbingestion.GDPSanityChecks(gdpA, false)).To(gomega.MatchError(
+		"invalid location of cluster cluster1: latitude and longitude must be set together"))
+	longitude = 200
+	gdpA.Spec.MatchClusters[0].Location = &gslbalphav1.GeoLocation{Latitude: &latitude, Longitude: &longitude}
+	g.Expect(gslbingestion.GDPSanityChecks(gdpA, false)).To(gomega.MatchError(
+		"invalid location of cluster cluster1: longitude 200 is invalid, must be between -180 and 180"))
+}
+
+func TestGDPClusterDrain(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	buildAndAddTestGSLBObject(t)
+
+	gdpA := getTestGDPObject(true, false)
+	gdpA.ObjectMeta.Name = "drain-gdp-a"
+	gdpA.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1"}, {Cluster: "cluster2"}}
+	gdpB := getTestGDPObject(true, false)
+	gdpB.ObjectMeta.Name = "drain-gdp-b"
+	gdpB.Spec.MatchClusters = []gdpalphav2.ClusterProperty{{Cluster: "cluster1", Drain: true}}
+
+	gf := gslbutils.GetGlobalFilter()
+	filters := map[string]*gslbutils.GDPFilter{}
+	for _, gdp := range []*gdpalphav2.GlobalDeploymentPolicy{gdpA, gdpB} {
+		gdpFilter := gslbutils.NewGDPFilter(gdp.Name)
+		gdpFilter.AddToFilter(gdp)
+		gf.AddFilter(gdpFilter)
+		filters[gdp.Name] = gdpFilter
+	}
+	defer gf.DeleteFilter(gdpA.Name)
+	defer gf.DeleteFilter(gdpB.Name)
+
+	// a cluster is drained if any of the GDP objects drains it
+	g.Expect(gf.IsClusterDrained("cluster1", gdpA.Name, gdpB.Name)).To(gomega.BeTrue())
+	g.Expect(gf.IsClusterDrained("cluster1", gdpA.Name)).To(gomega.BeFalse())
+	g.Expect(gf.IsClusterDrained("cluster2", gdpA.Name, gdpB.Name)).To(gomega.BeFalse())
+
+	// draining a cluster re-syncs the objects of the cluster
+	newGdpA := gdpA.DeepCopy()
+	newGdpA.Spec.MatchClusters[1].Drain = true
+	changed, _, clustersToBeSynced := filters[gdpA.Name].UpdateFilter(gdpA, newGdpA)
+	g.Expect(changed).To(gomega.BeTrue())
+	g.Expect(clustersToBeSynced).To(gomega.Equal([]string{"cluster2"}))
+	g.Expect(gf.IsClusterDrained("cluster2", gdpA.Name)).To(gomega.BeTrue())
+
+	g.Expect(gslbutils.IsDrainAnnotationSet(map[string]string{gslbutils.DrainAnnotation: "true"})).To(gomega.BeTrue())
+	g.Expect(gslbutils.IsDrainAnnotationSet(map[string]string{gslbutils.DrainAnnotation: "false"})).To(gomega.BeFalse())
+	g.Expect(gslbutils.IsDrainAnnotationSet(nil)).To(gomega.BeFalse())
+}
@@ -15,10 +15,14 @@
 package ingestion
 
 import (
+	"errors"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/onsi/gomega"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
 	gslbingestion "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
 
@@ -26,7 +30,9 @@ import (
 
 	gslbinformers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/informers/externalversions"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 type GSLBTestConfigAddfn func(obj interface{})
@@ -57,3 +63,79 @@ func TestGSLBKubeConfig(t *testing.T) {
 		t.Fatalf("Failure in generating GSLB Kube config: %s", err.Error())
 	}
 }
+
+// Removing a member cluster from the GSLBConfig object must delete its objects from the stores and
+// publish the delete keys, without touching the objects from the other clusters.
+func TestRemoveMemberCluster(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "rmc-"
+	ingName := testPrefix + "def-ing"
+	ns := "default"
+	host := testPrefix + TestDomain1
+	ipAddr := "10.10.10.20"
+
+	ingHostIPMap := map[string]string{host: ipAddr}
+	gdp := addGDPAndGSLBForIngress(t)
+
+	k8sAddIngress(t, fooKubeClient, ingName, ns, TestSvc, "cluster1", ingHostIPMap)
+	buildIngressKeyAndVerify(t, false, "ADD", "cluster1", ns, ingName, host, tenant)
+	k8sAddIngress(t, barKubeClient, ingName, ns, TestSvc, "cluster2", ingHostIPMap)
+	buildIngressKeyAndVerify(t, false, "ADD", "cluster2", ns, ingName, host, tenant)
+	verifyInIngStore(g, acceptedIngStore, true, ingName, ns, "cluster2", host, ipAddr)
+
+	gc := getTestGSLBObject()
+	gslbingestion.UpdateMemberClusters(gc.Spec.MemberClusters, gc.Spec.MemberClusters[:1])
+	buildIngressKeyAndVerify(t, false, "DELETE", "cluster2", ns, ingName, host, tenant)
+	verifyInIngStore(g, acceptedIngStore, false, ingName, ns, "cluster2", host, ipAddr)
+	verifyInIngStore(g, acceptedIngStore, true, ingName, ns, "cluster1", host, ipAddr)
+	g.Expect(gslbutils.IsClusterContextPresent("cluster2")).To(gomega.BeFalse())
+	g.Expect(gslbutils.IsClusterContextPresent("cluster1")).To(gomega.BeTrue())
+
+	k8sDeleteIngress(t, fooKubeClient, ingName, ns)
+	buildIngressKeyAndVerify(t, false, "DELETE", "cluster1", ns, ingName, host, tenant)
+	DeleteTestGDPObj(gdp)
+}
+
+// An ingress deleted while its member cluster is unreachable must be kept as stale, and removed only
+// after the cluster is reachable again and confirms the deletion.
+func TestIngressDeleteInUnhealthyCluster(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "uhc-"
+	ingName := testPrefix + "def-ing"
+	ns := "default"
+	host := testPrefix + TestDomain1
+	ipAddr := "10.10.10.30"
+
+	ingHostIPMap := map[string]string{host: ipAddr}
+	gdp := addGDPAndGSLBForIngress(t)
+
+	k8sAddIngress(t, fooKubeClient, ingName, ns, TestSvc, "cluster1", ingHostIPMap)
+	buildIngressKeyAndVerify(t, false, "ADD", "cluster1", ns, ingName, host, tenant)
+
+	var apiServerDown atomic.Bool
+	apiServerDown.Store(true)
+	fooKubeClient.PrependReactor("list", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if apiServerDown.Load() {
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+	gslbingestion.CheckMemberClusterHealth()
+	state, _ := gslbingestion.GetMemberClusterState("cluster1")
+	g.Expect(state).To(gomega.Equal(gslbingestion.ClusterUnhealthy))
+
+	k8sDeleteIngress(t, fooKubeClient, ingName, ns)
+	buildIngressKeyAndVerify(t, true, "DELETE", "cluster1", ns, ingName, host, tenant)
+	verifyInIngStore(g, acceptedIngStore, true, ingName, ns, "cluster1", host, ipAddr)
+	_, staleObjs := gslbingestion.GetMemberClusterState("cluster1")
+	g.Expect(staleObjs).To(gomega.Equal(1))
+
+	apiServerDown.Store(false)
+	gslbingestion.CheckMemberClusterHealth()
+	buildIngressKeyAndVerify(t, false, "DELETE", "cluster1", ns, ingName, host, tenant)
+	verifyInIngStore(g, acceptedIngStore, false, ingName, ns, "cluster1", host, ipAddr)
+	state, staleObjs = gslbingestion.GetMemberClusterState("cluster1")
+	g.Expect(state).To(gomega.Equal(gslbingestion.ClusterHealthy))
+	g.Expect(staleObjs).To(gomega.Equal(0))
+	DeleteTestGDPObj(gdp)
+}
@@ -1,15 +1,20 @@
 package ingestion
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
 	gslbingestion "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/k8sobjects"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/store"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/test/mockaviserver"
 	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
 	gslbfake "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned/fake"
@@ -73,6 +78,27 @@ func TestGSLBHostRuleInvalidThirdPartyMember(t *testing.T) {
 	t.Logf("Verified GSLBHostRule")
 }
 
+func TestGSLBHostRuleThirdPartyMemberLocation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	latitude, longitude := 12.97, 77.59
+	gslbhrObj := getTestGSLBHRObject(gslbhrTestObjName, gslbhrTestNamespace, gslbhrTestFqdn)
+	gslbhrObj.Spec.ThirdPartyMembers = []gslbalphav1.ThirdPartyMember{{
+		VIP:      "10.10.10.10",
+		Site:     "test-third-party-member",
+		Location: &gslbalphav1.GeoLocation{Name: "IN/Karnataka/Bangalore", Latitude: &latitude, Longitude: &longitude},
+	}}
+	g.Expect(gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)).To(gomega.BeNil())
+
+	latitude = -95
+	err := gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(err.Error()).Should(gomega.Equal("invalid location of thirdPartyMember site test-third-party-member for " +
+		gslbhrTestObjName + " GSLBHostRule: latitude -95 is invalid, must be between -90 and 90"))
+
+	gslbhrObj.Spec.ThirdPartyMembers[0].Location = &gslbalphav1.GeoLocation{}
+	g.Expect(gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)).NotTo(gomega.BeNil())
+}
+
 func TestGSLBHostRuleValidSitePersistence(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	addGDPAndGSLBForIngress(t)
@@ -316,3 +342,113 @@ func TestGSLBHostRuleInvalidPublicIP(t *testing.T) {
 	g.Expect(err.Error()).Should(gomega.Equal("Invalid IP for site cluster1," + gslbhrTestObjName + " GSLBHostRule (expecting IP address)"))
 
 }
+
+func TestGSLBHostRuleMemberTrafficSplit(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	buildAndAddTestGSLBObject(t)
+	gslbhrObj := getTestGSLBHRObject(gslbhrTestObjName, gslbhrTestNamespace, gslbhrTestFqdn)
+	gslbhrObj.Spec.MemberTrafficSplit = []gslbalphav1.MemberTrafficSplitElem{
+		{Cluster: "cluster1", Namespace: "default", Kind: gslbutils.MemberKindIngress, Name: "mts-blue", Weight: 18, Priority: 10},
+	}
+
+	// the member ingress doesn't exist yet
+	err := gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(err.Error()).Should(gomega.Equal("Ingress default/mts-blue in member traffic split not present in cluster cluster1 for " +
+		gslbhrTestObjName + " GSLBHostRule"))
+	// the members can't be verified during a full sync
+	g.Expect(gslbingestion.ValidateGSLBHostRule(gslbhrObj, true)).To(gomega.BeNil())
+
+	ingStore := store.GetAcceptedIngressStore()
+	ingStore.AddOrUpdate(k8sobjects.IngressHostMeta{IngName: "mts-blue", Namespace: "default", Cluster: "cluster1",
+		ObjName: "mts-blue/" + gslbhrTestFqdn}, "cluster1", "default", "mts-blue/"+gslbhrTestFqdn)
+	defer ingStore.DeleteClusterNSObj("cluster1", "default", "mts-blue/"+gslbhrTestFqdn)
+	g.Expect(gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)).To(gomega.BeNil())
+
+	// invalid kind, weight and cluster
+	gslbhrObj.Spec.MemberTrafficSplit[0].Kind = "Pod"
+	err = gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(err.Error()).Should(gomega.HavePrefix("member kind Pod is invalid"))
+
+	gslbhrObj.Spec.MemberTrafficSplit[0].Kind = gslbutils.MemberKindIngress
+	gslbhrObj.Spec.MemberTrafficSplit[0].Weight = 25
+	err = gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(err.Error()).Should(gomega.Equal("traffic weight 25 for member cluster1/default/mts-blue must be between 1 and 20 for " +
+		gslbhrTestObjName + " GSLBHostRule"))
+
+	gslbhrObj.Spec.MemberTrafficSplit[0].Weight = 18
+	for _, priority := range []uint32{0, 101} {
+		gslbhrObj.Spec.MemberTrafficSplit[0].Priority = priority
+		err = gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+		g.Expect(err).NotTo(gomega.BeNil())
+		g.Expect(err.Error()).Should(gomega.Equal(fmt.Sprintf("priority %d for member cluster1/default/mts-blue must be between 1 and 100 for ",
+			priority) + gslbhrTestObjName + " GSLBHostRule"))
+	}
+
+	gslbhrObj.Spec.MemberTrafficSplit[0].Priority = 10
+	gslbhrObj.Spec.MemberTrafficSplit[0].Cluster = "cluster3"
+	err = gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(err.Error()).Should(gomega.Equal("cluster cluster3 in member traffic split not present in GSLBConfig for " +
+		gslbhrTestObjName + " GSLBHostRule"))
+}
+
+func TestGSLBHostRuleRevalidatedOnMemberAdd(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	buildAndAddTestGSLBObject(t)
+	fqdn := "mts-revalidate.avi.internal"
+	gslbhrObj := getTestGSLBHRObject(gslbhrTestObjName, gslbhrTestNamespace, fqdn)
+	gslbhrObj.Spec.MemberTrafficSplit = []gslbalphav1.MemberTrafficSplitElem{
+		{Cluster: "cluster1", Namespace: "default", Kind: gslbutils.MemberKindIngress, Name: "mts-green", Weight: 18, Priority: 10},
+	}
+	gslbhrObj.Status.Status = gslbingestion.GslbHostRuleRejected
+
+	gslbClient := gslbfake.NewSimpleClientset(gslbhrObj)
+	gslbutils.AMKOControlConfig().SetGSLBClientset(gslbClient)
+	gslbhrInformer := gslbinformers.NewSharedInformerFactory(gslbClient, time.Second*30).Amko().V1alpha1().GSLBHostRules()
+	g.Expect(gslbhrInformer.Informer().GetIndexer().Add(gslbhrObj)).To(gomega.Succeed())
+	gslbutils.SetAMKOCrdInformers(&gslbutils.AMKOCrdInformers{GslbHostruleInformer: gslbhrInformer})
+	defer gslbutils.SetAMKOCrdInformers(nil)
+	defer gslbutils.GetGSHostRulesList().DeleteGSHostRulesForFQDN(fqdn)
+	k8swq := []workqueue.RateLimitingInterface{workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+	defer k8swq[0].ShutDown()
+	getStatus := func() string {
+		obj, err := gslbClient.AmkoV1alpha1().GSLBHostRules(gslbhrTestNamespace).Get(context.TODO(), gslbhrTestObjName,
+			metav1.GetOptions{})
+		g.Expect(err).To(gomega.BeNil())
+		return obj.Status.Status
+	}
+
+	// an object which isn't a member of the GSLBHostRule
+	gslbingestion.RevalidateGSLBHostRulesForMember("cluster1", "default", gslbutils.IngressType, "mts-blue/"+fqdn, k8swq, 1)
+	g.Expect(getStatus()).To(gomega.Equal(gslbingestion.GslbHostRuleRejected))
+	g.Expect(gslbutils.GetGSHostRulesList().GetGSHostRulesForFQDN(fqdn)).To(gomega.BeNil())
+
+	ingStore := store.GetAcceptedIngressStore()
+	ingStore.AddOrUpdate(k8sobjects.IngressHostMeta{IngName: "mts-green", Namespace: "default", Cluster: "cluster1",
+		ObjName: "mts-green/" + fqdn}, "cluster1", "default", "mts-green/"+fqdn)
+	defer ingStore.DeleteClusterNSObj("cluster1", "default", "mts-green/"+fqdn)
+	gslbingestion.RevalidateGSLBHostRulesForMember("cluster1", "default", gslbutils.IngressType, "mts-green/"+fqdn, k8swq, 1)
+	g.Expect(getStatus()).To(gomega.Equal(gslbingestion.GslbHostRuleAccepted))
+	g.Expect(gslbutils.GetGSHostRulesList().GetGSHostRulesForFQDN(fqdn)).NotTo(gomega.BeNil())
+	g.Eventually(k8swq[0].Len, 5*time.Second, 100*time.Millisecond).Should(gomega.Equal(1))
+}
+
+func TestGSLBHostRuleWildcardFqdn(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	addGDPAndGSLBForIngress(t)
+	gslbhrObj := getTestGSLBHRObject(gslbhrTestObjName, gslbhrTestNamespace, "*.avi.com")
+	g.Expect(gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)).To(gomega.BeNil())
+
+	wildcardMatch := true
+	gslbhrObj.Spec.WildcardMatch = &wildcardMatch
+	g.Expect(gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)).To(gomega.BeNil())
+
+	wildcardMatch = false
+	err := gslbingestion.ValidateGSLBHostRule(gslbhrObj, false)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(err.Error()).Should(gomega.Equal("wildcard match can't be disabled for the wildcard fqdn *.avi.com in " +
+		gslbhrTestObjName + " GSLBHostRule"))
+}
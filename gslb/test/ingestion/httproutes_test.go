@@ -0,0 +1,205 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/k8sobjects"
+)
+
+func getTestGateway(ns, name string, ipAddrs []string, listeners []gatewayv1.Listener) *gatewayv1.Gateway {
+	gw := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Annotations: map[string]string{
+				gslbutils.VSAnnotation:         `{"foo.avi.com":"vs-uuid-1","bar.avi.com":"vs-uuid-2"}`,
+				gslbutils.ControllerAnnotation: "controller-uuid-1",
+			},
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: listeners,
+		},
+	}
+	ipType := gatewayv1.IPAddressType
+	for _, ip := range ipAddrs {
+		gw.Status.Addresses = append(gw.Status.Addresses, gatewayv1.GatewayStatusAddress{
+			Type:  &ipType,
+			Value: ip,
+		})
+	}
+	return gw
+}
+
+func getTestListener(name string, hostname string, port int32, protocol gatewayv1.ProtocolType) gatewayv1.Listener {
+	listener := gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Port:     gatewayv1.PortNumber(port),
+		Protocol: protocol,
+	}
+	if hostname != "" {
+		h := gatewayv1.Hostname(hostname)
+		listener.Hostname = &h
+	}
+	return listener
+}
+
+func getTestHTTPRoute(ns, name, gwName string, hostnames []string, paths []string) *gatewayv1.HTTPRoute {
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    map[string]string{"key": "value"},
+		},
+	}
+	route.Spec.ParentRefs = []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gwName)}}
+	for _, h := range hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1.Hostname(h))
+	}
+	pathType := gatewayv1.PathMatchPathPrefix
+	rule := gatewayv1.HTTPRouteRule{}
+	for i := range paths {
+		rule.Matches = append(rule.Matches, gatewayv1.HTTPRouteMatch{
+			Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &paths[i]},
+		})
+	}
+	route.Spec.Rules = []gatewayv1.HTTPRouteRule{rule}
+	return route
+}
+
+func gatewayGetterForTest(gws ...*gatewayv1.Gateway) k8sobjects.GatewayGetter {
+	return func(ns, name string) (*gatewayv1.Gateway, bool) {
+		for _, gw := range gws {
+			if gw.Namespace == ns && gw.Name == name {
+				return gw, true
+			}
+		}
+		return nil, false
+	}
+}
+
+func TestHTTPRouteHostMeta(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ns := "default"
+	cname := "cluster1"
+
+	gw := getTestGateway(ns, "gw1", []string{"10.10.10.10", "2001:db8::10"}, []gatewayv1.Listener{
+		getTestListener("http", "", 80, gatewayv1.HTTPProtocolType),
+		getTestListener("https", "*.avi.com", 443, gatewayv1.HTTPSProtocolType),
+	})
+	route := getTestHTTPRoute(ns, "route1", "gw1", []string{"foo.avi.com", "*.avi.com"}, []string{"/foo", "/bar"})
+
+	metaObjs := k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	// both hostnames are served by the http listener without a hostname, the wildcard one as is
+	g.Expect(metaObjs).To(gomega.HaveLen(2))
+	g.Expect(metaObjs[1].Hostname).To(gomega.Equal("*.avi.com"))
+	g.Expect(metaObjs[1].ObjName).To(gomega.Equal("route1/*.avi.com"))
+	hrhm := metaObjs[0]
+	g.Expect(hrhm.Hostname).To(gomega.Equal("foo.avi.com"))
+	g.Expect(hrhm.ObjName).To(gomega.Equal("route1/foo.avi.com"))
+	g.Expect(hrhm.Gateway).To(gomega.Equal(ns + "/gw1"))
+	g.Expect(hrhm.IPAddr).To(gomega.Equal("10.10.10.10"))
+	g.Expect(hrhm.GetIPAddrs()).To(gomega.Equal([]string{"10.10.10.10", "2001:db8::10"}))
+	g.Expect(hrhm.VirtualServiceUUID).To(gomega.Equal("vs-uuid-1"))
+	g.Expect(hrhm.ControllerUUID).To(gomega.Equal("controller-uuid-1"))
+	g.Expect(hrhm.Paths).To(gomega.Equal([]string{"/foo", "/bar"}))
+	g.Expect(hrhm.Labels).To(gomega.HaveKeyWithValue("key", "value"))
+	g.Expect(hrhm.GetType()).To(gomega.Equal(gslbutils.HTTPRouteType))
+	// the first matching listener is plain HTTP
+	g.Expect(hrhm.TLS).To(gomega.BeFalse())
+	g.Expect(hrhm.IsDrained()).To(gomega.BeFalse())
+
+	// the drain annotation drains the members of all the hostnames of the route, and changes their checksums
+	route.Annotations = map[string]string{gslbutils.DrainAnnotation: "true"}
+	drainedObjs := k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	g.Expect(drainedObjs).To(gomega.HaveLen(2))
+	for i, drained := range drainedObjs {
+		g.Expect(drained.IsDrained()).To(gomega.BeTrue())
+		g.Expect(drained.GetHTTPRouteHostCksum()).NotTo(gomega.Equal(metaObjs[i].GetHTTPRouteHostCksum()))
+	}
+	route.Annotations = nil
+
+	// attach the route to the https listener only
+	sectionName := gatewayv1.SectionName("https")
+	route.Spec.ParentRefs[0].SectionName = &sectionName
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	g.Expect(metaObjs).To(gomega.HaveLen(2))
+	g.Expect(metaObjs[0].TLS).To(gomega.BeTrue())
+	g.Expect(metaObjs[1].TLS).To(gomega.BeTrue())
+
+	// the more specific of a wildcard route hostname and the wildcard listener hostname is picked
+	route.Spec.Hostnames = []gatewayv1.Hostname{"*.foo.avi.com", "*.com"}
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	g.Expect(metaObjs).To(gomega.HaveLen(2))
+	g.Expect(metaObjs[0].Hostname).To(gomega.Equal("*.foo.avi.com"))
+	g.Expect(metaObjs[1].Hostname).To(gomega.Equal("*.avi.com"))
+
+	// a hostname outside of the listener's wildcard must not be picked up
+	route.Spec.Hostnames = []gatewayv1.Hostname{"foo.example.com"}
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	g.Expect(metaObjs).To(gomega.BeEmpty())
+}
+
+func TestHTTPRouteHostMetaDefaultsAndRejections(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ns := "default"
+	cname := "cluster1"
+
+	gw := getTestGateway(ns, "gw1", []string{"10.10.10.10"}, []gatewayv1.Listener{
+		getTestListener("http", "bar.avi.com", 80, gatewayv1.HTTPProtocolType),
+	})
+
+	// no hostnames in the route, the listener hostname is used, and the path defaults to "/"
+	route := getTestHTTPRoute(ns, "route1", "gw1", nil, nil)
+	metaObjs := k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	g.Expect(metaObjs).To(gomega.HaveLen(1))
+	g.Expect(metaObjs[0].Hostname).To(gomega.Equal("bar.avi.com"))
+	g.Expect(metaObjs[0].VirtualServiceUUID).To(gomega.Equal("vs-uuid-2"))
+	g.Expect(metaObjs[0].Paths).To(gomega.Equal([]string{"/"}))
+
+	// no hostnames in the route, a wildcard listener hostname is used as is
+	wcGw := getTestGateway(ns, "gw1", []string{"10.10.10.10"}, []gatewayv1.Listener{
+		getTestListener("http", "*.bar.avi.com", 80, gatewayv1.HTTPProtocolType),
+	})
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(wcGw))
+	g.Expect(metaObjs).To(gomega.HaveLen(1))
+	g.Expect(metaObjs[0].Hostname).To(gomega.Equal("*.bar.avi.com"))
+
+	// parent gateway not present
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest())
+	g.Expect(metaObjs).To(gomega.BeEmpty())
+
+	// parent gateway without an address
+	gwNoAddr := getTestGateway(ns, "gw1", nil, gw.Spec.Listeners)
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gwNoAddr))
+	g.Expect(metaObjs).To(gomega.BeEmpty())
+
+	// route not accepted by the parent gateway
+	route.Status.Parents = []gatewayv1.RouteParentStatus{{
+		ParentRef: gatewayv1.ParentReference{Name: "gw1"},
+		Conditions: []metav1.Condition{{
+			Type:   string(gatewayv1.RouteConditionAccepted),
+			Status: metav1.ConditionFalse,
+		}},
+	}}
+	metaObjs = k8sobjects.GetHostMetaForHTTPRoute(route, cname, gatewayGetterForTest(gw))
+	g.Expect(metaObjs).To(gomega.BeEmpty())
+}
@@ -725,6 +725,45 @@ func TestBasicTLSIngressCUD(t *testing.T) {
 	DeleteTestGDPObj(gdp)
 }
 
+func TestWildcardHostIngressCD(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "wcd-"
+	ingName := testPrefix + "def-ing"
+	ns := "default"
+	cname := "cluster1"
+	domain := testPrefix + TestDomain1
+	wildcardHost := "*." + domain
+	host := "app." + domain
+
+	hostIPMap := map[string]string{
+		wildcardHost: "10.10.10.10",
+		host:         "10.10.10.20",
+	}
+	gdp := addGDPAndGSLBForIngress(t)
+	ingObj := buildIngressObj(ingName, ns, TestSvc, cname, hostIPMap, true)
+	// the status hostname of a wildcard host is its domain
+	for idx := range ingObj.Status.LoadBalancer.Ingress {
+		if ingObj.Status.LoadBalancer.Ingress[idx].Hostname == wildcardHost {
+			ingObj.Status.LoadBalancer.Ingress[idx].Hostname = domain
+		}
+	}
+	_, err := fooKubeClient.NetworkingV1().Ingresses(ns).Create(context.TODO(), ingObj, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating ingress: %v", err)
+	}
+	buildIngMultiHostKeyAndVerify(t, false, "ADD", cname, ns, ingName, tenant, hostIPMap)
+	for h, ip := range hostIPMap {
+		verifyInIngStore(g, acceptedIngStore, true, ingName, ns, cname, h, ip)
+	}
+
+	k8sDeleteIngress(t, fooKubeClient, ingName, ns)
+	buildIngMultiHostKeyAndVerify(t, false, "DELETE", cname, ns, ingName, tenant, hostIPMap)
+	for h, ip := range hostIPMap {
+		verifyInIngStore(g, acceptedIngStore, false, ingName, ns, cname, h, ip)
+	}
+	DeleteTestGDPObj(gdp)
+}
+
 func k8sUpdateIngress(t *testing.T, kc *k8sfake.Clientset, ns, cname string,
 	ingObj *networkingv1.Ingress) {
 
@@ -157,6 +157,12 @@ func verifyInRouteStore(g *gomega.WithT, accepted bool, present bool, routeName,
 	}
 }
 
+func verifyRouteWildcardFqdn(g *gomega.WithT, routeName, ns, cname, wildcardFqdn string) {
+	obj, found := store.GetAcceptedRouteStore().GetClusterNSObjectByName(cname, ns, routeName)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(obj.(k8sobjects.RouteMeta).WildcardFqdn).To(gomega.Equal(wildcardFqdn))
+}
+
 func TestBasicRouteCD(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	testPrefix := "rcd-"
@@ -430,3 +436,39 @@ func TestBasicRouteWithsubdomainCUD(t *testing.T) {
 
 	DeleteTestGDPObj(gdp)
 }
+
+func TestRouteWithSubdomainWildcardPolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	testPrefix := "rwc-"
+	routeName := testPrefix + "def-route"
+	ns := "default"
+	host := testPrefix + "app." + TestDomain1
+	ipAddr := "10.10.20.20"
+	cname := "cluster1"
+
+	gdp := addGDPAndGSLBForIngress(t)
+
+	route := buildRouteObj(routeName, ns, TestSvc, cname, host, ipAddr, true)
+	route.Spec.WildcardPolicy = routev1.WildcardPolicySubdomain
+	_, err := fooOshiftClient.RouteV1().Routes(ns).Create(context.TODO(), route, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("error in creating route: %v", err)
+	}
+	buildRouteKeyAndVerify(t, false, "ADD", cname, ns, routeName, tenant)
+	// the route keeps its host, and is a member of the GslbService for the wildcard fqdn of its domain
+	verifyInRouteStore(g, acceptedRouteStore, true, routeName, ns, cname, host, ipAddr)
+	verifyRouteWildcardFqdn(g, routeName, ns, cname, "*."+TestDomain1)
+
+	// removing the wildcard policy moves the route to the GslbService of its host
+	route.Spec.WildcardPolicy = routev1.WildcardPolicyNone
+	ocUpdateRoute(t, fooOshiftClient, ns, cname, route)
+	buildRouteKeyAndVerify(t, false, "DELETE", cname, ns, routeName, tenant)
+	buildRouteKeyAndVerify(t, false, "ADD", cname, ns, routeName, tenant)
+	verifyInRouteStore(g, acceptedRouteStore, true, routeName, ns, cname, host, ipAddr)
+	verifyRouteWildcardFqdn(g, routeName, ns, cname, "")
+
+	ocDeleteRoute(t, fooOshiftClient, routeName, ns)
+	buildRouteKeyAndVerify(t, false, "DELETE", cname, ns, routeName, tenant)
+
+	DeleteTestGDPObj(gdp)
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingestion
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	gslbingestion "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getAdmissionReview(t *testing.T, op admissionv1.Operation, kind string, obj, oldObj interface{}) []byte {
+	review := admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("test-uid"),
+			Operation: op,
+		},
+	}
+	review.Request.Kind.Kind = kind
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("error in marshalling the object: %v", err)
+	}
+	review.Request.Object = runtime.RawExtension{Raw: raw}
+	if oldObj != nil {
+		oldRaw, err := json.Marshal(oldObj)
+		if err != nil {
+			t.Fatalf("error in marshalling the old object: %v", err)
+		}
+		review.Request.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+	body, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("error in marshalling the admission review: %v", err)
+	}
+	return body
+}
+
+func sendAdmissionReview(t *testing.T, path string, body []byte) *admissionv1.AdmissionResponse {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	if path == gslbingestion.WebhookGDPPath {
+		gslbingestion.ServeAdmission(w, req, gslbingestion.ValidateGDPAdmission)
+	} else {
+		gslbingestion.ServeAdmission(w, req, gslbingestion.ValidateGSLBHostRuleAdmission)
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected response code from the webhook: %d", w.Code)
+	}
+	review := admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(w.Body.Bytes(), &review); err != nil {
+		t.Fatalf("error in unmarshalling the admission review: %v", err)
+	}
+	return review.Response
+}
+
+func TestWebhookValidateGDP(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	buildAndAddTestGSLBObject(t)
+
+	gdp := getTestGDPObject(true, false)
+	resp := sendAdmissionReview(t, gslbingestion.WebhookGDPPath,
+		getAdmissionReview(t, admissionv1.Create, "GlobalDeploymentPolicy", gdp, nil))
+	g.Expect(resp.UID).To(gomega.Equal(types.UID("test-uid")))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	// invalid traffic weight
+	invalidGdp := gdp.DeepCopy()
+	invalidGdp.Spec.TrafficSplit = []gdpalphav2.TrafficSplitElem{{Cluster: "cluster1", Weight: 25}}
+	resp = sendAdmissionReview(t, gslbingestion.WebhookGDPPath,
+		getAdmissionReview(t, admissionv1.Create, "GlobalDeploymentPolicy", invalidGdp, nil))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.Equal("traffic weight 25 must be between 1 and 20"))
+
+	// unknown cluster
+	resp = sendAdmissionReview(t, gslbingestion.WebhookGDPPath,
+		getAdmissionReview(t, admissionv1.Update, "GlobalDeploymentPolicy", &gdpalphav2.GlobalDeploymentPolicy{
+			ObjectMeta: gdp.ObjectMeta,
+			Spec: gdpalphav2.GDPSpec{
+				MatchClusters: []gdpalphav2.ClusterProperty{{Cluster: "cluster3"}},
+			},
+		}, gdp))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.Equal("cluster context cluster3 not present in GSLBConfig"))
+
+	// status updates of an invalid GDP object must be allowed
+	statusGdp := invalidGdp.DeepCopy()
+	statusGdp.Status.ErrorStatus = "traffic weight 25 must be between 1 and 20"
+	resp = sendAdmissionReview(t, gslbingestion.WebhookGDPPath,
+		getAdmissionReview(t, admissionv1.Update, "GlobalDeploymentPolicy", statusGdp, invalidGdp))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	// deletes are always allowed
+	resp = sendAdmissionReview(t, gslbingestion.WebhookGDPPath,
+		getAdmissionReview(t, admissionv1.Delete, "GlobalDeploymentPolicy", invalidGdp, nil))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	// the refs fetched from the controller while validating aren't added to the caches
+	spGdp := gdp.DeepCopy()
+	spRef := "System-Persistence-TLS"
+	spGdp.Spec.SitePersistenceRef = &spRef
+	resp = sendAdmissionReview(t, gslbingestion.WebhookGDPPath,
+		getAdmissionReview(t, admissionv1.Create, "GlobalDeploymentPolicy", spGdp, nil))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+	tenant := gslbutils.GetTenantInNamespace(spGdp.Namespace, gslbutils.LeaderClusterContext)
+	_, present := avicache.GetAviSpCache().AviSpCacheGet(avicache.TenantName{Tenant: tenant, Name: spRef})
+	g.Expect(present).To(gomega.BeFalse())
+}
+
+func TestWebhookValidateGSLBHostRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	buildAndAddTestGSLBObject(t)
+
+	gslbhr := getTestGSLBHRObject("test-gslbhr", "default", "")
+	resp := sendAdmissionReview(t, gslbingestion.WebhookGSLBHostRulePath,
+		getAdmissionReview(t, admissionv1.Create, "GSLBHostRule", gslbhr, nil))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.Equal("GSFqdn missing for test-gslbhr GSLBHostRule"))
+
+	gslbhr.Spec.Fqdn = "test.avi.com"
+	resp = sendAdmissionReview(t, gslbingestion.WebhookGSLBHostRulePath,
+		getAdmissionReview(t, admissionv1.Create, "GSLBHostRule", gslbhr, nil))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+}
@@ -0,0 +1,420 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+// Package avi_simulator runs AMKO end to end against an envtest cluster and the stateful Avi
+// controller simulator: the GSLBConfig, GDP and ingress objects are created in the cluster, and the
+// GslbServices are verified on the simulator.
+package avi_simulator
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/ingestion"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
+	avirest "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
+	aviretry "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/retry"
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/test/mockaviserver"
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	gdpalphav2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
+	gslbcs "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/clientset/versioned"
+	gslbinformers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha1/informers/externalversions"
+	gdpcs "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha2/clientset/versioned"
+	gdpinformers "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/client/v1alpha2/informers/externalversions"
+)
+
+const (
+	KubeBuilderAssetsEnv = "KUBEBUILDER_ASSETS"
+	// AMKO and AKO CRD directories
+	AmkoCRDs = "../../../../helm/amko/crds"
+	AkoCRDs  = "../../crds/ako"
+
+	AviSystemNS    = "avi-system"
+	AviSecret      = "avi-secret"
+	GslbConfigName = "test-gc"
+	GDPName        = "test-gdp"
+	K8sContext     = "k8s"
+	TestNS         = "default"
+)
+
+var (
+	cfg        *rest.Config
+	kubeClient *kubernetes.Clientset
+	testEnv    *envtest.Environment
+	stopCh     <-chan struct{}
+	sim        *mockaviserver.AviSimulator
+	apiURL     string
+)
+
+var appLabel = map[string]string{"key": "value"}
+
+func TestMain(m *testing.M) {
+	setUp()
+	ret := m.Run()
+	cleanUp()
+	os.Exit(ret)
+}
+
+func cleanUp() {
+	if testEnv != nil {
+		testEnv.Stop()
+		gslbutils.Logf("test env cluster stopped")
+	}
+}
+
+func CleanupAndExit() {
+	cleanUp()
+	os.Exit(1)
+}
+
+func StartEnvCluster() {
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{AmkoCRDs, AkoCRDs},
+		ErrorIfCRDPathMissing: true,
+	}
+	var err error
+	cfg, err = testEnv.Start()
+	if err != nil {
+		gslbutils.Errf("error occurred while starting the test env cluster: %v", err)
+		CleanupAndExit()
+	}
+	kubeClient, err = kubernetes.NewForConfig(cfg)
+	if err != nil {
+		gslbutils.Errf("error occurred while fetching the clientset: %v", err)
+		CleanupAndExit()
+	}
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: AviSystemNS,
+		},
+	}
+	kubeClient.CoreV1().Namespaces().Create(context.TODO(), &ns, metav1.CreateOptions{})
+}
+
+// SetUpWorkerQueues runs the ingestion, graph and retry layers exactly as AMKO does, so that the
+// objects added to the cluster are synced all the way to the simulator.
+func SetUpWorkerQueues() {
+	gslbutils.SetWaitGroupMap()
+	ingestionQParams := utils.WorkerQueue{NumWorkers: utils.NumWorkersIngestion, WorkqueueName: utils.ObjectIngestionLayer}
+	graphQParams := utils.WorkerQueue{NumWorkers: gslbutils.NumRestWorkers, WorkqueueName: utils.GraphLayer}
+	// the slow retry queue is drained every second instead of gslbutils.SlowSyncTime
+	slowRetryQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: gslbutils.SlowRetryQueue, SlowSyncTime: 1}
+	fastRetryQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: gslbutils.FastRetryQueue}
+	utils.SharedWorkQueue(&ingestionQParams, &graphQParams, &slowRetryQParams, &fastRetryQParams)
+
+	ingestionQ := utils.SharedWorkQueue().GetQueueByName(utils.ObjectIngestionLayer)
+	ingestionQ.SyncFunc = nodes.SyncFromIngestionLayer
+	ingestionQ.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGIngestion))
+
+	graphQ := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	graphQ.SyncFunc = avirest.SyncFromNodesLayer
+	graphQ.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGGraph))
+
+	slowRetryQ := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
+	slowRetryQ.SyncFunc = aviretry.SyncFromRetryLayer
+	slowRetryQ.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGSlowRetry))
+	fastRetryQ := utils.SharedWorkQueue().GetQueueByName(gslbutils.FastRetryQueue)
+	fastRetryQ.SyncFunc = aviretry.SyncFromRetryLayer
+	fastRetryQ.Run(stopCh, gslbutils.GetWaitGroupFromMap(gslbutils.WGFastRetry))
+}
+
+func SetUpAMKOConfigs() {
+	gslbutils.SetTestMode(true)
+	os.Setenv("GSLB_CONFIG", "test-data")
+
+	amkoControlConfig := gslbutils.AMKOControlConfig()
+	amkoControlConfig.SetClientset(kubeClient)
+
+	gslbClient, err := gslbcs.NewForConfig(cfg)
+	if err != nil {
+		gslbutils.Errf("error occurred while creating a clientset for gslb: %v", err)
+		CleanupAndExit()
+	}
+	amkoControlConfig.SetGSLBClientset(gslbClient)
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: k8sfake.NewSimpleClientset()}, []string{utils.NSInformer})
+	gdpClient, err := gdpcs.NewForConfig(cfg)
+	if err != nil {
+		gslbutils.Errf("error occurred while creating a clientset for gdp: %v", err)
+		CleanupAndExit()
+	}
+	amkoControlConfig.SetGDPClientset(gdpClient)
+	amkoControlConfig.SetPublishGSLBStatus(true)
+	amkoControlConfig.SetPublishGDPStatus(true)
+	stopCh = utils.SetupSignalHandler()
+
+	SetUpWorkerQueues()
+	ingestion.SetInformerListTimeout(120)
+	gslbInformerFactory := gslbinformers.NewSharedInformerFactory(gslbClient, time.Second*30)
+	gslbController := ingestion.GetNewController(kubeClient, gslbClient, gslbInformerFactory,
+		ingestion.AddGSLBConfigObject, GetTestEnvClusterAsGslbMember)
+	go gslbInformerFactory.Amko().V1alpha1().GSLBConfigs().Informer().Run(stopCh)
+
+	gdpInformerFactory := gdpinformers.NewSharedInformerFactory(gdpClient, time.Second*30)
+	gdpCtrl := ingestion.InitializeGDPController(kubeClient, gdpClient, gdpInformerFactory,
+		ingestion.AddGDPObj, ingestion.UpdateGDPObj, ingestion.DeleteGDPObj)
+	go gdpInformerFactory.Amko().V1alpha2().GlobalDeploymentPolicies().Informer().Run(stopCh)
+
+	gslbhrCtrl := ingestion.InitializeGSLBHostRuleController(kubeClient, gslbClient, gslbInformerFactory,
+		ingestion.AddGSLBHostRuleObj, ingestion.UpdateGSLBHostRuleObj, ingestion.DeleteGSLBHostRuleObj)
+	go gslbInformerFactory.Amko().V1alpha1().GSLBHostRules().Informer().Run(stopCh)
+
+	go ingestion.RunControllers(gslbController, gdpCtrl, gslbhrCtrl, stopCh)
+}
+
+func GetTestEnvClusterAsGslbMember(arg1 string, arg2 []gslbalphav1.MemberCluster) ([]*ingestion.GSLBMemberController, error) {
+	c := ingestion.GetNewKubeClusterDetails(K8sContext, "", "", nil)
+	gslbutils.AddClusterContext(c.GetClusterContextName())
+	member, err := ingestion.InitializeMemberCluster(cfg, c, make(map[string]*kubernetes.Clientset))
+	if err != nil {
+		return nil, err
+	}
+	gslbutils.LeaderClusterContext = K8sContext
+	return []*ingestion.GSLBMemberController{member}, nil
+}
+
+func SetUpSimulator() {
+	sim = mockaviserver.NewAviSimulator()
+	server := httptest.NewTLSServer(sim)
+	apiURL = strings.TrimPrefix(server.URL, "https://")
+	gslbutils.Logf("avi simulator started, URL: %s", apiURL)
+}
+
+func CreateAviSecret() {
+	secretObj := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AviSecret,
+			Namespace: AviSystemNS,
+		},
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("admin"),
+		},
+	}
+	_, err := kubeClient.CoreV1().Secrets(AviSystemNS).Create(context.TODO(), &secretObj, metav1.CreateOptions{})
+	if err != nil {
+		gslbutils.Errf("error in creating a secret: %v", err)
+		CleanupAndExit()
+	}
+}
+
+type forGomega struct {
+}
+
+func (f forGomega) Fatalf(format string, args ...interface{}) {
+	gslbutils.Errf(format, args...)
+	CleanupAndExit()
+}
+
+func (f forGomega) Helper() {
+}
+
+func AddGslbConfigObject() {
+	g := gomega.NewGomegaWithT(types.GomegaTestingT(forGomega{}))
+	gcClient := gslbutils.AMKOControlConfig().GSLBClientset()
+	gc := &gslbalphav1.GSLBConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GslbConfigName,
+			Namespace: AviSystemNS,
+		},
+		Spec: gslbalphav1.GSLBConfigSpec{
+			GSLBLeader: gslbalphav1.GSLBLeader{
+				Credentials:       AviSecret,
+				ControllerVersion: mockaviserver.SimulatorVersion,
+				ControllerIP:      apiURL,
+			},
+			MemberClusters: []gslbalphav1.MemberCluster{
+				{ClusterContext: K8sContext},
+			},
+			RefreshInterval: 3600,
+			LogLevel:        "DEBUG",
+		},
+	}
+	_, err := gcClient.AmkoV1alpha1().GSLBConfigs(AviSystemNS).Create(context.TODO(), gc, metav1.CreateOptions{})
+	if err != nil {
+		gslbutils.Errf("error in creating GSLBConfig object: %v", err)
+		CleanupAndExit()
+	}
+	g.Eventually(func() string {
+		gcObj, err := gcClient.AmkoV1alpha1().GSLBConfigs(AviSystemNS).Get(context.TODO(), GslbConfigName,
+			metav1.GetOptions{})
+		if err != nil {
+			return ""
+		}
+		return gcObj.Status.State
+	}, 30*time.Second, 1*time.Second).Should(gomega.Equal("success: gslb config accepted"))
+}
+
+func setUp() {
+	// Set the location of the api server and etcd binaries
+	if os.Getenv(KubeBuilderAssetsEnv) == "" {
+		panic("kube builder assets directory not set, set the environment variable KUBEBUILDER_ASSETS and re-run")
+	}
+	StartEnvCluster()
+	SetUpAMKOConfigs()
+	SetUpSimulator()
+	CreateAviSecret()
+	AddGslbConfigObject()
+}
+
+func addTestGDP(t *testing.T) {
+	gdp := &gdpalphav2.GlobalDeploymentPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: AviSystemNS,
+			Name:      GDPName,
+		},
+		Spec: gdpalphav2.GDPSpec{
+			MatchRules: gdpalphav2.MatchRules{
+				AppSelector: gdpalphav2.AppSelector{Label: appLabel},
+			},
+			MatchClusters: []gdpalphav2.ClusterProperty{{Cluster: K8sContext}},
+		},
+	}
+	gdpClient := gslbutils.AMKOControlConfig().GDPClientset().AmkoV1alpha2().GlobalDeploymentPolicies(AviSystemNS)
+	if _, err := gdpClient.Create(context.TODO(), gdp, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating GDP object: %v", err)
+	}
+	t.Cleanup(func() {
+		gdpClient.Delete(context.TODO(), GDPName, metav1.DeleteOptions{})
+	})
+}
+
+// addTestIngress creates an ingress for host with the status and annotations which AKO would have
+// set on it.
+func addTestIngress(t *testing.T, name, host, ip string) {
+	hostVS, _ := json.Marshal(map[string]string{host: "virtualservice-" + host})
+	ingObj := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: TestNS,
+			Labels:    appLabel,
+			Annotations: map[string]string{
+				gslbutils.ControllerAnnotation: "cluster-XXXXX",
+				gslbutils.VSAnnotation:         string(hostVS),
+			},
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{Host: host}},
+		},
+	}
+	ingClient := kubeClient.NetworkingV1().Ingresses(TestNS)
+	if _, err := ingClient.Create(context.TODO(), ingObj, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating ingress: %v", err)
+	}
+	t.Cleanup(func() {
+		ingClient.Delete(context.TODO(), name, metav1.DeleteOptions{})
+	})
+	ingObj.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: ip, Hostname: host}}
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": ingObj.Status,
+	})
+	if _, err := ingClient.Patch(context.TODO(), name, k8stypes.MergePatchType, patchPayload,
+		metav1.PatchOptions{}, "status"); err != nil {
+		t.Fatalf("error in patching ingress status: %v", err)
+	}
+}
+
+func gsMembers(gs map[string]interface{}) []map[string]interface{} {
+	var members []map[string]interface{}
+	for _, group := range gs["groups"].([]interface{}) {
+		for _, member := range group.(map[string]interface{})["members"].([]interface{}) {
+			members = append(members, member.(map[string]interface{}))
+		}
+	}
+	return members
+}
+
+func gsMemberIPs(gs map[string]interface{}) []string {
+	var ips []string
+	for _, member := range gsMembers(gs) {
+		ips = append(ips, member["ip"].(map[string]interface{})["addr"].(string))
+	}
+	return ips
+}
+
+func TestIngressGSOnSimulator(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "e2e.avi.com"
+	addTestGDP(t)
+	addTestIngress(t, "e2e-ing", host, "10.10.10.10")
+
+	g.Eventually(func() []string {
+		gs, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		if !found {
+			return nil
+		}
+		return gsMemberIPs(gs)
+	}, 30*time.Second, 1*time.Second).Should(gomega.ConsistOf("10.10.10.10"))
+	gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(gs["domain_names"]).To(gomega.ConsistOf(host))
+	g.Expect(gs["created_by"]).To(gomega.Equal(gslbutils.AMKOControlConfig().CreatedByField()))
+
+	// the GslbService is removed from the simulator along with the ingress
+	g.Expect(kubeClient.NetworkingV1().Ingresses(TestNS).Delete(context.TODO(), "e2e-ing",
+		metav1.DeleteOptions{})).To(gomega.Succeed())
+	g.Eventually(func() bool {
+		_, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		return found
+	}, 30*time.Second, 1*time.Second).Should(gomega.BeFalse())
+}
+
+func TestCacheRefreshRestoresDrift(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "e2e-drift.avi.com"
+	addTestGDP(t)
+	addTestIngress(t, "e2e-drift-ing", host, "10.10.10.20")
+
+	var ratio interface{}
+	g.Eventually(func() int {
+		gs, found := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		if !found {
+			return 0
+		}
+		members := gsMembers(gs)
+		if len(members) != 0 {
+			ratio = members[0]["ratio"]
+		}
+		return len(members)
+	}, 30*time.Second, 1*time.Second).Should(gomega.Equal(1))
+
+	// change the GslbService on the controller, behind AMKO's back
+	g.Expect(sim.Update("gslbservice", utils.ADMIN_NS, host, func(data map[string]interface{}) {
+		gsMembers(data)[0]["ratio"] = 7
+	})).To(gomega.Succeed())
+	gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+	g.Expect(gsMembers(gs)[0]["ratio"]).To(gomega.BeEquivalentTo(7))
+
+	ingestion.CacheRefreshRoutine()
+	g.Eventually(func() interface{} {
+		gs, _ := sim.Get("gslbservice", utils.ADMIN_NS, host)
+		return gsMembers(gs)[0]["ratio"]
+	}, 30*time.Second, 1*time.Second).Should(gomega.BeEquivalentTo(ratio))
+}
@@ -0,0 +1,1037 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package mockaviserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
+	avirest "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
+)
+
+const (
+	AdminTenant          = "admin"
+	DefaultPageSize      = 25
+	SimulatorClusterName = "cluster-0-1"
+	SimulatorVersion     = "22.1.3"
+	DefaultPKIProfile    = "System-Default-PKI-Profile"
+
+	// otherLeaderClusterUUID is the GSLB leader's cluster uuid, if the simulated controller isn't
+	// the leader.
+	otherLeaderClusterUUID = "cluster-00000000-0000-0000-0000-00000000ffff"
+)
+
+// crudObjTypes are the object types which can be created, updated and deleted via the API.
+var crudObjTypes = map[string]bool{
+	"gslbservice":                   true,
+	"healthmonitor":                 true,
+	"applicationpersistenceprofile": true,
+	"pkiprofile":                    true,
+	"tenant":                        true,
+}
+
+// readOnlyObjTypes are the object types which can only be fetched via the API, these can be
+// changed via the AviSimulator methods.
+var readOnlyObjTypes = map[string]bool{
+	"gslb":  true,
+	"cloud": true,
+}
+
+// query parameters which don't filter the objects in a collection
+var nonFilterParams = map[string]bool{
+	"include_name":      true,
+	"page":              true,
+	"page_size":         true,
+	"fields":            true,
+	"sort":              true,
+	"skip_default":      true,
+	"join_subresources": true,
+	"include_refs":      true,
+}
+
+// APIError is an error returned by the Avi API, it's sent as {"error": Message}.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status code: %d, error: %s", e.StatusCode, e.Message)
+}
+
+func newAPIError(statusCode int, format string, args ...interface{}) *APIError {
+	return &APIError{StatusCode: statusCode, Message: fmt.Sprintf(format, args...)}
+}
+
+// Fault is injected into the API requests which match the method and object type of the fault.
+// The request is delayed by Latency and then, if StatusCode is set, fails with StatusCode and
+// Message instead of being served.
+type Fault struct {
+	// Method is the HTTP method to match, matches all methods if empty.
+	Method string
+	// ObjType is the object type to match, e.g. gslbservice, matches all object types if empty.
+	ObjType    string
+	StatusCode int
+	Message    string
+	Latency    time.Duration
+	// Count is the number of requests the fault is injected into, the fault stays till
+	// ClearFaults is called if it's 0.
+	Count int
+}
+
+func (f *Fault) matches(method, objType string) bool {
+	return (f.Method == "" || strings.EqualFold(f.Method, method)) && (f.ObjType == "" || f.ObjType == objType)
+}
+
+type simObject struct {
+	objType string
+	uuid    string
+	// tenant is the uuid of the tenant of this object, empty for the tenant objects
+	tenant string
+	// data has the refs in the "/api/<objType>/<uuid>" form, these are rendered with the host
+	// and the name of the referred object in the responses
+	data map[string]interface{}
+}
+
+func (o *simObject) name() string {
+	name, _ := o.data["name"].(string)
+	return name
+}
+
+// AviSimulator is a stateful, in-memory Avi controller API. Unlike the canned responses of
+// DefaultServerMiddleware, the objects created, updated and deleted via the API are reflected in
+// the later responses, so the rest layer and the cache refresh can be tested against it. It
+// supports:
+//   - CRUD for gslbservice, healthmonitor, applicationpersistenceprofile, pkiprofile and tenant,
+//   - GET for gslb, cloud, cluster, cluster/runtime, cluster/status and initial-data,
+//   - GET for gslbservice/<uuid>/runtime, with the member states set via SetMemberOperState,
+//   - pagination via page and page_size, and filtering on the object fields via query parameters,
+//   - tenants via the X-Avi-Tenant header, objects in the admin tenant are visible in all tenants,
+//   - name conflicts (409), unknown refs (400) and conflicting GslbService domain names (400),
+//   - injectable faults and leader changes, see AddFault and SetLeader.
+//
+// The faults and the leader can also be changed via the /simulator/faults and /simulator/leader
+// APIs, see serveSimulatorAPI.
+type AviSimulator struct {
+	lock        sync.Mutex
+	seq         int
+	objects     map[string]map[string]*simObject
+	faults      []*Fault
+	leader      bool
+	clusterUUID string
+	version     string
+	requests    map[string]int
+	// memberStates has the operational state of the GslbService members by their IP address, the
+	// members not in it are up
+	memberStates map[string]string
+}
+
+// NewAviSimulator returns a simulator for a GSLB leader controller with the admin tenant, the
+// default cloud and GSLB configuration, the System-GSLB health monitors and the default PKI
+// profile.
+func NewAviSimulator() *AviSimulator {
+	s := &AviSimulator{
+		objects:      make(map[string]map[string]*simObject),
+		leader:       true,
+		clusterUUID:  "cluster-00000000-0000-0000-0000-000000000001",
+		version:      SimulatorVersion,
+		requests:     make(map[string]int),
+		memberStates: make(map[string]string),
+	}
+	s.addObject("tenant", AdminTenant, "", map[string]interface{}{"name": AdminTenant})
+	s.addObject("cloud", s.newUUID("cloud"), AdminTenant, map[string]interface{}{
+		"name":       "Default-Cloud",
+		"vtype":      "CLOUD_NONE",
+		"tenant_ref": "/api/tenant/" + AdminTenant,
+	})
+	s.addObject("gslb", s.newUUID("gslb"), AdminTenant, map[string]interface{}{
+		"name":                "Default",
+		"is_federated":        true,
+		"leader_cluster_uuid": s.clusterUUID,
+		"sites": []interface{}{
+			map[string]interface{}{
+				"name":         "leader-site",
+				"cluster_uuid": s.clusterUUID,
+				"member_type":  "GSLB_ACTIVE_MEMBER",
+				"enabled":      true,
+			},
+		},
+		"tenant_ref": "/api/tenant/" + AdminTenant,
+	})
+	for _, hmType := range []string{"TCP", "UDP", "HTTP", "HTTPS", "Ping"} {
+		s.addObject("healthmonitor", s.newUUID("healthmonitor"), AdminTenant, map[string]interface{}{
+			"name":         "System-GSLB-" + hmType,
+			"type":         "HEALTH_MONITOR_" + strings.ToUpper(hmType),
+			"is_federated": true,
+			"tenant_ref":   "/api/tenant/" + AdminTenant,
+		})
+	}
+	s.addObject("pkiprofile", s.newUUID("pkiprofile"), AdminTenant, map[string]interface{}{
+		"name":         DefaultPKIProfile,
+		"is_federated": true,
+		"tenant_ref":   "/api/tenant/" + AdminTenant,
+	})
+	return s
+}
+
+// Middleware serves the request from the simulator, it can be set as the CustomServerMiddleware
+// of the mock API server via AddMiddleware.
+func (s *AviSimulator) Middleware(w http.ResponseWriter, r *http.Request) bool {
+	s.ServeHTTP(w, r)
+	return true
+}
+
+func (s *AviSimulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.Trim(r.URL.Path, "/")
+	gslbutils.Logf("[aviSimulator]: %s %s", r.Method, r.URL.String())
+
+	switch {
+	case strings.HasPrefix(path, "simulator/"):
+		s.serveSimulatorAPI(w, r, strings.TrimPrefix(path, "simulator/"))
+		return
+	case path == "login" || path == "logout":
+		writeResponse(w, http.StatusOK, map[string]interface{}{"success": "true"})
+		return
+	case !strings.HasPrefix(path, "api/"):
+		writeError(w, newAPIError(http.StatusNotFound, "resource not found"))
+		return
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "api/"), "/")
+	objType := segments[0]
+	s.lock.Lock()
+	s.requests[requestKey(r.Method, objType)]++
+	s.lock.Unlock()
+
+	if fault := s.nextFault(r.Method, objType); fault != nil {
+		time.Sleep(fault.Latency)
+		if fault.StatusCode != 0 {
+			message := fault.Message
+			if message == "" {
+				message = http.StatusText(fault.StatusCode)
+			}
+			writeError(w, newAPIError(fault.StatusCode, "%s", message))
+			return
+		}
+	}
+
+	statusCode, resp, err := s.serveAPI(r, objType, segments[1:])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeResponse(w, statusCode, resp)
+}
+
+func (s *AviSimulator) serveAPI(r *http.Request, objType string, segments []string) (int, interface{}, *APIError) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	switch objType {
+	case "initial-data":
+		return http.StatusOK, map[string]interface{}{"version": map[string]interface{}{"Version": s.version}}, nil
+	case "cluster":
+		return s.serveCluster(r, segments)
+	}
+	if !crudObjTypes[objType] && !readOnlyObjTypes[objType] {
+		return 0, nil, newAPIError(http.StatusNotFound, "resource not found")
+	}
+	if r.Method != http.MethodGet && !crudObjTypes[objType] {
+		return 0, nil, newAPIError(http.StatusMethodNotAllowed, "method %s not allowed for %s", r.Method, objType)
+	}
+	if r.Method != http.MethodGet && !s.leader {
+		return 0, nil, newAPIError(http.StatusBadRequest, "%s", avirest.ControllerNotLeaderErr)
+	}
+	tenant, err := s.requestTenant(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var uuid string
+	if len(segments) > 0 {
+		uuid = segments[0]
+	}
+	switch {
+	case r.Method == http.MethodGet && uuid == "":
+		return s.list(r, objType, tenant)
+	case r.Method == http.MethodGet:
+		obj, err := s.getByUUID(objType, uuid, tenant)
+		if err != nil {
+			return 0, nil, err
+		}
+		if len(segments) > 1 {
+			if objType == "gslbservice" && segments[1] == "runtime" {
+				return http.StatusOK, s.gsRuntime(obj), nil
+			}
+			return 0, nil, newAPIError(http.StatusNotFound, "resource not found")
+		}
+		return http.StatusOK, s.render(obj, r.Host), nil
+	case r.Method == http.MethodPost && uuid == "":
+		data, err := readBody(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		obj, err := s.create(objType, tenant, data)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusCreated, s.render(obj, r.Host), nil
+	case r.Method == http.MethodPut && uuid != "":
+		data, err := readBody(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		obj, err := s.getByUUID(objType, uuid, tenant)
+		if err != nil {
+			return 0, nil, err
+		}
+		updated, err := s.update(obj, data)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, s.render(updated, r.Host), nil
+	case r.Method == http.MethodDelete && uuid != "":
+		obj, err := s.getByUUID(objType, uuid, tenant)
+		if err != nil {
+			return 0, nil, err
+		}
+		delete(s.objects[objType], obj.uuid)
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, newAPIError(http.StatusBadRequest, "bad request")
+}
+
+func (s *AviSimulator) serveCluster(r *http.Request, segments []string) (int, interface{}, *APIError) {
+	if r.Method != http.MethodGet {
+		return 0, nil, newAPIError(http.StatusMethodNotAllowed, "method %s not allowed for cluster", r.Method)
+	}
+	clusterState := map[string]interface{}{"state": "CLUSTER_UP_HA_ACTIVE"}
+	if len(segments) == 0 {
+		return http.StatusOK, map[string]interface{}{
+			"name":        SimulatorClusterName,
+			"uuid":        s.clusterUUID,
+			"tenant_uuid": AdminTenant,
+		}, nil
+	}
+	switch segments[0] {
+	case "runtime":
+		return http.StatusOK, map[string]interface{}{
+			"cluster_state": clusterState,
+			"node_states": []interface{}{
+				map[string]interface{}{"name": SimulatorClusterName, "role": "CLUSTER_LEADER", "state": "CLUSTER_ACTIVE"},
+			},
+		}, nil
+	case "status":
+		return http.StatusOK, map[string]interface{}{"cluster_state": clusterState}, nil
+	}
+	return 0, nil, newAPIError(http.StatusNotFound, "resource not found")
+}
+
+// gsRuntime returns the runtime of a GslbService on the leader site, with the operational state of
+// each of its members.
+func (s *AviSimulator) gsRuntime(obj *simObject) []interface{} {
+	groups := []interface{}{}
+	groupsData, _ := obj.data["groups"].([]interface{})
+	for _, g := range groupsData {
+		group, _ := g.(map[string]interface{})
+		members := []interface{}{}
+		membersData, _ := group["members"].([]interface{})
+		for _, m := range membersData {
+			member, _ := m.(map[string]interface{})
+			ip, _ := member["ip"].(map[string]interface{})
+			addr, _ := ip["addr"].(string)
+			state := "OPER_UP"
+			if memberState, ok := s.memberStates[addr]; ok {
+				state = memberState
+			}
+			members = append(members, map[string]interface{}{
+				"ip":          map[string]interface{}{"addr": addr, "type": ip["type"]},
+				"site_name":   "leader-site",
+				"oper_status": map[string]interface{}{"state": state},
+			})
+		}
+		groups = append(groups, map[string]interface{}{"name": group["name"], "members": members})
+	}
+	return []interface{}{
+		map[string]interface{}{"name": obj.name(), "uuid": obj.uuid, "groups": groups},
+	}
+}
+
+// requestTenant returns the uuid of the tenant in the X-Avi-Tenant header, "*" for all tenants.
+func (s *AviSimulator) requestTenant(r *http.Request) (string, *APIError) {
+	name := r.Header.Get("X-Avi-Tenant")
+	if name == "" {
+		return AdminTenant, nil
+	}
+	if name == "*" {
+		return name, nil
+	}
+	tenant := s.findByName("tenant", name, "*")
+	if tenant == nil {
+		return "", newAPIError(http.StatusBadRequest, "tenant %s not found", name)
+	}
+	return tenant.uuid, nil
+}
+
+func (s *AviSimulator) list(r *http.Request, objType, tenant string) (int, interface{}, *APIError) {
+	query := r.URL.Query()
+	var matched []*simObject
+	for _, obj := range s.objects[objType] {
+		if s.visible(obj, tenant) && matchesQuery(obj, query) {
+			matched = append(matched, obj)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].name() != matched[j].name() {
+			return matched[i].name() < matched[j].name()
+		}
+		return matched[i].uuid < matched[j].uuid
+	})
+
+	pageSize, err := intParam(query, "page_size", DefaultPageSize)
+	if err != nil {
+		return 0, nil, err
+	}
+	page, err := intParam(query, "page", 1)
+	if err != nil {
+		return 0, nil, err
+	}
+	start := (page - 1) * pageSize
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + pageSize
+	if end > len(matched) {
+		end = len(matched)
+	}
+	results := []interface{}{}
+	for _, obj := range matched[start:end] {
+		results = append(results, s.render(obj, r.Host))
+	}
+	resp := map[string]interface{}{"count": len(matched), "results": results}
+	if end < len(matched) {
+		query.Set("page", strconv.Itoa(page+1))
+		resp["next"] = "https://" + r.Host + "/api/" + objType + "?" + query.Encode()
+	}
+	return http.StatusOK, resp, nil
+}
+
+func intParam(query url.Values, param string, defaultValue int) (int, *APIError) {
+	value := query.Get(param)
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, newAPIError(http.StatusBadRequest, "invalid %s %s", param, value)
+	}
+	return n, nil
+}
+
+func matchesQuery(obj *simObject, query url.Values) bool {
+	for param, values := range query {
+		if nonFilterParams[param] || len(values) == 0 {
+			continue
+		}
+		field, ok := obj.data[param]
+		if !ok || fmt.Sprintf("%v", field) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// visible returns true if obj can be fetched in tenant. The tenant objects and the objects in the
+// admin tenant are visible in all tenants.
+func (s *AviSimulator) visible(obj *simObject, tenant string) bool {
+	return tenant == "*" || obj.tenant == "" || obj.tenant == tenant || obj.tenant == AdminTenant
+}
+
+func (s *AviSimulator) getByUUID(objType, uuid, tenant string) (*simObject, *APIError) {
+	obj, ok := s.objects[objType][uuid]
+	if !ok || !s.visible(obj, tenant) {
+		return nil, newAPIError(http.StatusNotFound, "%s object %s not found", objType, uuid)
+	}
+	return obj, nil
+}
+
+// findByName returns the object of objType with name in tenant, the objects in the tenant are
+// preferred over the ones in the admin tenant.
+func (s *AviSimulator) findByName(objType, name, tenant string) *simObject {
+	var found *simObject
+	for _, obj := range s.objects[objType] {
+		if obj.name() != name || !s.visible(obj, tenant) {
+			continue
+		}
+		if found == nil || obj.tenant == tenant {
+			found = obj
+		}
+	}
+	return found
+}
+
+func (s *AviSimulator) newUUID(objType string) string {
+	s.seq++
+	return fmt.Sprintf("%s-00000000-0000-0000-0000-%012x", objType, s.seq)
+}
+
+func (s *AviSimulator) addObject(objType, uuid, tenant string, data map[string]interface{}) *simObject {
+	if s.objects[objType] == nil {
+		s.objects[objType] = make(map[string]*simObject)
+	}
+	data["uuid"] = uuid
+	data["_last_modified"] = strconv.FormatInt(time.Now().UnixMicro(), 10)
+	obj := &simObject{objType: objType, uuid: uuid, tenant: tenant, data: data}
+	s.objects[objType][uuid] = obj
+	return obj
+}
+
+func (s *AviSimulator) create(objType, tenant string, data map[string]interface{}) (*simObject, *APIError) {
+	name, _ := data["name"].(string)
+	if name == "" {
+		return nil, newAPIError(http.StatusBadRequest, "name is required for %s", objType)
+	}
+	if tenant == "*" {
+		tenant = AdminTenant
+	}
+	objTenant, err := s.validate(objType, "", tenant, data)
+	if err != nil {
+		return nil, err
+	}
+	return s.addObject(objType, s.newUUID(objType), objTenant, data), nil
+}
+
+func (s *AviSimulator) update(obj *simObject, data map[string]interface{}) (*simObject, *APIError) {
+	name, _ := data["name"].(string)
+	if name == "" {
+		return nil, newAPIError(http.StatusBadRequest, "name is required for %s", obj.objType)
+	}
+	tenant := obj.tenant
+	if tenant == "" {
+		tenant = AdminTenant
+	}
+	objTenant, err := s.validate(obj.objType, obj.uuid, tenant, data)
+	if err != nil {
+		return nil, err
+	}
+	return s.addObject(obj.objType, obj.uuid, objTenant, data), nil
+}
+
+// validate resolves the refs in data and checks for conflicts with the other objects, it returns
+// the uuid of the tenant of the object.
+func (s *AviSimulator) validate(objType, uuid, tenant string, data map[string]interface{}) (string, *APIError) {
+	objTenant := ""
+	if objType != "tenant" {
+		if _, ok := data["tenant_ref"]; !ok {
+			data["tenant_ref"] = "/api/tenant/" + tenant
+		}
+		if err := s.resolveRefs(data, tenant, false); err != nil {
+			return "", err
+		}
+		objTenant = strings.TrimPrefix(data["tenant_ref"].(string), "/api/tenant/")
+	}
+
+	name := data["name"].(string)
+	for _, obj := range s.objects[objType] {
+		if obj.uuid != uuid && obj.tenant == objTenant && obj.name() == name {
+			return "", newAPIError(http.StatusConflict, "%s with this name %s and tenant already exists", objType, name)
+		}
+	}
+	if objType == "gslbservice" {
+		domainNames := map[string]bool{}
+		for _, domain := range interfaceList(data["domain_names"]) {
+			domainNames[fmt.Sprintf("%v", domain)] = true
+		}
+		for _, obj := range s.objects[objType] {
+			if obj.uuid == uuid {
+				continue
+			}
+			for _, domain := range interfaceList(obj.data["domain_names"]) {
+				if domainNames[fmt.Sprintf("%v", domain)] {
+					return "", newAPIError(http.StatusBadRequest,
+						"domain name conflicting with existing domain name %v in GslbService %s", domain, obj.name())
+				}
+			}
+		}
+	}
+	return objTenant, nil
+}
+
+func interfaceList(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+// resolveRefs converts all the refs ("*_ref" and "*_refs" fields) in v to the "/api/<objType>/<uuid>"
+// form. A ref can be by name ("/api/<objType>?name=<name>") or by uuid, with or without the scheme
+// and host. Refs to unknown objects are an error, unless lenient is set, in which case these are
+// left unchanged.
+func (s *AviSimulator) resolveRefs(v interface{}, tenant string, lenient bool) *APIError {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, field := range val {
+			switch {
+			case strings.HasSuffix(key, "_ref"):
+				ref, ok := field.(string)
+				if !ok {
+					continue
+				}
+				resolved, err := s.resolveRef(ref, tenant, lenient)
+				if err != nil {
+					return err
+				}
+				val[key] = resolved
+			case strings.HasSuffix(key, "_refs"):
+				refs := interfaceList(field)
+				for i, refIntf := range refs {
+					ref, ok := refIntf.(string)
+					if !ok {
+						continue
+					}
+					resolved, err := s.resolveRef(ref, tenant, lenient)
+					if err != nil {
+						return err
+					}
+					refs[i] = resolved
+				}
+			default:
+				if err := s.resolveRefs(field, tenant, lenient); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		for _, elem := range val {
+			if err := s.resolveRefs(elem, tenant, lenient); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *AviSimulator) resolveRef(ref, tenant string, lenient bool) (string, *APIError) {
+	idx := strings.Index(ref, "/api/")
+	if idx < 0 {
+		return ref, nil
+	}
+	path := strings.SplitN(ref[idx+len("/api/"):], "#", 2)[0]
+	var rawQuery string
+	if parts := strings.SplitN(path, "?", 2); len(parts) == 2 {
+		path, rawQuery = parts[0], parts[1]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	objType := segments[0]
+	if !crudObjTypes[objType] && !readOnlyObjTypes[objType] {
+		return ref, nil
+	}
+
+	var obj *simObject
+	query, _ := url.ParseQuery(rawQuery)
+	if name := query.Get("name"); name != "" {
+		obj = s.findByName(objType, name, tenant)
+	} else if len(segments) > 1 {
+		obj, _ = s.getByUUID(objType, segments[1], tenant)
+	}
+	if obj == nil {
+		if lenient {
+			return ref, nil
+		}
+		return "", newAPIError(http.StatusBadRequest, "cannot find object of type %s for ref %s", objType, ref)
+	}
+	return "/api/" + objType + "/" + obj.uuid, nil
+}
+
+// render returns a copy of obj with the url and the refs in the "https://<host>/api/<objType>/<uuid>#<name>"
+// form.
+func (s *AviSimulator) render(obj *simObject, host string) map[string]interface{} {
+	if host == "" {
+		host = "localhost"
+	}
+	data := deepCopy(obj.data).(map[string]interface{})
+	s.renderRefs(data, host)
+	data["url"] = "https://" + host + "/api/" + obj.objType + "/" + obj.uuid
+	return data
+}
+
+func (s *AviSimulator) renderRefs(v interface{}, host string) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, field := range val {
+			switch {
+			case strings.HasSuffix(key, "_ref"):
+				if ref, ok := field.(string); ok {
+					val[key] = s.renderRef(ref, host)
+				}
+			case strings.HasSuffix(key, "_refs"):
+				refs := interfaceList(field)
+				for i, refIntf := range refs {
+					if ref, ok := refIntf.(string); ok {
+						refs[i] = s.renderRef(ref, host)
+					}
+				}
+			default:
+				s.renderRefs(field, host)
+			}
+		}
+	case []interface{}:
+		for _, elem := range val {
+			s.renderRefs(elem, host)
+		}
+	}
+}
+
+func (s *AviSimulator) renderRef(ref, host string) string {
+	if !strings.HasPrefix(ref, "/api/") {
+		return ref
+	}
+	rendered := "https://" + host + ref
+	segments := strings.Split(strings.TrimPrefix(ref, "/api/"), "/")
+	if len(segments) == 2 {
+		if obj, ok := s.objects[segments[0]][segments[1]]; ok {
+			rendered += "#" + obj.name()
+		}
+	}
+	return rendered
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(val))
+		for k, elem := range val {
+			c[k] = deepCopy(elem)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(val))
+		for i, elem := range val {
+			c[i] = deepCopy(elem)
+		}
+		return c
+	}
+	return v
+}
+
+func readBody(r *http.Request) (map[string]interface{}, *APIError) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, newAPIError(http.StatusBadRequest, "error in reading the request body: %v", err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, newAPIError(http.StatusBadRequest, "error in parsing the request body: %v", err)
+	}
+	return data, nil
+}
+
+func writeResponse(w http.ResponseWriter, statusCode int, resp interface{}) {
+	w.WriteHeader(statusCode)
+	if resp == nil {
+		return
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		gslbutils.Errf("[aviSimulator]: error in marshalling the response: %v", err)
+		return
+	}
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, err *APIError) {
+	writeResponse(w, err.StatusCode, map[string]interface{}{"error": err.Message})
+}
+
+func requestKey(method, objType string) string {
+	return strings.ToUpper(method) + " " + objType
+}
+
+// nextFault returns the first fault matching the request and removes it if it has been injected
+// Count times.
+func (s *AviSimulator) nextFault(method, objType string) *Fault {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, f := range s.faults {
+		if !f.matches(method, objType) {
+			continue
+		}
+		fault := *f
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &fault
+	}
+	return nil
+}
+
+// AddFault injects f into the matching requests, the faults are matched in the order they were
+// added.
+func (s *AviSimulator) AddFault(f Fault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all the faults.
+func (s *AviSimulator) ClearFaults() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.faults = nil
+}
+
+// SetLeader changes whether the simulated controller is the GSLB leader. A follower reports a
+// different leader cluster in the GSLB configuration and rejects the writes with the error that a
+// follower controller returns.
+func (s *AviSimulator) SetLeader(leader bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.leader = leader
+	for _, gslb := range s.objects["gslb"] {
+		if leader {
+			gslb.data["leader_cluster_uuid"] = s.clusterUUID
+		} else {
+			gslb.data["leader_cluster_uuid"] = otherLeaderClusterUUID
+		}
+	}
+}
+
+// SetMemberOperState sets the operational state of the GslbService members with the IP address ip in
+// the GslbService runtime, e.g. OPER_DOWN, an empty state sets them up.
+func (s *AviSimulator) SetMemberOperState(ip, state string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if state == "" {
+		delete(s.memberStates, ip)
+		return
+	}
+	s.memberStates[ip] = state
+}
+
+// IsLeader returns true if the simulated controller is the GSLB leader.
+func (s *AviSimulator) IsLeader() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.leader
+}
+
+// RequestCount returns the number of API requests received for method and objType.
+func (s *AviSimulator) RequestCount(method, objType string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.requests[requestKey(method, objType)]
+}
+
+// Load adds the objects of objType in data, which can be a single object or a collection response
+// like the ones in avimockobjects. The uuids of the objects are retained, the refs to unknown
+// objects are left as they are and the existing objects with the same name are replaced.
+func (s *AviSimulator) Load(objType string, data []byte) error {
+	var resp map[string]interface{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("error in parsing %s objects: %v", objType, err)
+	}
+	objs := []interface{}{resp}
+	if results, ok := resp["results"]; ok {
+		objs = interfaceList(results)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, objIntf := range objs {
+		obj, ok := objIntf.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected %s object %v", objType, objIntf)
+		}
+		name, _ := obj["name"].(string)
+		if name == "" {
+			return fmt.Errorf("%s object without a name", objType)
+		}
+		uuid, _ := obj["uuid"].(string)
+		if uuid == "" {
+			uuid = s.newUUID(objType)
+		}
+		delete(obj, "url")
+		tenant := ""
+		if objType != "tenant" {
+			if _, ok := obj["tenant_ref"]; !ok {
+				obj["tenant_ref"] = "/api/tenant/" + AdminTenant
+			}
+			s.resolveRefs(obj, AdminTenant, true)
+			tenant = AdminTenant
+			if tenantRef, ok := obj["tenant_ref"].(string); ok && strings.HasPrefix(tenantRef, "/api/tenant/") {
+				tenant = strings.TrimPrefix(tenantRef, "/api/tenant/")
+			}
+		}
+		for _, existing := range s.objects[objType] {
+			if existing.tenant == tenant && existing.name() == name {
+				delete(s.objects[objType], existing.uuid)
+			}
+		}
+		s.addObject(objType, uuid, tenant, obj)
+	}
+	return nil
+}
+
+// Create adds an object of objType in tenant, the same way as a POST request.
+func (s *AviSimulator) Create(objType, tenant string, data map[string]interface{}) (map[string]interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	tenantObj := s.findByName("tenant", tenant, "*")
+	if tenantObj == nil {
+		return nil, newAPIError(http.StatusBadRequest, "tenant %s not found", tenant)
+	}
+	obj, err := s.create(objType, tenantObj.uuid, deepCopy(data).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return s.render(obj, ""), nil
+}
+
+// Get returns the object of objType with name in tenant.
+func (s *AviSimulator) Get(objType, tenant, name string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	obj := s.findObject(objType, tenant, name)
+	if obj == nil {
+		return nil, false
+	}
+	return s.render(obj, ""), true
+}
+
+// List returns all the objects of objType, across all tenants.
+func (s *AviSimulator) List(objType string) []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var objs []map[string]interface{}
+	for _, obj := range s.objects[objType] {
+		objs = append(objs, s.render(obj, ""))
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		return fmt.Sprintf("%v", objs[i]["name"]) < fmt.Sprintf("%v", objs[j]["name"])
+	})
+	return objs
+}
+
+// Update changes the object of objType with name in tenant out of band, e.g. to simulate a change
+// made by a user on the controller. The refs set by updateFn must be in the "/api/<objType>/<uuid>"
+// form.
+func (s *AviSimulator) Update(objType, tenant, name string, updateFn func(data map[string]interface{})) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	obj := s.findObject(objType, tenant, name)
+	if obj == nil {
+		return newAPIError(http.StatusNotFound, "%s object %s not found in tenant %s", objType, name, tenant)
+	}
+	updateFn(obj.data)
+	obj.data["_last_modified"] = strconv.FormatInt(time.Now().UnixMicro(), 10)
+	return nil
+}
+
+// Delete removes the object of objType with name in tenant out of band, it returns false if the
+// object doesn't exist.
+func (s *AviSimulator) Delete(objType, tenant, name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	obj := s.findObject(objType, tenant, name)
+	if obj == nil {
+		return false
+	}
+	delete(s.objects[objType], obj.uuid)
+	return true
+}
+
+func (s *AviSimulator) findObject(objType, tenant, name string) *simObject {
+	tenantUUID := ""
+	if objType != "tenant" {
+		tenantObj := s.findByName("tenant", tenant, "*")
+		if tenantObj == nil {
+			return nil
+		}
+		tenantUUID = tenantObj.uuid
+	}
+	for _, obj := range s.objects[objType] {
+		if obj.tenant == tenantUUID && obj.name() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+// faultSpec is a fault in the /simulator/faults API, the latency is a duration string like "2s".
+type faultSpec struct {
+	Method     string `json:"method,omitempty"`
+	ObjType    string `json:"objType,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Latency    string `json:"latency,omitempty"`
+	Count      int    `json:"count,omitempty"`
+}
+
+// serveSimulatorAPI serves the APIs to control the simulator:
+//   - POST /simulator/faults adds a fault, DELETE /simulator/faults removes all the faults,
+//   - GET and PUT /simulator/leader fetch and change the leader state, as {"leader": true|false}.
+func (s *AviSimulator) serveSimulatorAPI(w http.ResponseWriter, r *http.Request, path string) {
+	switch {
+	case path == "faults" && r.Method == http.MethodPost:
+		var spec faultSpec
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &spec); err != nil {
+			writeError(w, newAPIError(http.StatusBadRequest, "error in parsing the fault: %v", err))
+			return
+		}
+		fault := Fault{Method: spec.Method, ObjType: spec.ObjType, StatusCode: spec.StatusCode,
+			Message: spec.Message, Count: spec.Count}
+		if spec.Latency != "" {
+			latency, err := time.ParseDuration(spec.Latency)
+			if err != nil {
+				writeError(w, newAPIError(http.StatusBadRequest, "invalid latency %s: %v", spec.Latency, err))
+				return
+			}
+			fault.Latency = latency
+		}
+		s.AddFault(fault)
+		writeResponse(w, http.StatusCreated, spec)
+	case path == "faults" && r.Method == http.MethodDelete:
+		s.ClearFaults()
+		writeResponse(w, http.StatusNoContent, nil)
+	case path == "leader" && r.Method == http.MethodGet:
+		writeResponse(w, http.StatusOK, map[string]bool{"leader": s.IsLeader()})
+	case path == "leader" && r.Method == http.MethodPut:
+		var state struct {
+			Leader bool `json:"leader"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &state); err != nil {
+			writeError(w, newAPIError(http.StatusBadRequest, "error in parsing the leader state: %v", err))
+			return
+		}
+		s.SetLeader(state.Leader)
+		writeResponse(w, http.StatusOK, state)
+	default:
+		writeError(w, newAPIError(http.StatusNotFound, "resource not found"))
+	}
+}
@@ -15,18 +15,22 @@
 package restlayer
 
 import (
+	"fmt"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/gslbutils"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/nodes"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/rest"
 	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/test/mockaviserver"
 
+	gslbalphav1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
 	gdpv1alpha2 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha2"
 
 	"github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	avicache "github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/cache"
@@ -59,11 +63,16 @@ func syncFuncForRetryTest(key interface{}, wg *sync.WaitGroup) error {
 
 func setupQueue(testCh <-chan struct{}) {
 	slowRetryQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: gslbutils.SlowRetryQueue, SlowSyncTime: gslbutils.SlowSyncTime}
-	utils.SharedWorkQueue(&slowRetryQParams)
+	graphQParams := utils.WorkerQueue{NumWorkers: 1, WorkqueueName: utils.GraphLayer}
+	utils.SharedWorkQueue(&slowRetryQParams, &graphQParams)
 
 	slowRetryQ := utils.SharedWorkQueue().GetQueueByName(gslbutils.SlowRetryQueue)
 	slowRetryQ.SyncFunc = syncFuncForRetryTest
 	slowRetryQ.Run(testCh, &sync.WaitGroup{})
+
+	graphQ := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+	graphQ.SyncFunc = rest.SyncFromNodesLayer
+	graphQ.Run(testCh, &sync.WaitGroup{})
 }
 
 func setUp() {
@@ -101,16 +110,10 @@ func buildTestGSGraph(clusterList, ipList, objNames []string, host, objType stri
 			HMProtocol: gslbutils.SystemGslbHealthMonitorHTTPS,
 			Port:       443,
 			Type:       nodes.PathHM,
-			PathHM: []nodes.PathHealthMonitorDetails{
-				{
-					Name:            "amko--d32527f936da2c6c888e4c53d19e1eda52735f5c",
-					IngressProtocol: protocol,
-					Path:            path,
-				},
-			},
 		},
 		Lock: &sync.RWMutex{},
 	}
+	gsGraph.Hm.PathHM = []nodes.PathHealthMonitorDetails{gsGraph.BuildPathHM(host, path, protocol == "https")}
 
 	gsGraph.GetChecksum()
 	return gsGraph
@@ -120,7 +123,10 @@ func verifyMembersMatch(g *gomega.WithT, gsGraph nodes.AviGSObjectGraph, gsCache
 	for _, member := range gsCacheObj.Members {
 		matched := false
 		for _, graphMember := range gsGraph.MemberObjs {
-			if member.IPAddr == graphMember.IPAddr && member.Weight == graphMember.Weight {
+			if member.Weight != graphMember.Weight {
+				continue
+			}
+			if member.IPAddr == graphMember.IPAddr || gslbutils.PresentInList(member.IPAddr, graphMember.IPAddrs) {
 				matched = true
 				break
 			}
@@ -219,3 +225,220 @@ func TestDeleteGS(t *testing.T) {
 
 	saveSyncAndVerify(t, modelName, gsGraph, true)
 }
+
+func verifyMemberIPsInAviCache(t *testing.T, gsGraph nodes.AviGSObjectGraph, ipList []string) {
+	cache := avicache.GetAviCache()
+	cacheKey := avicache.TenantName{
+		Tenant: gsGraph.Tenant,
+		Name:   gsGraph.Name,
+	}
+	g := gomega.NewGomegaWithT(t)
+	gsCache, found := cache.AviCacheGet(cacheKey)
+	g.Expect(found).To(gomega.Equal(true))
+	gsCacheObj, ok := gsCache.(*avicache.AviGSCache)
+	g.Expect(ok).To(gomega.Equal(true))
+	memberIPs := []string{}
+	for _, member := range gsCacheObj.Members {
+		memberIPs = append(memberIPs, member.IPAddr)
+	}
+	g.Expect(memberIPs).To(gomega.ConsistOf(ipList))
+}
+
+func TestCreateDualStackGS(t *testing.T) {
+	host := "host4.avi.com"
+	clusterList := []string{"foo", "bar"}
+	ipList := []string{"10.10.10.41", "10.10.10.42"}
+	ipv6List := []string{"2001:db8::41", "2001:db8::42"}
+	names := []string{"ing1/" + host, "ing2/" + host}
+	modelName := gslbutils.GetTenant() + "/" + host
+	gsGraph := buildTestGSGraph(clusterList, ipList, names, host, gdpv1alpha2.IngressObj)
+	for idx := range gsGraph.MemberObjs {
+		gsGraph.MemberObjs[idx].IPAddrs = []string{ipList[idx], ipv6List[idx]}
+	}
+
+	// no ip family, only the primary addresses are added as pool members
+	saveSyncAndVerify(t, modelName, gsGraph, false)
+	verifyMemberIPsInAviCache(t, gsGraph, ipList)
+
+	// dual-stack, one pool member per address family
+	gsGraph.IPFamily = gslbalphav1.IPFamilyDualStack
+	gsGraph.CalculateChecksum()
+	saveSyncAndVerify(t, modelName, gsGraph, false)
+	verifyMemberIPsInAviCache(t, gsGraph, append(ipList, ipv6List...))
+
+	// IPv6 only
+	gsGraph.IPFamily = gslbalphav1.IPFamilyV6
+	gsGraph.CalculateChecksum()
+	saveSyncAndVerify(t, modelName, gsGraph, false)
+	verifyMemberIPsInAviCache(t, gsGraph, ipv6List)
+}
+
+func getMetricValue(t *testing.T, name string, labels map[string]string) float64 {
+	mfs, err := gslbutils.GetMetricsRegistry().Gather()
+	if err != nil {
+		t.Fatalf("error in gathering metrics: %v", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if !metricLabelsMatch(m, labels) {
+				continue
+			}
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				return m.GetCounter().GetValue()
+			case dto.MetricType_GAUGE:
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func metricLabelsMatch(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, lp := range m.GetLabel() {
+		if val, ok := labels[lp.GetName()]; ok {
+			if val != lp.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func TestRestMetrics(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	avicache.RegisterCacheMetrics()
+
+	host := "host5.avi.com"
+	clusterList := []string{"foo", "bar"}
+	ipList := []string{"10.10.10.51", "10.10.10.52"}
+	names := []string{"ing1/" + host, "ing2/" + host}
+	modelName := gslbutils.GetTenant() + "/" + host
+	restLabels := map[string]string{"method": "POST", "model": "GSLBService", "status": gslbutils.MetricsResultSuccess}
+	prevCount := getMetricValue(t, "amko_avi_rest_requests_total", restLabels)
+
+	gsGraph := buildTestGSGraph(clusterList, ipList, names, host, gdpv1alpha2.IngressObj)
+	saveSyncAndVerify(t, modelName, gsGraph, false)
+
+	g.Expect(getMetricValue(t, "amko_avi_rest_requests_total", restLabels)).To(gomega.Equal(prevCount + 1))
+	g.Expect(getMetricValue(t, "amko_cache_objects", map[string]string{"type": "GSLBService"})).To(
+		gomega.Equal(float64(avicache.GetAviCache().AviCacheLen())))
+}
+
+func getPlannedOps(key, method, model string) []rest.PlannedOperation {
+	ops := []rest.PlannedOperation{}
+	for _, op := range rest.SharedOperationPlan().Get(key) {
+		if op.Method == method && op.Model == model {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func saveAndSyncGraph(modelName string, gsGraph *nodes.AviGSObjectGraph) {
+	gsGraph.SetRetryCounter()
+	nodes.SharedAviGSGraphLister().Save(modelName, gsGraph)
+	rest.SyncFromNodesLayer(modelName, &sync.WaitGroup{})
+}
+
+func TestDryRunGS(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	host := "dryrun-host1.avi.com"
+	clusterList := []string{"foo", "bar"}
+	ipList := []string{"10.10.10.11", "10.10.10.21"}
+	names := []string{"ing1/" + host, "ing2/" + host}
+	modelName := gslbutils.GetTenant() + "/" + host
+
+	os.Setenv("DRY_RUN", "true")
+	defer os.Unsetenv("DRY_RUN")
+
+	// the GS is only planned, and not created
+	gsGraph := buildTestGSGraph(clusterList, ipList, names, host, gdpv1alpha2.IngressObj)
+	saveAndSyncGraph(modelName, &gsGraph)
+	verifyInAviCache(t, gsGraph, true)
+	gsOps := getPlannedOps(modelName, "POST", "GSLBService")
+	g.Expect(gsOps).To(gomega.HaveLen(1))
+	g.Expect(gsOps[0].Name).To(gomega.Equal(host))
+	g.Expect(gsOps[0].Existing).To(gomega.BeNil())
+
+	// syncing the key again rebuilds the plan for the key
+	saveAndSyncGraph(modelName, &gsGraph)
+	g.Expect(getPlannedOps(modelName, "POST", "GSLBService")).To(gomega.HaveLen(1))
+
+	// create the GS, and verify that an update is planned against the cached GS
+	os.Unsetenv("DRY_RUN")
+	saveSyncAndVerify(t, modelName, gsGraph, false)
+
+	os.Setenv("DRY_RUN", "true")
+	updatedGraph := buildTestGSGraph(clusterList, []string{"10.10.10.12", "10.10.10.21"}, names, host,
+		gdpv1alpha2.IngressObj)
+	saveAndSyncGraph(modelName, &updatedGraph)
+	g.Expect(rest.SharedOperationPlan().Get(modelName)).To(gomega.HaveLen(1))
+	gsOps = getPlannedOps(modelName, "PUT", "GSLBService")
+	g.Expect(gsOps).To(gomega.HaveLen(1))
+	g.Expect(gsOps[0].Existing).NotTo(gomega.BeNil())
+	// the cache still has the old member
+	verifyInAviCache(t, gsGraph, false)
+}
+
+func deleteAndSyncGraph(modelName string, gsGraph *nodes.AviGSObjectGraph) {
+	gsGraph.SetRetryCounter()
+	nodes.SharedDeleteGSGraphLister().Save(modelName, gsGraph)
+	nodes.SharedAviGSGraphLister().Delete(modelName)
+	rest.SyncFromNodesLayer(modelName, &sync.WaitGroup{})
+}
+
+func TestDeletionProtection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	rest.SetDeletionProtection(&gslbalphav1.DeletionProtection{MaxDeletions: 2, Window: 300})
+	defer rest.SetDeletionProtection(nil)
+
+	var gsGraphs []nodes.AviGSObjectGraph
+	var modelNames []string
+	for i := 1; i <= 4; i++ {
+		host := fmt.Sprintf("dp-host%d.avi.com", i)
+		modelName := gslbutils.GetTenant() + "/" + host
+		gsGraph := buildTestGSGraph([]string{"foo"}, []string{fmt.Sprintf("10.10.20.%d", i)},
+			[]string{"ing1/" + host}, host, gdpv1alpha2.IngressObj)
+		saveSyncAndVerify(t, modelName, gsGraph, false)
+		gsGraphs = append(gsGraphs, gsGraph)
+		modelNames = append(modelNames, modelName)
+	}
+
+	// the deletions within the threshold go through, the ones after are held
+	for i := range gsGraphs {
+		deleteAndSyncGraph(modelNames[i], &gsGraphs[i])
+	}
+	verifyInAviCache(t, gsGraphs[0], true)
+	verifyInAviCache(t, gsGraphs[1], true)
+	verifyInAviCache(t, gsGraphs[2], false)
+	verifyInAviCache(t, gsGraphs[3], false)
+	heldID, heldKeys := rest.GetHeldDeletions()
+	g.Expect(heldID).NotTo(gomega.BeEmpty())
+	g.Expect(heldKeys).To(gomega.ConsistOf(modelNames[2], modelNames[3]))
+
+	// a GS which isn't to be deleted anymore is dropped from the held deletions
+	saveSyncAndVerify(t, modelNames[3], gsGraphs[3], false)
+	_, heldKeys = rest.GetHeldDeletions()
+	g.Expect(heldKeys).To(gomega.ConsistOf(modelNames[2]))
+
+	// the held deletions are released only with the right ID
+	rest.ReleaseHeldDeletions("invalid-id")
+	_, heldKeys = rest.GetHeldDeletions()
+	g.Expect(heldKeys).To(gomega.HaveLen(1))
+
+	rest.ReleaseHeldDeletions(heldID)
+	heldID, heldKeys = rest.GetHeldDeletions()
+	g.Expect(heldID).To(gomega.BeEmpty())
+	g.Expect(heldKeys).To(gomega.BeEmpty())
+	g.Eventually(func() bool {
+		_, found := avicache.GetAviCache().AviCacheGet(avicache.TenantName{Tenant: gsGraphs[2].Tenant,
+			Name: gsGraphs[2].Name})
+		return found
+	}, 10*time.Second, 500*time.Millisecond).Should(gomega.BeFalse())
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 VMware, Inc.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package simulate
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/vmware/global-load-balancing-services-for-kubernetes/gslb/simulate"
+)
+
+// simulate.Run sets up the global state of AMKO, so only one simulation can be run per test binary.
+// The expected output can be regenerated with:
+// go run ./cmd/amko-simulate -dir gslb/test/simulate/testdata/basic -o gslb/test/simulate/testdata/basic.json
+func TestSimulateBasic(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	result, err := simulate.Run(simulate.Options{
+		Dir:        "testdata/basic",
+		PKIProfile: "System-Default-PKI-Profile",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	g.Expect(result.GslbServices).To(gomega.HaveLen(2))
+	dbGS := result.GslbServices[0]
+	g.Expect(*dbGS.Name).To(gomega.Equal("db.example.com"))
+	g.Expect(*dbGS.TTL).To(gomega.Equal(uint32(30)))
+	g.Expect(dbGS.HealthMonitorRefs).To(gomega.Equal([]string{"/api/healthmonitor?name=db-hm"}))
+	g.Expect(dbGS.Groups).To(gomega.HaveLen(1))
+	g.Expect(dbGS.Groups[0].Members).To(gomega.HaveLen(2))
+	g.Expect(*dbGS.Groups[0].Members[0].IP.Addr).To(gomega.Equal("10.1.1.2"))
+	g.Expect(*dbGS.Groups[0].Members[0].Ratio).To(gomega.Equal(uint32(8)))
+	g.Expect(*dbGS.Groups[0].Members[1].IP.Addr).To(gomega.Equal("10.2.1.2"))
+	g.Expect(*dbGS.Groups[0].Members[1].Ratio).To(gomega.Equal(uint32(2)))
+
+	// the unselected cache service doesn't get a GslbService
+	webGS := result.GslbServices[1]
+	g.Expect(*webGS.Name).To(gomega.Equal("web.example.com"))
+	g.Expect(webGS.HealthMonitorRefs).To(gomega.HaveLen(2))
+	g.Expect(result.HealthMonitors).To(gomega.HaveLen(2))
+	for _, hm := range result.HealthMonitors {
+		g.Expect(webGS.HealthMonitorRefs).To(gomega.ContainElement("/api/healthmonitor?name=" + *hm.Name))
+	}
+
+	expected, err := os.ReadFile("testdata/basic.json")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	actual, err := json.MarshalIndent(result, "", "  ")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(actual) + "\n").To(gomega.MatchJSON(string(expected)))
+}
+
+// The default PKI profile can't be fetched from the controller offline, so a PKI profile is required.
+// The options are validated before the global state is set up.
+func TestSimulateWithoutPKIProfile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := simulate.Run(simulate.Options{Dir: "testdata/basic"})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("PKI profile is required")))
+}
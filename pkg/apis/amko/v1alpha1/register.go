@@ -52,6 +52,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&GSLBConfigList{},
 		&GSLBHostRule{},
 		&GSLBHostRuleList{},
+		&GSLBTrafficShift{},
+		&GSLBTrafficShiftList{},
 	)
 
 	scheme.AddKnownTypes(
@@ -49,6 +49,44 @@ type GSLBConfigSpec struct {
 	RefreshInterval     int             `json:"refreshInterval,omitempty"`
 	LogLevel            string          `json:"logLevel,omitempty"`
 	UseCustomGlobalFqdn *bool           `json:"useCustomGlobalFqdn,omitempty"`
+	// StaleObjectGracePeriod is the time in seconds for which the objects of an unhealthy member
+	// cluster are kept, if their deletion can't be confirmed. 0 keeps them until the cluster recovers.
+	StaleObjectGracePeriod int `json:"staleObjectGracePeriod,omitempty"`
+	// DeletionProtection pauses the GslbService deletions if too many of them are seen in a short time.
+	DeletionProtection *DeletionProtection `json:"deletionProtection,omitempty"`
+	// AdoptExistingGslbServices lets AMKO take ownership of the GslbServices created outside of AMKO,
+	// whose domain names match the FQDNs of the GslbServices built by AMKO.
+	AdoptExistingGslbServices bool `json:"adoptExistingGslbServices,omitempty"`
+	// OrphanCleanup finds the GslbServices and health monitors created by this AMKO instance which
+	// don't belong to any GslbService built by AMKO anymore, and reports or deletes them.
+	OrphanCleanup *OrphanCleanup `json:"orphanCleanup,omitempty"`
+}
+
+// OrphanCleanup defines how the orphaned GslbServices and health monitors are handled.
+type OrphanCleanup struct {
+	// Mode is one of Disabled (default), ReportOnly or Delete.
+	Mode string `json:"mode,omitempty"`
+	// GracePeriod is the time in seconds for which an object must be orphaned before it is deleted,
+	// defaults to 1800 seconds.
+	GracePeriod int `json:"gracePeriod,omitempty"`
+}
+
+const (
+	OrphanCleanupDisabled   = "Disabled"
+	OrphanCleanupReportOnly = "ReportOnly"
+	OrphanCleanupDelete     = "Delete"
+)
+
+// DeletionProtection defines the thresholds for the GslbService deletions in a time window, beyond
+// which the deletions are held till they are acknowledged.
+type DeletionProtection struct {
+	// MaxDeletions is the number of GslbService deletions allowed in the window, 0 disables this check.
+	MaxDeletions int `json:"maxDeletions,omitempty"`
+	// MaxDeletionPercentage is the percentage of the GslbServices allowed to be deleted in the window,
+	// 0 disables this check.
+	MaxDeletionPercentage int `json:"maxDeletionPercentage,omitempty"`
+	// Window is the time window in seconds, defaults to 60 seconds.
+	Window int `json:"window,omitempty"`
 }
 
 // GSLBLeader is the leader node in the GSLB cluster
@@ -62,11 +100,32 @@ type GSLBLeader struct {
 // MemberCluster defines a GSLB member cluster details
 type MemberCluster struct {
 	ClusterContext string `json:"clusterContext,omitempty"`
+	// UseNodeRegion sets the topology.kubernetes.io/region label of the cluster's nodes as the location
+	// of its GS members which don't have a location otherwise, i.e., the members synced as VIPs only
+	// without a location in the GDP objects.
+	UseNodeRegion bool `json:"useNodeRegion,omitempty"`
+	// Drain disables the GS members from this cluster in all the GslbServices, irrespective of the GDP
+	// objects selecting them.
+	Drain bool `json:"drain,omitempty"`
 }
 
 // GSLBConfigStatus represents the state and status message of the GSLB cluster
 type GSLBConfigStatus struct {
 	State string `json:"state,omitempty"`
+	// AdoptionConflicts are the existing GslbServices which AMKO couldn't adopt
+	AdoptionConflicts []AdoptionConflict `json:"adoptionConflicts,omitempty"`
+}
+
+// AdoptionConflict is an existing GslbService whose domain names match a GslbService built by AMKO,
+// but which can't be adopted by AMKO.
+type AdoptionConflict struct {
+	// GslbService is the name of the GslbService built by AMKO
+	GslbService string `json:"gslbService,omitempty"`
+	// ExistingGslbService is the name of the existing GslbService
+	ExistingGslbService string `json:"existingGslbService,omitempty"`
+	Tenant              string `json:"tenant,omitempty"`
+	// Reason is why the existing GslbService can't be adopted
+	Reason string `json:"reason,omitempty"`
 }
 
 // how the Global services are going to be named
@@ -90,6 +149,19 @@ type TrafficSplitElem struct {
 	Priority uint32 `json:"priority,omitempty"`
 }
 
+// MemberTrafficSplitElem determines how much traffic to be routed to a single member object of a
+// GSLB service.
+type MemberTrafficSplitElem struct {
+	// Cluster is the cluster context of the member object
+	Cluster   string `json:"cluster"`
+	Namespace string `json:"namespace"`
+	// Kind of the member object, one of Ingress, Route, Service, MultiClusterIngress or HTTPRoute
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Weight   uint32 `json:"weight,omitempty"`
+	Priority uint32 `json:"priority,omitempty"`
+}
+
 // PublicIPElem determines the publicip of a cluster where traffic should be routed
 type PublicIPElem struct {
 	// Cluster is the cluster context
@@ -149,6 +221,9 @@ type GSLBHostRuleSpec struct {
 	HealthMonitorTemplate *string `json:"healthMonitorTemplate,omitempty"`
 	// TrafficSplit defines the weightage of traffic that can be routed to each cluster.
 	TrafficSplit []TrafficSplitElem `json:"trafficSplit,omitempty"`
+	// MemberTrafficSplit defines the weightage of traffic that can be routed to individual member
+	// objects, and takes precedence over TrafficSplit for those members.
+	MemberTrafficSplit []MemberTrafficSplitElem `json:"memberTrafficSplit,omitempty"`
 	// DownResponse defines the properties of the DNS service such as response towards the client when the GSLB service is DOWN,
 	// fallback IP to use in A response to the client query.
 	DownResponse *DownResponse `json:"downResponse,omitempty"`
@@ -156,6 +231,25 @@ type GSLBHostRuleSpec struct {
 	PublicIP []PublicIPElem `json:"publicIP,omitempty"`
 	// ControlPlaneHmOnly will only enable hm on control plane and would not create data plane HM for GSLB service
 	ControlPlaneHmOnly *bool `json:"controlPlaneHmOnly,omitempty"`
+	// IPFamily determines the address family of the GS pool members, one of V4, V6 or V4_V6 (dual-stack).
+	// If unset, only the first status IP of each member object is used.
+	IPFamily *string `json:"ipFamily,omitempty"`
+	// HealthMonitorScope determines the members of the GSLB Service which are health monitored,
+	// one of GSLB_SERVICE_HEALTH_MONITOR_ALL_MEMBERS or GSLB_SERVICE_HEALTH_MONITOR_ONLY_NON_CONTROLLER_MEMBERS.
+	HealthMonitorScope *string `json:"healthMonitorScope,omitempty"`
+	// MinMembers is the minimum number of members which must be up for the GSLB Service to be up.
+	MinMembers *int `json:"minMembers,omitempty"`
+	// WildcardMatch enables the wildcard match of the GSLB Service's domain names.
+	WildcardMatch *bool `json:"wildcardMatch,omitempty"`
+	// ResolveCname enables the resolution of the CNAME records of the GSLB Service.
+	ResolveCname *bool `json:"resolveCname,omitempty"`
+	// UseEdnsClientSubnet enables the use of the EDNS client subnet for the GSLB Service.
+	UseEdnsClientSubnet *bool `json:"useEdnsClientSubnet,omitempty"`
+	// IsFederated determines whether the GSLB Service is replicated to the other sites.
+	IsFederated *bool `json:"isFederated,omitempty"`
+	// MinHealthMonitorsUp is the minimum number of health monitors which must mark a member up,
+	// set on all the pools of the GSLB Service.
+	MinHealthMonitorsUp *int `json:"minHealthMonitorsUp,omitempty"`
 }
 
 // PoolAlgorithmSettings define a set of properties to select the Gslb Algorithm for a Gslb
@@ -195,11 +289,86 @@ type GSLBHostRuleStatus struct {
 }
 
 type ThirdPartyMember struct {
-	VIP      string `json:"vip,omitempty"`
-	Site     string `json:"site,omitempty"`
-	PublicIP string `json:"publicIP,omitempty"`
+	VIP      string       `json:"vip,omitempty"`
+	Site     string       `json:"site,omitempty"`
+	PublicIP string       `json:"publicIP,omitempty"`
+	Location *GeoLocation `json:"location,omitempty"`
+}
+
+// GeoLocation is the geographic location of a GslbService member, used by the geo and the topology
+// pool algorithms. Name is in the Country/State/City format and Region is set as the location tag.
+// Latitude (-90 to 90) and Longitude (-180 to 180) are in degrees.
+type GeoLocation struct {
+	Name      string   `json:"name,omitempty"`
+	Region    string   `json:"region,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// GSLBTrafficShift moves the traffic of a GSLB Service, or of all the GSLB Services of a GDP
+// object, from the source clusters to the target clusters in steps.
+type GSLBTrafficShift struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GSLBTrafficShiftSpec `json:"spec,omitempty"`
+	// +optional
+	Status GSLBTrafficShiftStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GSLBTrafficShiftList is a list of GSLBTrafficShift resources
+type GSLBTrafficShiftList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GSLBTrafficShift `json:"items"`
+}
+
+// GSLBTrafficShiftSpec defines the GSLB Services whose traffic is shifted and the steps of the shift.
+// Exactly one of Fqdn and GDP must be set.
+type GSLBTrafficShiftSpec struct {
+	// Fqdn is the fqdn of the GSLB Service whose traffic is shifted.
+	Fqdn string `json:"fqdn,omitempty"`
+	// GDP is the name of a GDP object in the same namespace, the traffic of all the GSLB Services
+	// owned by it is shifted.
+	GDP string `json:"gdp,omitempty"`
+	// SourceClusters are the cluster contexts from which the traffic is moved.
+	SourceClusters []string `json:"sourceClusters"`
+	// TargetClusters are the cluster contexts to which the traffic is moved.
+	TargetClusters []string `json:"targetClusters"`
+	// Steps are the percentages of the traffic routed to the target clusters, in increasing order.
+	// At 100, the members of the source clusters only serve as a backup for the target clusters.
+	Steps []uint32 `json:"steps"`
+	// StepInterval is the time in seconds between two steps, the health of the members of the target
+	// clusters is checked before moving to the next step.
+	StepInterval *int `json:"stepInterval,omitempty"`
 }
 
+// GSLBTrafficShiftStatus is the progress of a GSLBTrafficShift.
+type GSLBTrafficShiftStatus struct {
+	// Phase is one of Progressing, Completed, RolledBack or Rejected.
+	Phase string `json:"phase,omitempty"`
+	// CurrentStep is the number of the steps applied so far.
+	CurrentStep int `json:"currentStep,omitempty"`
+	// LastStepTime is the time at which the current step was applied.
+	LastStepTime *metav1.Time `json:"lastStepTime,omitempty"`
+	// Message is the reason of a rejection or a roll back.
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	TrafficShiftProgressing = "Progressing"
+	TrafficShiftCompleted   = "Completed"
+	TrafficShiftRolledBack  = "RolledBack"
+	TrafficShiftRejected    = "Rejected"
+)
+
 const (
 	PoolAlgorithmConsistentHash = "GSLB_ALGORITHM_CONSISTENT_HASH"
 	PoolAlgorithmGeo            = "GSLB_ALGORITHM_GEO"
@@ -207,6 +376,17 @@ const (
 	PoolAlgorithmTopology       = "GSLB_ALGORITHM_TOPOLOGY"
 )
 
+const (
+	IPFamilyV4        = "V4"
+	IPFamilyV6        = "V6"
+	IPFamilyDualStack = "V4_V6"
+)
+
+const (
+	HealthMonitorScopeAllMembers               = "GSLB_SERVICE_HEALTH_MONITOR_ALL_MEMBERS"
+	HealthMonitorScopeOnlyNonControllerMembers = "GSLB_SERVICE_HEALTH_MONITOR_ONLY_NON_CONTROLLER_MEMBERS"
+)
+
 const (
 	GSLBServiceDownResponseNone       = "GSLB_SERVICE_DOWN_RESPONSE_NONE"
 	GSLBServiceDownResponseAllRecords = "GSLB_SERVICE_DOWN_RESPONSE_ALL_RECORDS"
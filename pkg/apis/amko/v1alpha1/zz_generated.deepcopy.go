@@ -25,6 +25,38 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionConflict) DeepCopyInto(out *AdoptionConflict) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionConflict.
+func (in *AdoptionConflict) DeepCopy() *AdoptionConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionProtection) DeepCopyInto(out *DeletionProtection) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionProtection.
+func (in *DeletionProtection) DeepCopy() *DeletionProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DownResponse) DeepCopyInto(out *DownResponse) {
 	*out = *in
@@ -47,7 +79,7 @@ func (in *GSLBConfig) DeepCopyInto(out *GSLBConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -116,6 +148,16 @@ func (in *GSLBConfigSpec) DeepCopyInto(out *GSLBConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(DeletionProtection)
+		**out = **in
+	}
+	if in.OrphanCleanup != nil {
+		in, out := &in.OrphanCleanup, &out.OrphanCleanup
+		*out = new(OrphanCleanup)
+		**out = **in
+	}
 	return
 }
 
@@ -165,6 +207,11 @@ func (in *GSLBConfigSpecList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GSLBConfigStatus) DeepCopyInto(out *GSLBConfigStatus) {
 	*out = *in
+	if in.AdoptionConflicts != nil {
+		in, out := &in.AdoptionConflicts, &out.AdoptionConflicts
+		*out = make([]AdoptionConflict, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -260,7 +307,9 @@ func (in *GSLBHostRuleSpec) DeepCopyInto(out *GSLBHostRuleSpec) {
 	if in.ThirdPartyMembers != nil {
 		in, out := &in.ThirdPartyMembers, &out.ThirdPartyMembers
 		*out = make([]ThirdPartyMember, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.HealthMonitorRefs != nil {
 		in, out := &in.HealthMonitorRefs, &out.HealthMonitorRefs
@@ -277,6 +326,11 @@ func (in *GSLBHostRuleSpec) DeepCopyInto(out *GSLBHostRuleSpec) {
 		*out = make([]TrafficSplitElem, len(*in))
 		copy(*out, *in)
 	}
+	if in.MemberTrafficSplit != nil {
+		in, out := &in.MemberTrafficSplit, &out.MemberTrafficSplit
+		*out = make([]MemberTrafficSplitElem, len(*in))
+		copy(*out, *in)
+	}
 	if in.DownResponse != nil {
 		in, out := &in.DownResponse, &out.DownResponse
 		*out = new(DownResponse)
@@ -292,6 +346,46 @@ func (in *GSLBHostRuleSpec) DeepCopyInto(out *GSLBHostRuleSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(string)
+		**out = **in
+	}
+	if in.HealthMonitorScope != nil {
+		in, out := &in.HealthMonitorScope, &out.HealthMonitorScope
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinMembers != nil {
+		in, out := &in.MinMembers, &out.MinMembers
+		*out = new(int)
+		**out = **in
+	}
+	if in.WildcardMatch != nil {
+		in, out := &in.WildcardMatch, &out.WildcardMatch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResolveCname != nil {
+		in, out := &in.ResolveCname, &out.ResolveCname
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseEdnsClientSubnet != nil {
+		in, out := &in.UseEdnsClientSubnet, &out.UseEdnsClientSubnet
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsFederated != nil {
+		in, out := &in.IsFederated, &out.IsFederated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinHealthMonitorsUp != nil {
+		in, out := &in.MinHealthMonitorsUp, &out.MinHealthMonitorsUp
+		*out = new(int)
+		**out = **in
+	}
 	return
 }
 
@@ -321,6 +415,123 @@ func (in *GSLBHostRuleStatus) DeepCopy() *GSLBHostRuleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GSLBTrafficShift) DeepCopyInto(out *GSLBTrafficShift) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GSLBTrafficShift.
+func (in *GSLBTrafficShift) DeepCopy() *GSLBTrafficShift {
+	if in == nil {
+		return nil
+	}
+	out := new(GSLBTrafficShift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GSLBTrafficShift) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GSLBTrafficShiftList) DeepCopyInto(out *GSLBTrafficShiftList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GSLBTrafficShift, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GSLBTrafficShiftList.
+func (in *GSLBTrafficShiftList) DeepCopy() *GSLBTrafficShiftList {
+	if in == nil {
+		return nil
+	}
+	out := new(GSLBTrafficShiftList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GSLBTrafficShiftList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GSLBTrafficShiftSpec) DeepCopyInto(out *GSLBTrafficShiftSpec) {
+	*out = *in
+	if in.SourceClusters != nil {
+		in, out := &in.SourceClusters, &out.SourceClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetClusters != nil {
+		in, out := &in.TargetClusters, &out.TargetClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepInterval != nil {
+		in, out := &in.StepInterval, &out.StepInterval
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GSLBTrafficShiftSpec.
+func (in *GSLBTrafficShiftSpec) DeepCopy() *GSLBTrafficShiftSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GSLBTrafficShiftSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GSLBTrafficShiftStatus) DeepCopyInto(out *GSLBTrafficShiftStatus) {
+	*out = *in
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GSLBTrafficShiftStatus.
+func (in *GSLBTrafficShiftStatus) DeepCopy() *GSLBTrafficShiftStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GSLBTrafficShiftStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GSLBLeader) DeepCopyInto(out *GSLBLeader) {
 	*out = *in
@@ -363,6 +574,32 @@ func (in *GeoFallback) DeepCopy() *GeoFallback {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoLocation) DeepCopyInto(out *GeoLocation) {
+	*out = *in
+	if in.Latitude != nil {
+		in, out := &in.Latitude, &out.Latitude
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Longitude != nil {
+		in, out := &in.Longitude, &out.Longitude
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoLocation.
+func (in *GeoLocation) DeepCopy() *GeoLocation {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoLocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemberCluster) DeepCopyInto(out *MemberCluster) {
 	*out = *in
@@ -379,6 +616,38 @@ func (in *MemberCluster) DeepCopy() *MemberCluster {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemberTrafficSplitElem) DeepCopyInto(out *MemberTrafficSplitElem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemberTrafficSplitElem.
+func (in *MemberTrafficSplitElem) DeepCopy() *MemberTrafficSplitElem {
+	if in == nil {
+		return nil
+	}
+	out := new(MemberTrafficSplitElem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanCleanup) DeepCopyInto(out *OrphanCleanup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanCleanup.
+func (in *OrphanCleanup) DeepCopy() *OrphanCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PoolAlgorithmSettings) DeepCopyInto(out *PoolAlgorithmSettings) {
 	*out = *in
@@ -445,6 +714,11 @@ func (in *SitePersistence) DeepCopy() *SitePersistence {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ThirdPartyMember) DeepCopyInto(out *ThirdPartyMember) {
 	*out = *in
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(GeoLocation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
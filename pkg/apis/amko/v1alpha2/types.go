@@ -62,14 +62,49 @@ type GDPSpec struct {
 	DownResponse          *gslbalphav1.DownResponse          `json:"downResponse,omitempty"`
 	ControlPlaneHmOnly    *bool                              `json:"controlPlaneHmOnly,omitempty"`
 	DefaultDomain         *string                            `json:"defaultDomain,omitempty"`
+	IPFamily              *string                            `json:"ipFamily,omitempty"`
+	HealthMonitorScope    *string                            `json:"healthMonitorScope,omitempty"`
+	MinMembers            *int                               `json:"minMembers,omitempty"`
+	WildcardMatch         *bool                              `json:"wildcardMatch,omitempty"`
+	ResolveCname          *bool                              `json:"resolveCname,omitempty"`
+	UseEdnsClientSubnet   *bool                              `json:"useEdnsClientSubnet,omitempty"`
+	IsFederated           *bool                              `json:"isFederated,omitempty"`
+	MinHealthMonitorsUp   *int                               `json:"minHealthMonitorsUp,omitempty"`
+	// NamespacedPolicyGuardrails bound the GDP objects created outside the avi-system namespace,
+	// only honoured for the GDP objects in the avi-system namespace.
+	NamespacedPolicyGuardrails *NamespacedPolicyGuardrails `json:"namespacedPolicyGuardrails,omitempty"`
+}
+
+// NamespacedPolicyGuardrails specify the limits within which the GDP objects outside the avi-system
+// namespace (namespaced GDP objects) can operate. Namespaced GDP objects are only accepted if an
+// admin GDP object specifies these guardrails.
+type NamespacedPolicyGuardrails struct {
+	// AllowedClusters is the list of clusters which can be selected by a namespaced GDP object,
+	// empty list allows all the clusters.
+	AllowedClusters []string `json:"allowedClusters,omitempty"`
+	// TTLRange is the range of TTL values allowed for a namespaced GDP object.
+	TTLRange *TTLRange `json:"ttlRange,omitempty"`
+	// AllowedHealthMonitorRefs is the list of health monitor refs which can be used by a
+	// namespaced GDP object.
+	AllowedHealthMonitorRefs []string `json:"allowedHealthMonitorRefs,omitempty"`
+}
+
+// TTLRange is an inclusive range of TTL values.
+type TTLRange struct {
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
 }
 
 // ClusterProperty specifies all the properties required for a Cluster. Cluster is the cluster
 // context name (already added as part of the GSLBConfig object).
 // SyncVIPOnly will ask AMKO to sync only the third party vips for this cluster.
+// Location is set as the geo location of the GslbService members from this cluster.
+// Drain disables the GslbService members from this cluster, e.g. for a maintenance of the cluster.
 type ClusterProperty struct {
-	Cluster     string `json:"cluster,omitempty"`
-	SyncVipOnly bool   `json:"syncVipOnly,omitempty"`
+	Cluster     string                   `json:"cluster,omitempty"`
+	SyncVipOnly bool                     `json:"syncVipOnly,omitempty"`
+	Location    *gslbalphav1.GeoLocation `json:"location,omitempty"`
+	Drain       bool                     `json:"drain,omitempty"`
 }
 
 // MatchRules is the match criteria needed to select the kubernetes/openshift objects.
@@ -78,14 +113,20 @@ type MatchRules struct {
 	NamespaceSelector `json:"namespaceSelector,omitempty"`
 }
 
-// AppSelector selects the applications based on their labels
+// AppSelector selects the applications based on their labels. An application is selected only if
+// it satisfies all the labels and all the label requirements.
 type AppSelector struct {
 	Label map[string]string `json:"label,omitempty"`
+	// MatchExpressions is a list of set-based label requirements (In, NotIn, Exists, DoesNotExist)
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
-// NamespaceSelector selects the applications based on their labels
+// NamespaceSelector selects the applications based on the labels of their namespaces. A namespace is
+// selected only if it satisfies all the labels and all the label requirements.
 type NamespaceSelector struct {
 	Label map[string]string `json:"label,omitempty"`
+	// MatchExpressions is a list of set-based label requirements (In, NotIn, Exists, DoesNotExist)
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
 }
 
 // Objects on which rules will be applied
@@ -111,4 +152,10 @@ type TrafficSplitElem struct {
 // GDPStatus gives the current status of the policy object.
 type GDPStatus struct {
 	ErrorStatus string `json:"errorStatus,omitempty"`
+	// GslbServices is the list of GslbServices owned by this GDP object, i.e., the GslbServices
+	// for which this GDP object has the highest precedence among the selecting GDP objects.
+	GslbServices []string `json:"gslbServices,omitempty"`
+	// Conflicts is the list of GslbServices selected by this GDP object, but owned by another GDP
+	// object.
+	Conflicts []string `json:"conflicts,omitempty"`
 }
@@ -23,6 +23,7 @@ package v1alpha2
 
 import (
 	v1alpha1 "github.com/vmware/global-load-balancing-services-for-kubernetes/pkg/apis/amko/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -36,6 +37,13 @@ func (in *AppSelector) DeepCopyInto(out *AppSelector) {
 			(*out)[key] = val
 		}
 	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -52,6 +60,11 @@ func (in *AppSelector) DeepCopy() *AppSelector {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProperty) DeepCopyInto(out *ClusterProperty) {
 	*out = *in
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(v1alpha1.GeoLocation)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -72,7 +85,9 @@ func (in *GDPSpec) DeepCopyInto(out *GDPSpec) {
 	if in.MatchClusters != nil {
 		in, out := &in.MatchClusters, &out.MatchClusters
 		*out = make([]ClusterProperty, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.TrafficSplit != nil {
 		in, out := &in.TrafficSplit, &out.TrafficSplit
@@ -119,6 +134,56 @@ func (in *GDPSpec) DeepCopyInto(out *GDPSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DefaultDomain != nil {
+		in, out := &in.DefaultDomain, &out.DefaultDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(string)
+		**out = **in
+	}
+	if in.HealthMonitorScope != nil {
+		in, out := &in.HealthMonitorScope, &out.HealthMonitorScope
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinMembers != nil {
+		in, out := &in.MinMembers, &out.MinMembers
+		*out = new(int)
+		**out = **in
+	}
+	if in.WildcardMatch != nil {
+		in, out := &in.WildcardMatch, &out.WildcardMatch
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ResolveCname != nil {
+		in, out := &in.ResolveCname, &out.ResolveCname
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UseEdnsClientSubnet != nil {
+		in, out := &in.UseEdnsClientSubnet, &out.UseEdnsClientSubnet
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsFederated != nil {
+		in, out := &in.IsFederated, &out.IsFederated
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinHealthMonitorsUp != nil {
+		in, out := &in.MinHealthMonitorsUp, &out.MinHealthMonitorsUp
+		*out = new(int)
+		**out = **in
+	}
+	if in.NamespacedPolicyGuardrails != nil {
+		in, out := &in.NamespacedPolicyGuardrails, &out.NamespacedPolicyGuardrails
+		*out = new(NamespacedPolicyGuardrails)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -135,6 +200,16 @@ func (in *GDPSpec) DeepCopy() *GDPSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GDPStatus) DeepCopyInto(out *GDPStatus) {
 	*out = *in
+	if in.GslbServices != nil {
+		in, out := &in.GslbServices, &out.GslbServices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -154,7 +229,7 @@ func (in *GlobalDeploymentPolicy) DeepCopyInto(out *GlobalDeploymentPolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -227,6 +302,37 @@ func (in *MatchRules) DeepCopy() *MatchRules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedPolicyGuardrails) DeepCopyInto(out *NamespacedPolicyGuardrails) {
+	*out = *in
+	if in.AllowedClusters != nil {
+		in, out := &in.AllowedClusters, &out.AllowedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TTLRange != nil {
+		in, out := &in.TTLRange, &out.TTLRange
+		*out = new(TTLRange)
+		**out = **in
+	}
+	if in.AllowedHealthMonitorRefs != nil {
+		in, out := &in.AllowedHealthMonitorRefs, &out.AllowedHealthMonitorRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedPolicyGuardrails.
+func (in *NamespacedPolicyGuardrails) DeepCopy() *NamespacedPolicyGuardrails {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedPolicyGuardrails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceSelector) DeepCopyInto(out *NamespaceSelector) {
 	*out = *in
@@ -237,6 +343,13 @@ func (in *NamespaceSelector) DeepCopyInto(out *NamespaceSelector) {
 			(*out)[key] = val
 		}
 	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -250,6 +363,22 @@ func (in *NamespaceSelector) DeepCopy() *NamespaceSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TTLRange) DeepCopyInto(out *TTLRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TTLRange.
+func (in *TTLRange) DeepCopy() *TTLRange {
+	if in == nil {
+		return nil
+	}
+	out := new(TTLRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficSplitElem) DeepCopyInto(out *TrafficSplitElem) {
 	*out = *in
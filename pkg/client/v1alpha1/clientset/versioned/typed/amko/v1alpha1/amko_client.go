@@ -30,6 +30,7 @@ type AmkoV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	GSLBConfigsGetter
 	GSLBHostRulesGetter
+	GSLBTrafficShiftsGetter
 }
 
 // AmkoV1alpha1Client is used to interact with features provided by the amko.vmware.com group.
@@ -45,6 +46,10 @@ func (c *AmkoV1alpha1Client) GSLBHostRules(namespace string) GSLBHostRuleInterfa
 	return newGSLBHostRules(c, namespace)
 }
 
+func (c *AmkoV1alpha1Client) GSLBTrafficShifts(namespace string) GSLBTrafficShiftInterface {
+	return newGSLBTrafficShifts(c, namespace)
+}
+
 // NewForConfig creates a new AmkoV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
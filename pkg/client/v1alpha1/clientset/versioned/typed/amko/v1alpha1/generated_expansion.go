@@ -21,3 +21,5 @@ package v1alpha1
 type GSLBConfigExpansion interface{}
 
 type GSLBHostRuleExpansion interface{}
+
+type GSLBTrafficShiftExpansion interface{}
@@ -28,6 +28,8 @@ type Interface interface {
 	GSLBConfigs() GSLBConfigInformer
 	// GSLBHostRules returns a GSLBHostRuleInformer.
 	GSLBHostRules() GSLBHostRuleInformer
+	// GSLBTrafficShifts returns a GSLBTrafficShiftInformer.
+	GSLBTrafficShifts() GSLBTrafficShiftInformer
 }
 
 type version struct {
@@ -50,3 +52,8 @@ func (v *version) GSLBConfigs() GSLBConfigInformer {
 func (v *version) GSLBHostRules() GSLBHostRuleInformer {
 	return &gSLBHostRuleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// GSLBTrafficShifts returns a GSLBTrafficShiftInformer.
+func (v *version) GSLBTrafficShifts() GSLBTrafficShiftInformer {
+	return &gSLBTrafficShiftInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -57,6 +57,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Amko().V1alpha1().GSLBConfigs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("gslbhostrules"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Amko().V1alpha1().GSLBHostRules().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("gslbtrafficshifts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Amko().V1alpha1().GSLBTrafficShifts().Informer()}, nil
 
 	}
 
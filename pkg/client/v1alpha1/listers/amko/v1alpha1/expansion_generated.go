@@ -33,3 +33,11 @@ type GSLBHostRuleListerExpansion interface{}
 // GSLBHostRuleNamespaceListerExpansion allows custom methods to be added to
 // GSLBHostRuleNamespaceLister.
 type GSLBHostRuleNamespaceListerExpansion interface{}
+
+// GSLBTrafficShiftListerExpansion allows custom methods to be added to
+// GSLBTrafficShiftLister.
+type GSLBTrafficShiftListerExpansion interface{}
+
+// GSLBTrafficShiftNamespaceListerExpansion allows custom methods to be added to
+// GSLBTrafficShiftNamespaceLister.
+type GSLBTrafficShiftNamespaceListerExpansion interface{}